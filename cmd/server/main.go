@@ -19,6 +19,10 @@ import (
 // @in header
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
+// @securityDefinitions.apikey APIKeyAuth
+// @in header
+// @name X-API-Key
+// @description API key created at /v1/api-keys.
 // @securityDefinitions.basic BasicAuth
 func main() {
 	v := validator.New()
@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/apikey"
+)
+
+type CreateAPIKeyResponse struct {
+	*apikey.CreateAPIKeyUseCaseOutput
+}
+
+type CreateAPIKeyRequest struct {
+	*apikey.CreateAPIKeyUseCaseInput
+}
+
+type ListAPIKeysResponse struct {
+	*apikey.ListAPIKeysUseCaseOutput
+}
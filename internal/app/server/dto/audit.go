@@ -0,0 +1,9 @@
+package dto
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auditlog"
+)
+
+type ListAuditEventsResponse struct {
+	*auditlog.ListAuditEventsUseCaseOutput
+}
@@ -11,3 +11,51 @@ type LoginResponse struct {
 type LoginRequest struct {
 	*auth.LoginUseCaseInput
 }
+
+type RegisterResponse struct {
+	*auth.RegisterUseCaseOutput
+}
+
+type RegisterRequest struct {
+	*auth.RegisterUseCaseInput
+}
+
+type RefreshResponse struct {
+	*auth.RefreshUseCaseOutput
+}
+
+type RefreshRequest struct {
+	*auth.RefreshUseCaseInput
+}
+
+type RevokeSessionsResponse struct {
+	*auth.RevokeSessionsUseCaseOutput
+}
+
+type UpdateUserRolesResponse struct {
+	*auth.UpdateUserRolesUseCaseOutput
+}
+
+type UpdateUserRolesRequest struct {
+	*auth.UpdateUserRolesUseCaseInput
+}
+
+type FinishOIDCLoginResponse struct {
+	*auth.FinishOIDCLoginUseCaseOutput
+}
+
+type ForgotPasswordRequest struct {
+	*auth.ForgotPasswordUseCaseInput
+}
+
+type ResetPasswordRequest struct {
+	*auth.ResetPasswordUseCaseInput
+}
+
+type VerifyEmailRequest struct {
+	*auth.VerifyEmailUseCaseInput
+}
+
+type ListLoginLockoutsResponse struct {
+	*auth.ListLoginLockoutsUseCaseOutput
+}
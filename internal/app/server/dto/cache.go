@@ -0,0 +1,23 @@
+package dto
+
+import "github.com/danielmesquitta/flight-api/internal/domain/usecase/cacheadmin"
+
+type GetCacheStatsResponse struct {
+	*cacheadmin.GetCacheStatsUseCaseOutput
+}
+
+type GetCacheWarmerStatusResponse struct {
+	*cacheadmin.GetCacheWarmerStatusUseCaseOutput
+}
+
+type ListCacheKeysResponse struct {
+	*cacheadmin.ListCacheKeysUseCaseOutput
+}
+
+type PurgeCacheKeysResponse struct {
+	*cacheadmin.PurgeCacheKeysUseCaseOutput
+}
+
+type FlushRateLimitResponse struct {
+	*cacheadmin.FlushRateLimitUseCaseOutput
+}
@@ -0,0 +1,7 @@
+package dto
+
+import "github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
+
+type JWKSResponse struct {
+	jwtutil.JWKS
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/organization"
+)
+
+type CreateOrganizationRequest struct {
+	*organization.CreateOrganizationUseCaseInput
+}
+
+type CreateOrganizationResponse struct {
+	*organization.CreateOrganizationUseCaseOutput
+}
+
+type ListOrganizationsResponse struct {
+	*organization.ListOrganizationsUseCaseOutput
+}
+
+type ListOrganizationProvidersResponse struct {
+	*organization.ListOrganizationProvidersUseCaseOutput
+}
+
+type ConfigureProviderRequest struct {
+	*organization.ConfigureProviderUseCaseInput
+}
+
+type ConfigureProviderResponse struct {
+	*organization.ConfigureProviderUseCaseOutput
+}
+
+type UpdateUserOrganizationRequest struct {
+	*organization.UpdateUserOrganizationUseCaseInput
+}
+
+type UpdateUserOrganizationResponse struct {
+	*organization.UpdateUserOrganizationUseCaseOutput
+}
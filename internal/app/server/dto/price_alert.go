@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/pricealert"
+)
+
+type CreatePriceAlertRequest struct {
+	*pricealert.CreatePriceAlertUseCaseInput
+}
+
+type CreatePriceAlertResponse struct {
+	*pricealert.CreatePriceAlertUseCaseOutput
+}
+
+type ListPriceAlertsResponse struct {
+	*pricealert.ListPriceAlertsUseCaseOutput
+}
+
+type ListPriceAlertChecksResponse struct {
+	*pricealert.ListPriceAlertChecksUseCaseOutput
+}
@@ -0,0 +1,7 @@
+package dto
+
+import "github.com/danielmesquitta/flight-api/internal/domain/usecase/flight"
+
+type ListProvidersUsageResponse struct {
+	*flight.ListProvidersUsageUseCaseOutput
+}
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/savedsearch"
+)
+
+type CreateSavedSearchRequest struct {
+	*savedsearch.CreateSavedSearchUseCaseInput
+}
+
+type CreateSavedSearchResponse struct {
+	*savedsearch.CreateSavedSearchUseCaseOutput
+}
+
+type ListSavedSearchesResponse struct {
+	*savedsearch.ListSavedSearchesUseCaseOutput
+}
+
+type RunSavedSearchResponse struct {
+	*savedsearch.RunSavedSearchUseCaseOutput
+}
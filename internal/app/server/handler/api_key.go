@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/app/server/dto"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/apikey"
+	"github.com/gofiber/fiber/v2"
+)
+
+type APIKeyHandler struct {
+	cuc *apikey.CreateAPIKeyUseCase
+	luc *apikey.ListAPIKeysUseCase
+	ruc *apikey.RevokeAPIKeyUseCase
+}
+
+func NewAPIKeyHandler(
+	cuc *apikey.CreateAPIKeyUseCase,
+	luc *apikey.ListAPIKeysUseCase,
+	ruc *apikey.RevokeAPIKeyUseCase,
+) *APIKeyHandler {
+	return &APIKeyHandler{
+		cuc: cuc,
+		luc: luc,
+		ruc: ruc,
+	}
+}
+
+// @Summary Create API key
+// @Description Create an API key for server-to-server requests, sent in the X-API-Key header. The key is only returned once
+// @Tags API Key
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateAPIKeyRequest true "Request body"
+// @Success 201 {object} dto.CreateAPIKeyResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/api-keys [post]
+func (h *APIKeyHandler) Create(c *fiber.Ctx) error {
+	req := dto.CreateAPIKeyRequest{}
+	if err := c.BodyParser(&req); err != nil {
+		return errs.New(err)
+	}
+
+	in := *req.CreateAPIKeyUseCaseInput
+	in.UserID = GetClaims(c).Subject
+
+	out, err := h.cuc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.CreateAPIKeyResponse{
+		CreateAPIKeyUseCaseOutput: out,
+	})
+}
+
+// @Summary List API keys
+// @Description List the API keys of the user, newest first
+// @Tags API Key
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.ListAPIKeysResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/api-keys [get]
+func (h *APIKeyHandler) List(c *fiber.Ctx) error {
+	in := apikey.ListAPIKeysUseCaseInput{
+		UserID: GetClaims(c).Subject,
+	}
+
+	out, err := h.luc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.ListAPIKeysResponse{
+		ListAPIKeysUseCaseOutput: out,
+	})
+}
+
+// @Summary Revoke API key
+// @Description Delete an API key of the user, which can't be used anymore
+// @Tags API Key
+// @Security BearerAuth
+// @Produce json
+// @Param api_key_id path string true "API key ID"
+// @Success 204
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/api-keys/{api_key_id} [delete]
+func (h *APIKeyHandler) Revoke(c *fiber.Ctx) error {
+	in := apikey.RevokeAPIKeyUseCaseInput{
+		UserID: GetClaims(c).Subject,
+		ID:     c.Params(PathParamAPIKeyID),
+	}
+
+	if err := h.ruc.Execute(c.UserContext(), in); err != nil {
+		return errs.New(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/app/server/dto"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auditlog"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuditHandler struct {
+	laeuc *auditlog.ListAuditEventsUseCase
+}
+
+func NewAuditHandler(
+	laeuc *auditlog.ListAuditEventsUseCase,
+) *AuditHandler {
+	return &AuditHandler{
+		laeuc: laeuc,
+	}
+}
+
+// @Summary List audit events
+// @Description List the audit events of authentication and admin actions matching the filters, newest first
+// @Tags Admin
+// @Security BasicAuth
+// @Security BearerAuth
+// @Produce json
+// @Param actor query string false "Actor, e.g. user:{id}, apikey:{id}, admin:{username} or email:{address}"
+// @Param ip query string false "IP address the actions were sent from"
+// @Param action query string false "Action, e.g. auth.login or admin.cache_purge"
+// @Param target query string false "Target of the actions, e.g. user:{id} or the URL of admin requests"
+// @Param outcome query string false "Outcome of the actions" Enums(success, failure)
+// @Param from query string false "Earliest time of the events, inclusive (RFC 3339)"
+// @Param to query string false "Latest time of the events, exclusive (RFC 3339)"
+// @Param limit query int false "Maximum number of events, up to 1000 (defaults to 100)"
+// @Success 200 {object} dto.ListAuditEventsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/admin/audit-events [get]
+func (h *AuditHandler) List(c *fiber.Ctx) error {
+	in := auditlog.ListAuditEventsUseCaseInput{
+		Actor:   c.Query(QueryParamActor),
+		IP:      c.Query(QueryParamIP),
+		Action:  c.Query(QueryParamAction),
+		Target:  c.Query(QueryParamTarget),
+		Outcome: c.Query(QueryParamOutcome),
+		Limit:   c.QueryInt(QueryParamLimit),
+	}
+
+	if c.Query(QueryParamFrom) != "" {
+		from, err := parseDateQueryParam(c, QueryParamFrom)
+		if err != nil {
+			return errs.New(err)
+		}
+		in.From = from
+	}
+
+	if c.Query(QueryParamTo) != "" {
+		to, err := parseDateQueryParam(c, QueryParamTo)
+		if err != nil {
+			return errs.New(err)
+		}
+		in.To = to
+	}
+
+	out, err := h.laeuc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.ListAuditEventsResponse{
+		ListAuditEventsUseCaseOutput: out,
+	})
+}
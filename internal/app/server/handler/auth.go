@@ -8,19 +8,58 @@ import (
 )
 
 type AuthHandler struct {
-	luc *auth.LoginUseCase
+	luc  *auth.LoginUseCase
+	ruc  *auth.RegisterUseCase
+	fuc  *auth.RefreshUseCase
+	louc *auth.LogoutUseCase
+	rsuc *auth.RevokeSessionsUseCase
+	uruc *auth.UpdateUserRolesUseCase
+	souc *auth.StartOIDCLoginUseCase
+	fouc *auth.FinishOIDCLoginUseCase
+	seuc *auth.SendEmailVerificationUseCase
+	veuc *auth.VerifyEmailUseCase
+	fpuc *auth.ForgotPasswordUseCase
+	rpuc *auth.ResetPasswordUseCase
+	lluc *auth.ListLoginLockoutsUseCase
+	uluc *auth.UnlockLoginUseCase
 }
 
 func NewAuthHandler(
 	luc *auth.LoginUseCase,
+	ruc *auth.RegisterUseCase,
+	fuc *auth.RefreshUseCase,
+	louc *auth.LogoutUseCase,
+	rsuc *auth.RevokeSessionsUseCase,
+	uruc *auth.UpdateUserRolesUseCase,
+	souc *auth.StartOIDCLoginUseCase,
+	fouc *auth.FinishOIDCLoginUseCase,
+	seuc *auth.SendEmailVerificationUseCase,
+	veuc *auth.VerifyEmailUseCase,
+	fpuc *auth.ForgotPasswordUseCase,
+	rpuc *auth.ResetPasswordUseCase,
+	lluc *auth.ListLoginLockoutsUseCase,
+	uluc *auth.UnlockLoginUseCase,
 ) *AuthHandler {
 	return &AuthHandler{
-		luc: luc,
+		luc:  luc,
+		ruc:  ruc,
+		fuc:  fuc,
+		louc: louc,
+		rsuc: rsuc,
+		uruc: uruc,
+		souc: souc,
+		fouc: fouc,
+		seuc: seuc,
+		veuc: veuc,
+		fpuc: fpuc,
+		rpuc: rpuc,
+		lluc: lluc,
+		uluc: uluc,
 	}
 }
 
 // @Summary Login
-// @Description Use e-mail and password to login
+// @Description Use e-mail and password to login. Each failure delays the next login of the account, and too many lock the account or IP address out for a while
 // @Tags Auth
 // @Accept json
 // @Produce json
@@ -28,7 +67,7 @@ func NewAuthHandler(
 // @Success 200 {object} dto.LoginResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /v1/auth/login [post]
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
@@ -37,7 +76,10 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return errs.New(err)
 	}
 
-	out, err := h.luc.Execute(c.UserContext(), *req.LoginUseCaseInput)
+	in := *req.LoginUseCaseInput
+	in.IP = c.IP()
+
+	out, err := h.luc.Execute(c.UserContext(), in)
 	if err != nil {
 		return errs.New(err)
 	}
@@ -46,3 +88,346 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		LoginUseCaseOutput: out,
 	})
 }
+
+// @Summary Register
+// @Description Create a user with e-mail and password
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RegisterRequest true "Request body"
+// @Success 201 {object} dto.RegisterResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/auth/register [post]
+func (h *AuthHandler) Register(c *fiber.Ctx) error {
+	req := dto.RegisterRequest{}
+	if err := c.BodyParser(&req); err != nil {
+		return errs.New(err)
+	}
+
+	out, err := h.ruc.Execute(c.UserContext(), *req.RegisterUseCaseInput)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.RegisterResponse{
+		RegisterUseCaseOutput: out,
+	})
+}
+
+// @Summary Refresh
+// @Description Exchange a refresh token for new access and refresh tokens. Refresh tokens are single-use, and replaying one revokes every token issued from the same login
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshRequest true "Request body"
+// @Success 200 {object} dto.RefreshResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/auth/refresh [post]
+func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
+	req := dto.RefreshRequest{}
+	if err := c.BodyParser(&req); err != nil {
+		return errs.New(err)
+	}
+
+	out, err := h.fuc.Execute(c.UserContext(), *req.RefreshUseCaseInput)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.RefreshResponse{
+		RefreshUseCaseOutput: out,
+	})
+}
+
+// @Summary Logout
+// @Description Revoke the access token, and every refresh token issued from the same login
+// @Tags Auth
+// @Security BearerAuth
+// @Produce json
+// @Success 204
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/auth/logout [post]
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	in := auth.LogoutUseCaseInput{
+		Claims: GetClaims(c),
+	}
+
+	if err := h.louc.Execute(c.UserContext(), in); err != nil {
+		return errs.New(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Revoke user sessions
+// @Description Revoke every access and refresh token issued to a user so far
+// @Tags Admin
+// @Security BasicAuth
+// @Security BearerAuth
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Success 200 {object} dto.RevokeSessionsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/admin/users/{user_id}/sessions [delete]
+func (h *AuthHandler) RevokeSessions(c *fiber.Ctx) error {
+	in := auth.RevokeSessionsUseCaseInput{
+		UserID: c.Params(PathParamUserID),
+	}
+
+	out, err := h.rsuc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.RevokeSessionsResponse{
+		RevokeSessionsUseCaseOutput: out,
+	})
+}
+
+// @Summary Update user roles
+// @Description Replace the roles of a user, granted to its next access tokens
+// @Tags Admin
+// @Security BasicAuth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Param request body dto.UpdateUserRolesRequest true "Request body"
+// @Success 200 {object} dto.UpdateUserRolesResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/admin/users/{user_id}/roles [put]
+func (h *AuthHandler) UpdateRoles(c *fiber.Ctx) error {
+	req := dto.UpdateUserRolesRequest{}
+	if err := c.BodyParser(&req); err != nil {
+		return errs.New(err)
+	}
+
+	in := *req.UpdateUserRolesUseCaseInput
+	in.UserID = c.Params(PathParamUserID)
+
+	out, err := h.uruc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.UpdateUserRolesResponse{
+		UpdateUserRolesUseCaseOutput: out,
+	})
+}
+
+// @Summary Start OpenID Connect login
+// @Description Redirect to the identity provider to sign in with the authorization code flow with PKCE
+// @Tags Auth
+// @Param provider path string true "Identity provider name"
+// @Success 302
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/auth/oidc/{provider}/start [get]
+func (h *AuthHandler) StartOIDCLogin(c *fiber.Ctx) error {
+	in := auth.StartOIDCLoginUseCaseInput{
+		Provider: c.Params(PathParamProvider),
+	}
+
+	out, err := h.souc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.Redirect(out.URL, fiber.StatusFound)
+}
+
+// @Summary Finish OpenID Connect login
+// @Description Sign in with the code the identity provider redirected back with, creating the user on their first login
+// @Tags Auth
+// @Produce json
+// @Param provider path string true "Identity provider name"
+// @Param code query string true "Authorization code"
+// @Param state query string true "State given to the identity provider"
+// @Success 200 {object} dto.FinishOIDCLoginResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/auth/oidc/{provider}/callback [get]
+func (h *AuthHandler) FinishOIDCLogin(c *fiber.Ctx) error {
+	in := auth.FinishOIDCLoginUseCaseInput{
+		Provider: c.Params(PathParamProvider),
+		Code:     c.Query(QueryParamCode),
+		State:    c.Query(QueryParamState),
+	}
+
+	out, err := h.fouc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.FinishOIDCLoginResponse{
+		FinishOIDCLoginUseCaseOutput: out,
+	})
+}
+
+// @Summary Forgot password
+// @Description E-mail a single-use link to reset the password. Succeeds for unknown e-mails as well
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ForgotPasswordRequest true "Request body"
+// @Success 202
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/auth/password/forgot [post]
+func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
+	req := dto.ForgotPasswordRequest{}
+	if err := c.BodyParser(&req); err != nil {
+		return errs.New(err)
+	}
+
+	err := h.fpuc.Execute(c.UserContext(), *req.ForgotPasswordUseCaseInput)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
+
+// @Summary Reset password
+// @Description Set a new password with the token e-mailed by forgot password, ending every session of the user
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ResetPasswordRequest true "Request body"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/auth/password/reset [post]
+func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
+	req := dto.ResetPasswordRequest{}
+	if err := c.BodyParser(&req); err != nil {
+		return errs.New(err)
+	}
+
+	err := h.rpuc.Execute(c.UserContext(), *req.ResetPasswordUseCaseInput)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Send e-mail verification
+// @Description E-mail the signed in user another single-use link to verify their e-mail
+// @Tags Auth
+// @Security BearerAuth
+// @Produce json
+// @Success 202
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/auth/email/verification [post]
+func (h *AuthHandler) SendEmailVerification(c *fiber.Ctx) error {
+	in := auth.SendEmailVerificationUseCaseInput{
+		UserID: GetClaims(c).Subject,
+	}
+
+	if err := h.seuc.Execute(c.UserContext(), in); err != nil {
+		return errs.New(err)
+	}
+
+	return c.SendStatus(fiber.StatusAccepted)
+}
+
+// @Summary Verify e-mail
+// @Description Verify the e-mail of a user with the token e-mailed to them
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body dto.VerifyEmailRequest true "Request body"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/auth/email/verify [post]
+func (h *AuthHandler) VerifyEmail(c *fiber.Ctx) error {
+	req := dto.VerifyEmailRequest{}
+	if err := c.BodyParser(&req); err != nil {
+		return errs.New(err)
+	}
+
+	err := h.veuc.Execute(c.UserContext(), *req.VerifyEmailUseCaseInput)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary List login lockouts
+// @Description List the accounts and IP addresses locked out after too many failed logins
+// @Tags Admin
+// @Security BasicAuth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.ListLoginLockoutsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/admin/login-lockouts [get]
+func (h *AuthHandler) ListLoginLockouts(c *fiber.Ctx) error {
+	out, err := h.lluc.Execute(c.UserContext())
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.ListLoginLockoutsResponse{
+		ListLoginLockoutsUseCaseOutput: out,
+	})
+}
+
+// @Summary Unlock login
+// @Description Forget the failed logins of an account or IP address, letting it login again right away
+// @Tags Admin
+// @Security BasicAuth
+// @Security BearerAuth
+// @Produce json
+// @Param kind path string true "Lockout kind" Enums(account, ip)
+// @Param subject path string true "E-mail of the account or IP address"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/admin/login-lockouts/{kind}/{subject} [delete]
+func (h *AuthHandler) UnlockLogin(c *fiber.Ctx) error {
+	in := auth.UnlockLoginUseCaseInput{
+		Kind:    c.Params(PathParamKind),
+		Subject: c.Params(PathParamSubject),
+	}
+
+	if err := h.uluc.Execute(c.UserContext(), in); err != nil {
+		return errs.New(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/app/server/dto"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/cacheadmin"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CacheHandler struct {
+	gcsuc  *cacheadmin.GetCacheStatsUseCase
+	gcwsuc *cacheadmin.GetCacheWarmerStatusUseCase
+	lckuc  *cacheadmin.ListCacheKeysUseCase
+	pckuc  *cacheadmin.PurgeCacheKeysUseCase
+	frluc  *cacheadmin.FlushRateLimitUseCase
+}
+
+func NewCacheHandler(
+	gcsuc *cacheadmin.GetCacheStatsUseCase,
+	gcwsuc *cacheadmin.GetCacheWarmerStatusUseCase,
+	lckuc *cacheadmin.ListCacheKeysUseCase,
+	pckuc *cacheadmin.PurgeCacheKeysUseCase,
+	frluc *cacheadmin.FlushRateLimitUseCase,
+) *CacheHandler {
+	return &CacheHandler{
+		gcsuc:  gcsuc,
+		gcwsuc: gcwsuc,
+		lckuc:  lckuc,
+		pckuc:  pckuc,
+		frluc:  frluc,
+	}
+}
+
+// @Summary Cache stats
+// @Description Report hits, misses and hit ratio of each cache tier
+// @Tags Admin
+// @Security BasicAuth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.GetCacheStatsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/admin/cache/stats [get]
+func (h *CacheHandler) Stats(c *fiber.Ctx) error {
+	out, err := h.gcsuc.Execute(c.UserContext())
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.GetCacheStatsResponse{
+		GetCacheStatsUseCaseOutput: out,
+	})
+}
+
+// @Summary Cache warmer status
+// @Description Report the schedule of the cache warmer and its last run
+// @Tags Admin
+// @Security BasicAuth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Success 200 {object} dto.GetCacheWarmerStatusResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/admin/cache/warmer [get]
+func (h *CacheHandler) Warmer(c *fiber.Ctx) error {
+	out, err := h.gcwsuc.Execute(c.UserContext())
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.GetCacheWarmerStatusResponse{
+		GetCacheWarmerStatusUseCaseOutput: out,
+	})
+}
+
+// @Summary List cache keys
+// @Description List cache keys matching a pattern, or the cached searches of an organization, route and date, with their TTL in seconds (-1 if they never expire) and size in bytes
+// @Tags Admin
+// @Security BasicAuth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param pattern query string false "Glob-style key pattern, e.g. flightapi:flights:*"
+// @Param organization_id query string false "Organization of the cached searches, or default for the default tenant"
+// @Param origin query string false "Origin airport code of the cached searches"
+// @Param destination query string false "Destination airport code of the cached searches"
+// @Param date query string false "Departure date of the cached searches (YYYY-MM-DD)"
+// @Param limit query int false "Maximum number of keys, up to 1000 (defaults to 100)"
+// @Success 200 {object} dto.ListCacheKeysResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/admin/cache/keys [get]
+func (h *CacheHandler) Keys(c *fiber.Ctx) error {
+	filter, err := parseCacheKeysFilter(c)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	in := cacheadmin.ListCacheKeysUseCaseInput{
+		CacheKeysFilter: filter,
+		Limit:           c.QueryInt(QueryParamLimit),
+	}
+
+	out, err := h.lckuc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.ListCacheKeysResponse{
+		ListCacheKeysUseCaseOutput: out,
+	})
+}
+
+// @Summary Purge cache keys
+// @Description Delete cache keys matching a pattern, or the cached searches of an organization, route and date
+// @Tags Admin
+// @Security BasicAuth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param pattern query string false "Glob-style key pattern, e.g. flightapi:flights:*"
+// @Param organization_id query string false "Organization of the cached searches, or default for the default tenant"
+// @Param origin query string false "Origin airport code of the cached searches"
+// @Param destination query string false "Destination airport code of the cached searches"
+// @Param date query string false "Departure date of the cached searches (YYYY-MM-DD)"
+// @Success 200 {object} dto.PurgeCacheKeysResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/admin/cache/keys [delete]
+func (h *CacheHandler) Purge(c *fiber.Ctx) error {
+	filter, err := parseCacheKeysFilter(c)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	in := cacheadmin.PurgeCacheKeysUseCaseInput{
+		CacheKeysFilter: filter,
+	}
+
+	out, err := h.pckuc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.PurgeCacheKeysResponse{
+		PurgeCacheKeysUseCaseOutput: out,
+	})
+}
+
+// @Summary Flush client rate limit
+// @Description Delete the rate limit counters of a client, allowing its requests again
+// @Tags Admin
+// @Security BasicAuth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param client path string true "Client identifier, as user:{subject}, apikey:{id} or ip:{address}"
+// @Success 200 {object} dto.FlushRateLimitResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/admin/cache/rate-limits/{client} [delete]
+func (h *CacheHandler) FlushRateLimit(c *fiber.Ctx) error {
+	in := cacheadmin.FlushRateLimitUseCaseInput{
+		Client: c.Params(PathParamClient),
+	}
+
+	out, err := h.frluc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.FlushRateLimitResponse{
+		FlushRateLimitUseCaseOutput: out,
+	})
+}
+
+func parseCacheKeysFilter(
+	c *fiber.Ctx,
+) (cacheadmin.CacheKeysFilter, error) {
+	filter := cacheadmin.CacheKeysFilter{
+		Pattern:        c.Query(QueryParamPattern),
+		OrganizationID: c.Query(QueryParamOrganizationID),
+		Origin:         c.Query(QueryParamOrigin),
+		Destination:    c.Query(QueryParamDestination),
+	}
+
+	if c.Query(QueryParamDate) != "" {
+		date, err := parseDateQueryParam(c, QueryParamDate)
+		if err != nil {
+			return filter, errs.New(err)
+		}
+		filter.Date = date
+	}
+
+	return filter, nil
+}
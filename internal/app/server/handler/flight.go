@@ -23,6 +23,7 @@ func NewFlightHandler(
 // @Description Search for flights based on origin, destination, and date
 // @Tags Flight
 // @Security BearerAuth
+// @Security APIKeyAuth
 // @Accept json
 // @Produce json
 // @Param origin query string true "Origin airport code"
@@ -30,9 +31,14 @@ func NewFlightHandler(
 // @Param date query string true "Departure date (YYYY-MM-DD)"
 // @Param sort_by query string false "Sort by field (price or duration)"
 // @Param sort_order query string false "Sort order (asc or desc)"
+// @Param max_price query int false "Maximum price in cents"
+// @Param max_duration query int false "Maximum duration in minutes"
+// @Param page query int false "Page number, starting at 1"
+// @Param page_size query int false "Page size, up to 100 (defaults to 50)"
 // @Success 200 {object} dto.SearchFlightsResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /v1/flights/search [get]
@@ -45,6 +51,10 @@ func (h *FlightHandler) Search(c *fiber.Ctx) error {
 	}
 	sortBy := c.Query(QueryParamSortBy)
 	sortOrder := c.Query(QueryParamSortOrder)
+	maxPrice := c.QueryInt(QueryParamMaxPrice)
+	maxDuration := c.QueryInt(QueryParamMaxDuration)
+	page := c.QueryInt(QueryParamPage)
+	pageSize := c.QueryInt(QueryParamPageSize)
 
 	in := flight.SearchFlightsUseCaseInput{
 		Origin:      origin,
@@ -52,6 +62,11 @@ func (h *FlightHandler) Search(c *fiber.Ctx) error {
 		Date:        date,
 		SortBy:      sortBy,
 		SortOrder:   sortOrder,
+		MaxPrice:    int64(maxPrice),
+		MaxDuration: int64(maxDuration),
+		Page:        page,
+		PageSize:    pageSize,
+		TenantID:    GetClaims(c).TenantID,
 	}
 
 	out, err := h.sfuc.Execute(c.UserContext(), in)
@@ -8,17 +8,46 @@ import (
 	"github.com/danielmesquitta/flight-api/internal/domain/errs"
 	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
 	"github.com/gofiber/fiber/v2"
-	"github.com/golang-jwt/jwt/v5"
 )
 
 type QueryParam = string
 
 const (
-	QueryParamOrigin      QueryParam = "origin"
-	QueryParamDestination QueryParam = "destination"
-	QueryParamDate        QueryParam = "date"
-	QueryParamSortBy      QueryParam = "sort_by"
-	QueryParamSortOrder   QueryParam = "sort_order"
+	QueryParamOrigin         QueryParam = "origin"
+	QueryParamDestination    QueryParam = "destination"
+	QueryParamDate           QueryParam = "date"
+	QueryParamSortBy         QueryParam = "sort_by"
+	QueryParamSortOrder      QueryParam = "sort_order"
+	QueryParamMaxPrice       QueryParam = "max_price"
+	QueryParamMaxDuration    QueryParam = "max_duration"
+	QueryParamPage           QueryParam = "page"
+	QueryParamPageSize       QueryParam = "page_size"
+	QueryParamPattern        QueryParam = "pattern"
+	QueryParamLimit          QueryParam = "limit"
+	QueryParamCode           QueryParam = "code"
+	QueryParamState          QueryParam = "state"
+	QueryParamOrganizationID QueryParam = "organization_id"
+	QueryParamActor          QueryParam = "actor"
+	QueryParamIP             QueryParam = "ip"
+	QueryParamAction         QueryParam = "action"
+	QueryParamTarget         QueryParam = "target"
+	QueryParamOutcome        QueryParam = "outcome"
+	QueryParamFrom           QueryParam = "from"
+	QueryParamTo             QueryParam = "to"
+)
+
+type PathParam = string
+
+const (
+	PathParamClient         PathParam = "client"
+	PathParamUserID         PathParam = "user_id"
+	PathParamAPIKeyID       PathParam = "api_key_id"
+	PathParamProvider       PathParam = "provider"
+	PathParamKind           PathParam = "kind"
+	PathParamSubject        PathParam = "subject"
+	PathParamOrganizationID PathParam = "organization_id"
+	PathParamSavedSearchID  PathParam = "saved_search_id"
+	PathParamPriceAlertID   PathParam = "price_alert_id"
 )
 
 func parseDateQueryParam(
@@ -33,26 +62,11 @@ func parseDateQueryParam(
 	return parsedDate, nil
 }
 
+// GetClaims returns the claims the request was authenticated with, if
+// any, stored by the authentication middleware.
 func GetClaims(
 	c *fiber.Ctx,
 ) *jwtutil.UserClaims {
-	token, ok := c.Locals(jwtutil.ClaimsKey).(*jwt.Token)
-	if !ok {
-		return nil
-	}
-
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return nil
-	}
-
-	issuer, _ := claims.GetIssuer()
-	issuedAt, _ := claims.GetIssuedAt()
-	expiresAt, _ := claims.GetExpirationTime()
-
-	return &jwtutil.UserClaims{
-		Issuer:    issuer,
-		IssuedAt:  issuedAt.Time,
-		ExpiresAt: expiresAt.Time,
-	}
+	claims, _ := c.Locals(jwtutil.ClaimsKey).(*jwtutil.UserClaims)
+	return claims
 }
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/app/server/dto"
+	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
+	"github.com/gofiber/fiber/v2"
+)
+
+type JWKSHandler struct {
+	j *jwtutil.JWT
+}
+
+func NewJWKSHandler(
+	j *jwtutil.JWT,
+) *JWKSHandler {
+	return &JWKSHandler{
+		j: j,
+	}
+}
+
+// Get serves the public keys access tokens are verified with, for other
+// services to verify them without the secret. It is served out of the
+// API base path, so it is left out of the docs.
+func (h *JWKSHandler) Get(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(dto.JWKSResponse{
+		JWKS: h.j.JWKS(),
+	})
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/app/server/dto"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/organization"
+	"github.com/gofiber/fiber/v2"
+)
+
+type OrganizationHandler struct {
+	couc  *organization.CreateOrganizationUseCase
+	louc  *organization.ListOrganizationsUseCase
+	lopuc *organization.ListOrganizationProvidersUseCase
+	cpuc  *organization.ConfigureProviderUseCase
+	uuouc *organization.UpdateUserOrganizationUseCase
+}
+
+func NewOrganizationHandler(
+	couc *organization.CreateOrganizationUseCase,
+	louc *organization.ListOrganizationsUseCase,
+	lopuc *organization.ListOrganizationProvidersUseCase,
+	cpuc *organization.ConfigureProviderUseCase,
+	uuouc *organization.UpdateUserOrganizationUseCase,
+) *OrganizationHandler {
+	return &OrganizationHandler{
+		couc:  couc,
+		louc:  louc,
+		lopuc: lopuc,
+		cpuc:  cpuc,
+		uuouc: uuouc,
+	}
+}
+
+// @Summary Create organization
+// @Description Create an organization, a tenant searching the flight providers with its own credentials
+// @Tags Admin
+// @Security BasicAuth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateOrganizationRequest true "Request body"
+// @Success 201 {object} dto.CreateOrganizationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/admin/organizations [post]
+func (h *OrganizationHandler) Create(c *fiber.Ctx) error {
+	req := dto.CreateOrganizationRequest{}
+	if err := c.BodyParser(&req); err != nil {
+		return errs.New(err)
+	}
+
+	out, err := h.couc.Execute(
+		c.UserContext(),
+		*req.CreateOrganizationUseCaseInput,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.CreateOrganizationResponse{
+		CreateOrganizationUseCaseOutput: out,
+	})
+}
+
+// @Summary List organizations
+// @Description List every organization, sorted by name
+// @Tags Admin
+// @Security BasicAuth
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} dto.ListOrganizationsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/admin/organizations [get]
+func (h *OrganizationHandler) List(c *fiber.Ctx) error {
+	out, err := h.louc.Execute(c.UserContext())
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.ListOrganizationsResponse{
+		ListOrganizationsUseCaseOutput: out,
+	})
+}
+
+// @Summary List organization providers
+// @Description List the flight providers configured for an organization, without their credentials
+// @Tags Admin
+// @Security BasicAuth
+// @Security BearerAuth
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Success 200 {object} dto.ListOrganizationProvidersResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/admin/organizations/{organization_id}/providers [get]
+func (h *OrganizationHandler) ListProviders(c *fiber.Ctx) error {
+	in := organization.ListOrganizationProvidersUseCaseInput{
+		OrganizationID: c.Params(PathParamOrganizationID),
+	}
+
+	out, err := h.lopuc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.ListOrganizationProvidersResponse{
+		ListOrganizationProvidersUseCaseOutput: out,
+	})
+}
+
+// @Summary Configure organization provider
+// @Description Enable or disable a flight provider for an organization, with its credentials encrypted at rest. Stored credentials are kept if no API key is sent
+// @Tags Admin
+// @Security BasicAuth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param organization_id path string true "Organization ID"
+// @Param provider path string true "Flight provider" Enums(amadeus, serp, duffel)
+// @Param request body dto.ConfigureProviderRequest true "Request body"
+// @Success 200 {object} dto.ConfigureProviderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/admin/organizations/{organization_id}/providers/{provider} [put]
+func (h *OrganizationHandler) ConfigureProvider(c *fiber.Ctx) error {
+	req := dto.ConfigureProviderRequest{}
+	if err := c.BodyParser(&req); err != nil {
+		return errs.New(err)
+	}
+
+	in := *req.ConfigureProviderUseCaseInput
+	in.OrganizationID = c.Params(PathParamOrganizationID)
+	in.Provider = c.Params(PathParamProvider)
+
+	out, err := h.cpuc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.ConfigureProviderResponse{
+		ConfigureProviderUseCaseOutput: out,
+	})
+}
+
+// @Summary Update user organization
+// @Description Move a user to an organization, or back to the default tenant without one, for its next access tokens
+// @Tags Admin
+// @Security BasicAuth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Param request body dto.UpdateUserOrganizationRequest true "Request body"
+// @Success 200 {object} dto.UpdateUserOrganizationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/admin/users/{user_id}/organization [put]
+func (h *OrganizationHandler) UpdateUserOrganization(c *fiber.Ctx) error {
+	req := dto.UpdateUserOrganizationRequest{}
+	if err := c.BodyParser(&req); err != nil {
+		return errs.New(err)
+	}
+
+	in := *req.UpdateUserOrganizationUseCaseInput
+	in.UserID = c.Params(PathParamUserID)
+
+	out, err := h.uuouc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.UpdateUserOrganizationResponse{
+		UpdateUserOrganizationUseCaseOutput: out,
+	})
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/app/server/dto"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/pricealert"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PriceAlertHandler struct {
+	cuc *pricealert.CreatePriceAlertUseCase
+	luc *pricealert.ListPriceAlertsUseCase
+	duc *pricealert.DeletePriceAlertUseCase
+	huc *pricealert.ListPriceAlertChecksUseCase
+}
+
+func NewPriceAlertHandler(
+	cuc *pricealert.CreatePriceAlertUseCase,
+	luc *pricealert.ListPriceAlertsUseCase,
+	duc *pricealert.DeletePriceAlertUseCase,
+	huc *pricealert.ListPriceAlertChecksUseCase,
+) *PriceAlertHandler {
+	return &PriceAlertHandler{
+		cuc: cuc,
+		luc: luc,
+		duc: duc,
+		huc: huc,
+	}
+}
+
+// @Summary Create price alert
+// @Description Watch the cheapest flight of a route and date, to be e-mailed when its price, in cents, drops below the target price or by the drop percent
+// @Tags Price Alert
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreatePriceAlertRequest true "Request body"
+// @Success 201 {object} dto.CreatePriceAlertResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/price-alerts [post]
+func (h *PriceAlertHandler) Create(c *fiber.Ctx) error {
+	req := dto.CreatePriceAlertRequest{}
+	if err := c.BodyParser(&req); err != nil {
+		return errs.New(err)
+	}
+
+	in := *req.CreatePriceAlertUseCaseInput
+	in.UserID = GetClaims(c).Subject
+
+	out, err := h.cuc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.CreatePriceAlertResponse{
+		CreatePriceAlertUseCaseOutput: out,
+	})
+}
+
+// @Summary List price alerts
+// @Description List the price alerts of the user, newest first, with the last price found
+// @Tags Price Alert
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce json
+// @Success 200 {object} dto.ListPriceAlertsResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/price-alerts [get]
+func (h *PriceAlertHandler) List(c *fiber.Ctx) error {
+	in := pricealert.ListPriceAlertsUseCaseInput{
+		UserID: GetClaims(c).Subject,
+	}
+
+	out, err := h.luc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.ListPriceAlertsResponse{
+		ListPriceAlertsUseCaseOutput: out,
+	})
+}
+
+// @Summary Delete price alert
+// @Description Delete a price alert of the user, with its price history
+// @Tags Price Alert
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce json
+// @Param price_alert_id path string true "Price alert ID"
+// @Success 204
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/price-alerts/{price_alert_id} [delete]
+func (h *PriceAlertHandler) Delete(c *fiber.Ctx) error {
+	in := pricealert.DeletePriceAlertUseCaseInput{
+		UserID: GetClaims(c).Subject,
+		ID:     c.Params(PathParamPriceAlertID),
+	}
+
+	if err := h.duc.Execute(c.UserContext(), in); err != nil {
+		return errs.New(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary List price alert checks
+// @Description List the prices found by the checks of a price alert of the user, newest first
+// @Tags Price Alert
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce json
+// @Param price_alert_id path string true "Price alert ID"
+// @Param limit query int false "Max checks to return, 100 by default"
+// @Success 200 {object} dto.ListPriceAlertChecksResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/price-alerts/{price_alert_id}/checks [get]
+func (h *PriceAlertHandler) ListChecks(c *fiber.Ctx) error {
+	in := pricealert.ListPriceAlertChecksUseCaseInput{
+		UserID: GetClaims(c).Subject,
+		ID:     c.Params(PathParamPriceAlertID),
+		Limit:  c.QueryInt(QueryParamLimit),
+	}
+
+	out, err := h.huc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.ListPriceAlertChecksResponse{
+		ListPriceAlertChecksUseCaseOutput: out,
+	})
+}
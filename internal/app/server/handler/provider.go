@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/app/server/dto"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/flight"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProviderHandler struct {
+	lpuuc *flight.ListProvidersUsageUseCase
+}
+
+func NewProviderHandler(
+	lpuuc *flight.ListProvidersUsageUseCase,
+) *ProviderHandler {
+	return &ProviderHandler{
+		lpuuc: lpuuc,
+	}
+}
+
+// @Summary Providers usage
+// @Description Report calls, errors and estimated cost per flight provider in a day
+// @Tags Admin
+// @Security BasicAuth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param date query string false "Day to report (YYYY-MM-DD), defaults to today"
+// @Param organization_id query string false "Organization to report, defaults to the default tenant"
+// @Success 200 {object} dto.ListProvidersUsageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/admin/providers/usage [get]
+func (h *ProviderHandler) Usage(c *fiber.Ctx) error {
+	in := flight.ListProvidersUsageUseCaseInput{
+		TenantID: c.Query(QueryParamOrganizationID),
+	}
+
+	if c.Query(QueryParamDate) != "" {
+		date, err := parseDateQueryParam(c, QueryParamDate)
+		if err != nil {
+			return errs.New(err)
+		}
+		in.Date = date
+	}
+
+	out, err := h.lpuuc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.ListProvidersUsageResponse{
+		ListProvidersUsageUseCaseOutput: out,
+	})
+}
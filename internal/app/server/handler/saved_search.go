@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/app/server/dto"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/savedsearch"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SavedSearchHandler struct {
+	cuc *savedsearch.CreateSavedSearchUseCase
+	luc *savedsearch.ListSavedSearchesUseCase
+	duc *savedsearch.DeleteSavedSearchUseCase
+	ruc *savedsearch.RunSavedSearchUseCase
+}
+
+func NewSavedSearchHandler(
+	cuc *savedsearch.CreateSavedSearchUseCase,
+	luc *savedsearch.ListSavedSearchesUseCase,
+	duc *savedsearch.DeleteSavedSearchUseCase,
+	ruc *savedsearch.RunSavedSearchUseCase,
+) *SavedSearchHandler {
+	return &SavedSearchHandler{
+		cuc: cuc,
+		luc: luc,
+		duc: duc,
+		ruc: ruc,
+	}
+}
+
+// @Summary Create saved search
+// @Description Save a flight search to run again, with a date such as 2025-06-01, today, tomorrow, +30d, +2w, +1m or next friday, resolved each time it runs
+// @Tags Saved Search
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateSavedSearchRequest true "Request body"
+// @Success 201 {object} dto.CreateSavedSearchResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/saved-searches [post]
+func (h *SavedSearchHandler) Create(c *fiber.Ctx) error {
+	req := dto.CreateSavedSearchRequest{}
+	if err := c.BodyParser(&req); err != nil {
+		return errs.New(err)
+	}
+
+	in := *req.CreateSavedSearchUseCaseInput
+	in.UserID = GetClaims(c).Subject
+
+	out, err := h.cuc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.CreateSavedSearchResponse{
+		CreateSavedSearchUseCaseOutput: out,
+	})
+}
+
+// @Summary List saved searches
+// @Description List the saved searches of the user, newest first
+// @Tags Saved Search
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce json
+// @Success 200 {object} dto.ListSavedSearchesResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/saved-searches [get]
+func (h *SavedSearchHandler) List(c *fiber.Ctx) error {
+	in := savedsearch.ListSavedSearchesUseCaseInput{
+		UserID: GetClaims(c).Subject,
+	}
+
+	out, err := h.luc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.ListSavedSearchesResponse{
+		ListSavedSearchesUseCaseOutput: out,
+	})
+}
+
+// @Summary Delete saved search
+// @Description Delete a saved search of the user
+// @Tags Saved Search
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce json
+// @Param saved_search_id path string true "Saved search ID"
+// @Success 204
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/saved-searches/{saved_search_id} [delete]
+func (h *SavedSearchHandler) Delete(c *fiber.Ctx) error {
+	in := savedsearch.DeleteSavedSearchUseCaseInput{
+		UserID: GetClaims(c).Subject,
+		ID:     c.Params(PathParamSavedSearchID),
+	}
+
+	if err := h.duc.Execute(c.UserContext(), in); err != nil {
+		return errs.New(err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Run saved search
+// @Description Search flights with a saved search of the user, for the date it stands for today
+// @Tags Saved Search
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Produce json
+// @Param saved_search_id path string true "Saved search ID"
+// @Param page query int false "Page number, starting at 1"
+// @Success 200 {object} dto.RunSavedSearchResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 429 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /v1/saved-searches/{saved_search_id}/run [post]
+func (h *SavedSearchHandler) Run(c *fiber.Ctx) error {
+	claims := GetClaims(c)
+	in := savedsearch.RunSavedSearchUseCaseInput{
+		UserID:   claims.Subject,
+		ID:       c.Params(PathParamSavedSearchID),
+		Page:     c.QueryInt(QueryParamPage),
+		TenantID: claims.TenantID,
+	}
+
+	out, err := h.ruc.Execute(c.UserContext(), in)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return c.JSON(dto.RunSavedSearchResponse{
+		RunSavedSearchUseCaseOutput: out,
+	})
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/apikey"
+	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
+	"github.com/gofiber/fiber/v2"
+)
+
+const HeaderAPIKey = "X-API-Key"
+
+// BearerAuthOrAPIKey authenticates with the API key of the X-API-Key
+// header when sent, or with a bearer access token otherwise.
+func (m *Middleware) BearerAuthOrAPIKey() fiber.Handler {
+	bearerAuth := m.BearerAuthAccessToken()
+
+	return func(c *fiber.Ctx) error {
+		key := c.Get(HeaderAPIKey)
+		if key == "" {
+			return bearerAuth(c)
+		}
+
+		out, err := m.a.Execute(
+			c.UserContext(),
+			apikey.AuthenticateAPIKeyUseCaseInput{Key: key},
+		)
+		if err != nil {
+			return errs.New(err)
+		}
+
+		c.Locals(jwtutil.ClaimsKey, &jwtutil.UserClaims{
+			Subject:   out.User.ID,
+			Issuer:    out.User.Email,
+			IssuedAt:  out.APIKey.CreatedAt,
+			ExpiresAt: out.APIKey.ExpiresAt,
+			Plan:      out.User.Plan,
+			TenantID:  out.User.OrganizationID,
+			APIKeyID:  out.APIKey.ID,
+			Scopes:    out.APIKey.Scopes,
+		})
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/danielmesquitta/flight-api/internal/app/server/handler"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auditlog"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AuditRequest stamps the audit events recorded while handling the
+// request with its IP address and request ID.
+func (m *Middleware) AuditRequest() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID, _ := c.Locals(RequestIDContextKey).(string)
+
+		c.SetUserContext(auditlog.WithRequest(
+			c.UserContext(),
+			auditlog.Request{IP: c.IP(), RequestID: requestID},
+		))
+
+		return c.Next()
+	}
+}
+
+// Audit records the action done by the request, on the URL it was sent
+// to, once it is handled. It must run after the authentication.
+func (m *Middleware) Audit(action entity.AuditAction) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+
+		actionErr := err
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) &&
+			fiberErr.Code < fiber.StatusInternalServerError {
+			// Tell the reason of client errors, such as malformed bodies.
+			actionErr = errs.New(fiberErr.Message, errs.ErrCodeValidation)
+		}
+
+		m.au.Record(c.UserContext(), entity.AuditEvent{
+			Actor:  auditActor(c),
+			Action: action,
+			Target: c.OriginalURL(),
+		}, actionErr)
+
+		return err
+	}
+}
+
+// auditActor returns who sent the request: the API key, the user of the
+// access token or the admin authenticated with basic auth.
+func auditActor(c *fiber.Ctx) string {
+	claims := handler.GetClaims(c)
+	switch {
+	case claims == nil:
+		return ""
+	case claims.APIKeyID != "":
+		return auditlog.APIKeyActor(claims.APIKeyID)
+	case claims.Subject != "":
+		return auditlog.UserActor(claims.Subject)
+	}
+	return auditlog.AdminActor(claims.Issuer)
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"strings"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
+	"github.com/gofiber/fiber/v2"
+)
+
+// BasicAuthAdmin requires the admin credentials, and authenticates the
+// request as an operator with the admin role. Every request is rejected
+// if they aren't configured.
+func (m *Middleware) BasicAuthAdmin() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		username, password, ok := parseBasicAuth(
+			c.Get(fiber.HeaderAuthorization),
+		)
+		if !ok || !m.isAdmin(username, password) {
+			c.Set(fiber.HeaderWWWAuthenticate, "basic realm=Restricted")
+			return fiber.ErrUnauthorized
+		}
+
+		c.Locals(jwtutil.ClaimsKey, &jwtutil.UserClaims{
+			Issuer: username,
+			Roles:  []string{entity.RoleAdmin},
+			Scopes: entity.Scopes,
+		})
+
+		return c.Next()
+	}
+}
+
+func (m *Middleware) isAdmin(username, password string) bool {
+	if m.e.AdminUsername == "" || m.e.AdminPassword == "" {
+		return false
+	}
+
+	usernameOK := subtle.ConstantTimeCompare(
+		[]byte(username),
+		[]byte(m.e.AdminUsername),
+	) == 1
+	passwordOK := subtle.ConstantTimeCompare(
+		[]byte(password),
+		[]byte(m.e.AdminPassword),
+	) == 1
+	return usernameOK && passwordOK
+}
+
+func parseBasicAuth(header string) (username, password string, ok bool) {
+	encoded, ok := strings.CutPrefix(header, "Basic ")
+	if !ok {
+		return "", "", false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", false
+	}
+
+	return strings.Cut(string(decoded), ":")
+}
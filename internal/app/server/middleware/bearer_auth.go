@@ -1,16 +1,41 @@
 package middleware
 
 import (
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
 	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
 	jwtware "github.com/gofiber/contrib/jwt"
 	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
 )
 
+// BearerAuthAccessToken requires a valid access token, that wasn't
+// revoked, and stores its claims for the next handlers.
 func (m *Middleware) BearerAuthAccessToken() fiber.Handler {
 	return jwtware.New(jwtware.Config{
 		ContextKey: jwtutil.ClaimsKey,
-		SigningKey: jwtware.SigningKey{
-			Key: []byte(m.e.JWTAccessTokenSecretKey),
+		KeyFunc:    m.j.KeyFunc(jwtutil.TokenTypeAccess),
+		SuccessHandler: func(c *fiber.Ctx) error {
+			token, ok := c.Locals(jwtutil.ClaimsKey).(*jwt.Token)
+			if !ok {
+				return fiber.ErrUnauthorized
+			}
+			mapClaims, ok := token.Claims.(jwt.MapClaims)
+			if !ok {
+				return fiber.ErrUnauthorized
+			}
+
+			claims := jwtutil.NewUserClaims(mapClaims)
+			c.Locals(jwtutil.ClaimsKey, claims)
+
+			revoked, err := m.d.IsRevoked(c.UserContext(), claims)
+			if err != nil {
+				return errs.New(err)
+			}
+			if revoked {
+				return errs.ErrTokenRevoked
+			}
+
+			return c.Next()
 		},
 	})
 }
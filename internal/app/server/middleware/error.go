@@ -3,7 +3,10 @@ package middleware
 import (
 	"errors"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/danielmesquitta/flight-api/internal/app/server/dto"
 	"github.com/danielmesquitta/flight-api/internal/app/server/handler"
@@ -13,14 +16,28 @@ import (
 
 type requestIDContextKey string
 
+// sensitiveFields are the fragments of the names of the request fields
+// that are redacted from the logs.
+var sensitiveFields = []string{
+	"password",
+	"secret",
+	"token",
+	"key",
+	"code",
+	"state",
+	"credential",
+}
+
 const RequestIDContextKey requestIDContextKey = "requestid"
 
 var mapAppErrToHTTPError = map[errs.Code]int{
-	errs.ErrCodeForbidden:    http.StatusForbidden,
-	errs.ErrCodeUnauthorized: http.StatusUnauthorized,
-	errs.ErrCodeValidation:   http.StatusBadRequest,
-	errs.ErrCodeUnknown:      http.StatusInternalServerError,
-	errs.ErrCodeNotFound:     http.StatusNotFound,
+	errs.ErrCodeForbidden:       http.StatusForbidden,
+	errs.ErrCodeUnauthorized:    http.StatusUnauthorized,
+	errs.ErrCodeValidation:      http.StatusBadRequest,
+	errs.ErrCodeUnknown:         http.StatusInternalServerError,
+	errs.ErrCodeNotFound:        http.StatusNotFound,
+	errs.ErrCodeConflict:        http.StatusConflict,
+	errs.ErrCodeTooManyRequests: http.StatusTooManyRequests,
 }
 
 func (m *Middleware) ErrorHandler(ctx *fiber.Ctx, err error) error {
@@ -35,6 +52,13 @@ func (m *Middleware) ErrorHandler(ctx *fiber.Ctx, err error) error {
 			return m.handleInternalServerError(ctx, appErr)
 		}
 
+		if appErr.RetryAfter > 0 {
+			ctx.Set(fiber.HeaderRetryAfter, strconv.FormatInt(
+				int64(math.Ceil(appErr.RetryAfter.Seconds())),
+				10,
+			))
+		}
+
 		return ctx.Status(code).JSON(
 			dto.ErrorResponse{Message: appErr.Message},
 		)
@@ -69,7 +93,7 @@ func (m *Middleware) handleInternalServerError(
 
 	queries := c.Queries()
 	if len(queries) > 0 {
-		args = append(args, "query", queries)
+		args = append(args, "query", redact(queries))
 	}
 
 	requestId := c.Locals(RequestIDContextKey)
@@ -86,10 +110,14 @@ func (m *Middleware) handleInternalServerError(
 		args = append(args, "user_id", userId)
 	}
 
-	requestData := map[string]any{}
-	_ = c.BodyParser(&requestData)
-	if len(requestData) > 0 {
-		args = append(args, "body", requestData)
+	// Authentication requests carry credentials in every field, so their
+	// body is never logged.
+	if !strings.Contains(c.Path(), "/auth/") {
+		requestData := map[string]any{}
+		_ = c.BodyParser(&requestData)
+		if len(requestData) > 0 {
+			args = append(args, "body", redact(requestData))
+		}
 	}
 
 	args = append(args, "stacktrace", appErr.StackTrace)
@@ -103,3 +131,35 @@ func (m *Middleware) handleInternalServerError(
 		dto.ErrorResponse{Message: "internal server error"},
 	)
 }
+
+// redact returns the fields of a request, replacing the values of the
+// sensitive ones, at any depth.
+func redact[V any](fields map[string]V) map[string]any {
+	redacted := make(map[string]any, len(fields))
+	for name, value := range fields {
+		redacted[name] = redactField(name, value)
+	}
+	return redacted
+}
+
+func redactField(name string, value any) any {
+	name = strings.ToLower(name)
+	for _, field := range sensitiveFields {
+		if strings.Contains(name, field) {
+			return "[REDACTED]"
+		}
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		return redact(value)
+	case []any:
+		values := make([]any, len(value))
+		for i, v := range value {
+			values[i] = redactField(name, v)
+		}
+		return values
+	default:
+		return value
+	}
+}
@@ -2,20 +2,35 @@ package middleware
 
 import (
 	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/apikey"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auditlog"
 	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
+	"github.com/danielmesquitta/flight-api/internal/pkg/ratelimit"
 )
 
 type Middleware struct {
-	e *env.Env
-	j *jwtutil.JWT
+	e  *env.Env
+	j  *jwtutil.JWT
+	d  *jwtutil.Denylist
+	l  *ratelimit.Limiter
+	a  *apikey.AuthenticateAPIKeyUseCase
+	au *auditlog.Auditor
 }
 
 func NewMiddleware(
 	e *env.Env,
 	j *jwtutil.JWT,
+	d *jwtutil.Denylist,
+	l *ratelimit.Limiter,
+	a *apikey.AuthenticateAPIKeyUseCase,
+	au *auditlog.Auditor,
 ) *Middleware {
 	return &Middleware{
-		e: e,
-		j: j,
+		e:  e,
+		j:  j,
+		d:  d,
+		l:  l,
+		a:  a,
+		au: au,
 	}
 }
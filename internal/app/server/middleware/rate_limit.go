@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"strconv"
+
+	"github.com/danielmesquitta/flight-api/internal/app/server/handler"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/ratelimit"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RateLimit counts requests against a budget, per API key, authenticated
+// user or IP address otherwise, and reports the budget state in the
+// RateLimit-* headers. Requests are allowed if the limiter fails.
+func (m *Middleware) RateLimit(budget ratelimit.Budget) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		client, plan := rateLimitClient(c)
+
+		res, err := m.l.Allow(c.UserContext(), client, budget, plan)
+		if err != nil {
+			slog.ErrorContext(
+				c.UserContext(),
+				"failed to check rate limit",
+				"error", err,
+			)
+			return c.Next()
+		}
+		if res == nil {
+			return c.Next()
+		}
+
+		reset := strconv.FormatInt(int64(math.Ceil(res.Reset.Seconds())), 10)
+
+		c.Set("RateLimit-Limit", strconv.FormatInt(res.Rule.Limit, 10))
+		c.Set("RateLimit-Remaining", strconv.FormatInt(res.Remaining, 10))
+		c.Set("RateLimit-Reset", reset)
+		c.Set("RateLimit-Policy", strconv.FormatInt(res.Rule.Limit, 10)+
+			";w="+strconv.FormatInt(int64(res.Rule.Window.Seconds()), 10))
+
+		if !res.Allowed {
+			c.Set(fiber.HeaderRetryAfter, reset)
+			return errs.ErrTooManyRequests
+		}
+
+		return c.Next()
+	}
+}
+
+// rateLimitClient returns who a request is counted against, and the
+// plan of the client, if any. Each API key has its own budget, apart
+// from the one of its user.
+func rateLimitClient(c *fiber.Ctx) (client, plan string) {
+	claims := handler.GetClaims(c)
+	switch {
+	case claims == nil:
+	case claims.APIKeyID != "":
+		return "apikey:" + claims.APIKeyID, claims.Plan
+	case claims.Issuer != "":
+		return "user:" + claims.Issuer, claims.Plan
+	}
+	return "ip:" + c.IP(), ""
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/danielmesquitta/flight-api/internal/app/server/handler"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/gofiber/fiber/v2"
+)
+
+// BasicAuthOrBearer authenticates with the admin credentials when sent as
+// basic auth, or with a bearer access token otherwise.
+func (m *Middleware) BasicAuthOrBearer() fiber.Handler {
+	basicAuth := m.BasicAuthAdmin()
+	bearerAuth := m.BearerAuthAccessToken()
+
+	return func(c *fiber.Ctx) error {
+		if strings.HasPrefix(c.Get(fiber.HeaderAuthorization), "Basic ") {
+			return basicAuth(c)
+		}
+		return bearerAuth(c)
+	}
+}
+
+// RequireRole only lets requests authenticated with the role through.
+func (m *Middleware) RequireRole(role entity.Role) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := handler.GetClaims(c)
+		if claims == nil {
+			return fiber.ErrUnauthorized
+		}
+		if !slices.Contains(claims.Roles, role) {
+			return errs.ErrMissingRole
+		}
+		return c.Next()
+	}
+}
+
+// RequireScope only lets requests authenticated with the scope through.
+func (m *Middleware) RequireScope(scope entity.Scope) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims := handler.GetClaims(c)
+		if claims == nil {
+			return fiber.ErrUnauthorized
+		}
+		if !slices.Contains(claims.Scopes, scope) {
+			return errs.ErrMissingScope
+		}
+		return c.Next()
+	}
+}
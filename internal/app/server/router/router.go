@@ -7,6 +7,8 @@ import (
 	"github.com/danielmesquitta/flight-api/internal/app/server/handler"
 	"github.com/danielmesquitta/flight-api/internal/app/server/middleware"
 	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/pkg/ratelimit"
 )
 
 type Router struct {
@@ -16,6 +18,14 @@ type Router struct {
 	dh *handler.DocHandler
 	ah *handler.AuthHandler
 	fh *handler.FlightHandler
+	ph *handler.ProviderHandler
+	ch *handler.CacheHandler
+	kh *handler.APIKeyHandler
+	jh *handler.JWKSHandler
+	oh *handler.OrganizationHandler
+	uh *handler.AuditHandler
+	sh *handler.SavedSearchHandler
+	lh *handler.PriceAlertHandler
 }
 
 func NewRouter(
@@ -25,6 +35,14 @@ func NewRouter(
 	dh *handler.DocHandler,
 	ah *handler.AuthHandler,
 	fh *handler.FlightHandler,
+	ph *handler.ProviderHandler,
+	ch *handler.CacheHandler,
+	kh *handler.APIKeyHandler,
+	jh *handler.JWKSHandler,
+	oh *handler.OrganizationHandler,
+	uh *handler.AuditHandler,
+	sh *handler.SavedSearchHandler,
+	lh *handler.PriceAlertHandler,
 ) *Router {
 	return &Router{
 		e:  e,
@@ -33,23 +51,218 @@ func NewRouter(
 		dh: dh,
 		ah: ah,
 		fh: fh,
+		ph: ph,
+		ch: ch,
+		kh: kh,
+		jh: jh,
+		oh: oh,
+		uh: uh,
+		sh: sh,
+		lh: lh,
 	}
 }
 
 func (r *Router) Register(
 	app *fiber.App,
 ) {
+	app.Get(
+		"/.well-known/jwks.json",
+		r.m.RateLimit(ratelimit.BudgetDefault),
+		r.jh.Get,
+	)
+
 	basePath := "/api"
 	api := app.Group(basePath)
 
 	api.Get("/health", r.hh.Health)
-	api.Use("/docs", r.dh.Get)
+	api.Use("/docs", r.m.RateLimit(ratelimit.BudgetDefault), r.dh.Get)
+
+	apiV1 := app.Group(
+		basePath+"/v1",
+		r.m.RateLimit(ratelimit.BudgetIP),
+	)
+
+	apiV1.Post(
+		"/auth/login",
+		r.m.RateLimit(ratelimit.BudgetLogin),
+		r.ah.Login,
+	)
+	apiV1.Post(
+		"/auth/register",
+		r.m.RateLimit(ratelimit.BudgetLogin),
+		r.ah.Register,
+	)
+	apiV1.Post(
+		"/auth/refresh",
+		r.m.RateLimit(ratelimit.BudgetLogin),
+		r.ah.Refresh,
+	)
+
+	apiV1.Post(
+		"/auth/password/forgot",
+		r.m.RateLimit(ratelimit.BudgetLogin),
+		r.ah.ForgotPassword,
+	)
+	apiV1.Post(
+		"/auth/password/reset",
+		r.m.RateLimit(ratelimit.BudgetLogin),
+		r.ah.ResetPassword,
+	)
+	apiV1.Post(
+		"/auth/email/verify",
+		r.m.RateLimit(ratelimit.BudgetLogin),
+		r.ah.VerifyEmail,
+	)
+	apiV1.Get(
+		"/auth/oidc/:provider/start",
+		r.m.RateLimit(ratelimit.BudgetLogin),
+		r.ah.StartOIDCLogin,
+	)
+	apiV1.Get(
+		"/auth/oidc/:provider/callback",
+		r.m.RateLimit(ratelimit.BudgetLogin),
+		r.ah.FinishOIDCLogin,
+	)
+
+	apiV1.Post(
+		"/auth/logout",
+		r.m.BearerAuthAccessToken(),
+		r.m.RateLimit(ratelimit.BudgetDefault),
+		r.ah.Logout,
+	)
+	apiV1.Post(
+		"/auth/email/verification",
+		r.m.BearerAuthAccessToken(),
+		r.m.RateLimit(ratelimit.BudgetDefault),
+		r.ah.SendEmailVerification,
+	)
+
+	apiKeysApiV1 := apiV1.Group(
+		"/api-keys",
+		r.m.BearerAuthAccessToken(),
+		r.m.RateLimit(ratelimit.BudgetDefault),
+	)
+
+	apiKeysApiV1.Post(
+		"",
+		r.m.Audit(entity.AuditActionAPIKeyCreate),
+		r.kh.Create,
+	)
+	apiKeysApiV1.Get("", r.kh.List)
+	apiKeysApiV1.Delete(
+		"/:api_key_id",
+		r.m.Audit(entity.AuditActionAPIKeyRevoke),
+		r.kh.Revoke,
+	)
+
+	savedSearchesApiV1 := apiV1.Group(
+		"/saved-searches",
+		r.m.BearerAuthOrAPIKey(),
+	)
+
+	savedSearchesApiV1.Post(
+		"",
+		r.m.RateLimit(ratelimit.BudgetDefault),
+		r.sh.Create,
+	)
+	savedSearchesApiV1.Get(
+		"",
+		r.m.RateLimit(ratelimit.BudgetDefault),
+		r.sh.List,
+	)
+	savedSearchesApiV1.Delete(
+		"/:saved_search_id",
+		r.m.RateLimit(ratelimit.BudgetDefault),
+		r.sh.Delete,
+	)
+	savedSearchesApiV1.Post(
+		"/:saved_search_id/run",
+		r.m.RequireScope(entity.ScopeFlightsSearch),
+		r.m.RateLimit(ratelimit.BudgetSearch),
+		r.sh.Run,
+	)
+
+	// Alerts are searched on behalf of their users, who must be allowed
+	// to search.
+	priceAlertsApiV1 := apiV1.Group(
+		"/price-alerts",
+		r.m.BearerAuthOrAPIKey(),
+		r.m.RequireScope(entity.ScopeFlightsSearch),
+		r.m.RateLimit(ratelimit.BudgetDefault),
+	)
+
+	priceAlertsApiV1.Post("", r.lh.Create)
+	priceAlertsApiV1.Get("", r.lh.List)
+	priceAlertsApiV1.Delete("/:price_alert_id", r.lh.Delete)
+	priceAlertsApiV1.Get("/:price_alert_id/checks", r.lh.ListChecks)
 
-	apiV1 := app.Group(basePath + "/v1")
+	adminApiV1 := apiV1.Group(
+		"/admin",
+		r.m.BasicAuthOrBearer(),
+		r.m.RequireRole(entity.RoleAdmin),
+		r.m.RateLimit(ratelimit.BudgetDefault),
+	)
 
-	apiV1.Post("/auth/login", r.ah.Login)
+	adminApiV1.Get("/providers/usage", r.ph.Usage)
+	adminApiV1.Get("/cache/stats", r.ch.Stats)
+	adminApiV1.Get("/cache/warmer", r.ch.Warmer)
+	adminApiV1.Get("/cache/keys", r.ch.Keys)
+	adminApiV1.Delete(
+		"/cache/keys",
+		r.m.Audit(entity.AuditActionCachePurge),
+		r.ch.Purge,
+	)
+	adminApiV1.Delete(
+		"/cache/rate-limits/:client",
+		r.m.Audit(entity.AuditActionRateLimitFlush),
+		r.ch.FlushRateLimit,
+	)
+	adminApiV1.Put(
+		"/users/:user_id/roles",
+		r.m.Audit(entity.AuditActionUserRolesUpdate),
+		r.ah.UpdateRoles,
+	)
+	adminApiV1.Delete(
+		"/users/:user_id/sessions",
+		r.m.Audit(entity.AuditActionUserSessionsRevoke),
+		r.ah.RevokeSessions,
+	)
+	adminApiV1.Get("/login-lockouts", r.ah.ListLoginLockouts)
+	adminApiV1.Delete(
+		"/login-lockouts/:kind/:subject",
+		r.m.Audit(entity.AuditActionLoginUnlock),
+		r.ah.UnlockLogin,
+	)
+	adminApiV1.Post(
+		"/organizations",
+		r.m.Audit(entity.AuditActionOrganizationCreate),
+		r.oh.Create,
+	)
+	adminApiV1.Get("/organizations", r.oh.List)
+	adminApiV1.Get(
+		"/organizations/:organization_id/providers",
+		r.oh.ListProviders,
+	)
+	adminApiV1.Put(
+		"/organizations/:organization_id/providers/:provider",
+		r.m.Audit(entity.AuditActionProviderConfigure),
+		r.oh.ConfigureProvider,
+	)
+	adminApiV1.Put(
+		"/users/:user_id/organization",
+		r.m.Audit(entity.AuditActionOrganizationAssign),
+		r.oh.UpdateUserOrganization,
+	)
+	adminApiV1.Get("/audit-events", r.uh.List)
 
-	loggedInApiV1 := apiV1.Group("", r.m.BearerAuthAccessToken())
+	// Groups always have a prefix, as the middleware of a group without
+	// one would run for every route registered after it.
+	flightsApiV1 := apiV1.Group("/flights", r.m.BearerAuthOrAPIKey())
 
-	loggedInApiV1.Get("/flights/search", r.fh.Search)
+	flightsApiV1.Get(
+		"/search",
+		r.m.RequireScope(entity.ScopeFlightsSearch),
+		r.m.RateLimit(ratelimit.BudgetSearch),
+		r.fh.Search,
+	)
 }
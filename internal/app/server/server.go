@@ -1,17 +1,17 @@
 package server
 
 import (
+	"context"
 	"time"
 
 	"github.com/danielmesquitta/flight-api/internal/app/server/middleware"
 	"github.com/danielmesquitta/flight-api/internal/app/server/router"
-	"github.com/danielmesquitta/flight-api/internal/provider/cache"
-	"github.com/danielmesquitta/flight-api/internal/provider/cache/fibercache"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/flight"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/pricealert"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/helmet"
-	"github.com/gofiber/fiber/v2/middleware/limiter"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
 )
 
@@ -22,7 +22,8 @@ type App struct {
 func Build(
 	m *middleware.Middleware,
 	r *router.Router,
-	c cache.Cache,
+	w *flight.CacheWarmer,
+	pw *pricealert.Watcher,
 ) *App {
 	app := fiber.New(fiber.Config{
 		ErrorHandler: m.ErrorHandler,
@@ -33,16 +34,24 @@ func Build(
 	app.Use(requestid.New(requestid.Config{
 		ContextKey: middleware.RequestIDContextKey,
 	}))
-	app.Use(limiter.New(limiter.Config{
-		Max:        20,
-		Expiration: 1 * time.Minute,
-		Storage:    fibercache.NewFiberCache(c),
-	}))
 	app.Use(helmet.New())
+	app.Use(m.AuditRequest())
 	app.Use(m.Timeout(60 * time.Second))
 
 	r.Register(app)
 
+	// Background jobs only run while the server is listening.
+	ctx, cancel := context.WithCancel(context.Background())
+	app.Hooks().OnListen(func(fiber.ListenData) error {
+		go w.Start(ctx)
+		go pw.Start(ctx)
+		return nil
+	})
+	app.Hooks().OnShutdown(func() error {
+		cancel()
+		return nil
+	})
+
 	return &App{
 		App: app,
 	}
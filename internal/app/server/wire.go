@@ -10,16 +10,26 @@ import (
 	"github.com/danielmesquitta/flight-api/internal/app/server/middleware"
 	"github.com/danielmesquitta/flight-api/internal/app/server/router"
 	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/apikey"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auditlog"
 	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auth"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/cacheadmin"
 	"github.com/danielmesquitta/flight-api/internal/domain/usecase/flight"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/organization"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/pricealert"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/savedsearch"
+	"github.com/danielmesquitta/flight-api/internal/pkg/hasher"
 	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
+	"github.com/danielmesquitta/flight-api/internal/pkg/ratelimit"
+	"github.com/danielmesquitta/flight-api/internal/pkg/secretbox"
 	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
-	"github.com/danielmesquitta/flight-api/internal/provider/cache"
-	"github.com/danielmesquitta/flight-api/internal/provider/cache/rediscache"
+	"github.com/danielmesquitta/flight-api/internal/provider/audit/auditdriver"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/cachedriver"
 	"github.com/danielmesquitta/flight-api/internal/provider/flightapi"
-	"github.com/danielmesquitta/flight-api/internal/provider/flightapi/amadeusapi"
-	"github.com/danielmesquitta/flight-api/internal/provider/flightapi/duffelapi"
-	"github.com/danielmesquitta/flight-api/internal/provider/flightapi/serpapi"
+	"github.com/danielmesquitta/flight-api/internal/provider/idp"
+	"github.com/danielmesquitta/flight-api/internal/provider/mailer/mailerdriver"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/repodriver"
 	"github.com/google/wire"
 	"testing"
 )
@@ -33,18 +43,91 @@ func NewDev(
 	wire.Build(
 		// Add any development-specific providers here
 		jwtutil.NewJWT,
-		amadeusapi.NewAmadeusAPI,
-		serpapi.NewSerpAPI,
-		duffelapi.NewDuffelAPI,
-		flightapi.NewFlightAPIs,
-		wire.Bind(new(cache.Cache), new(*rediscache.RedisCache)),
-		rediscache.NewRedisCache,
+		jwtutil.NewDenylist,
+		hasher.New,
+		wire.Bind(new(hasher.Hasher), new(*hasher.Bcrypt)),
+		secretbox.New,
+		flightapi.NewMeter,
+		flightapi.NewTenantClients,
+		wire.Bind(new(flightapi.Resolver), new(*flightapi.TenantClients)),
+		organization.NewTenantConfigs,
+		wire.Bind(
+			new(flightapi.TenantConfigLoader),
+			new(*organization.TenantConfigs),
+		),
+		cachedriver.NewCache,
+		repodriver.NewRepository,
+		wire.Bind(new(repo.UserRepository), new(repo.Repository)),
+		wire.Bind(new(repo.APIKeyRepository), new(repo.Repository)),
+		wire.Bind(new(repo.IdentityRepository), new(repo.Repository)),
+		wire.Bind(new(repo.OrganizationRepository), new(repo.Repository)),
+		wire.Bind(new(repo.AuditRepository), new(repo.Repository)),
+		wire.Bind(new(repo.SavedSearchRepository), new(repo.Repository)),
+		wire.Bind(new(repo.PriceAlertRepository), new(repo.Repository)),
+		auditdriver.NewAuditSink,
+		mailerdriver.NewMailer,
+		idp.NewOIDC,
+		wire.Bind(new(idp.IdentityProvider), new(*idp.OIDC)),
+		ratelimit.NewLimiter,
+		flight.NewCachePolicy,
+		flight.NewPopularSearches,
+		flight.NewCacheWarmer,
 		flight.NewSearchFlightsUseCase,
+		flight.NewListProvidersUsageUseCase,
+		auditlog.NewAuditor,
+		auditlog.NewListAuditEventsUseCase,
 		auth.NewLoginUseCase,
+		auth.NewRegisterUseCase,
+		auth.NewRefreshUseCase,
+		auth.NewSessions,
+		auth.NewLogoutUseCase,
+		auth.NewRevokeSessionsUseCase,
+		auth.NewUpdateUserRolesUseCase,
+		auth.NewStartOIDCLoginUseCase,
+		auth.NewFinishOIDCLoginUseCase,
+		auth.NewEmailVerifier,
+		auth.NewSendEmailVerificationUseCase,
+		auth.NewVerifyEmailUseCase,
+		auth.NewForgotPasswordUseCase,
+		auth.NewResetPasswordUseCase,
+		auth.NewLoginThrottle,
+		auth.NewListLoginLockoutsUseCase,
+		auth.NewUnlockLoginUseCase,
+		apikey.NewCreateAPIKeyUseCase,
+		apikey.NewListAPIKeysUseCase,
+		apikey.NewRevokeAPIKeyUseCase,
+		apikey.NewAuthenticateAPIKeyUseCase,
+		cacheadmin.NewGetCacheStatsUseCase,
+		cacheadmin.NewGetCacheWarmerStatusUseCase,
+		cacheadmin.NewListCacheKeysUseCase,
+		cacheadmin.NewPurgeCacheKeysUseCase,
+		cacheadmin.NewFlushRateLimitUseCase,
+		organization.NewCreateOrganizationUseCase,
+		organization.NewListOrganizationsUseCase,
+		organization.NewListOrganizationProvidersUseCase,
+		organization.NewConfigureProviderUseCase,
+		organization.NewUpdateUserOrganizationUseCase,
+		savedsearch.NewCreateSavedSearchUseCase,
+		savedsearch.NewListSavedSearchesUseCase,
+		savedsearch.NewDeleteSavedSearchUseCase,
+		savedsearch.NewRunSavedSearchUseCase,
+		pricealert.NewWatcher,
+		pricealert.NewCreatePriceAlertUseCase,
+		pricealert.NewListPriceAlertsUseCase,
+		pricealert.NewDeletePriceAlertUseCase,
+		pricealert.NewListPriceAlertChecksUseCase,
 		handler.NewDocHandler,
 		handler.NewHealthHandler,
 		handler.NewFlightHandler,
 		handler.NewAuthHandler,
+		handler.NewProviderHandler,
+		handler.NewCacheHandler,
+		handler.NewAPIKeyHandler,
+		handler.NewJWKSHandler,
+		handler.NewOrganizationHandler,
+		handler.NewAuditHandler,
+		handler.NewSavedSearchHandler,
+		handler.NewPriceAlertHandler,
 		middleware.NewMiddleware,
 		router.NewRouter,
 		Build,
@@ -61,18 +144,91 @@ func NewStaging(
 	wire.Build(
 		// Add any staging-specific providers here
 		jwtutil.NewJWT,
-		amadeusapi.NewAmadeusAPI,
-		serpapi.NewSerpAPI,
-		duffelapi.NewDuffelAPI,
-		flightapi.NewFlightAPIs,
-		wire.Bind(new(cache.Cache), new(*rediscache.RedisCache)),
-		rediscache.NewRedisCache,
+		jwtutil.NewDenylist,
+		hasher.New,
+		wire.Bind(new(hasher.Hasher), new(*hasher.Bcrypt)),
+		secretbox.New,
+		flightapi.NewMeter,
+		flightapi.NewTenantClients,
+		wire.Bind(new(flightapi.Resolver), new(*flightapi.TenantClients)),
+		organization.NewTenantConfigs,
+		wire.Bind(
+			new(flightapi.TenantConfigLoader),
+			new(*organization.TenantConfigs),
+		),
+		cachedriver.NewCache,
+		repodriver.NewRepository,
+		wire.Bind(new(repo.UserRepository), new(repo.Repository)),
+		wire.Bind(new(repo.APIKeyRepository), new(repo.Repository)),
+		wire.Bind(new(repo.IdentityRepository), new(repo.Repository)),
+		wire.Bind(new(repo.OrganizationRepository), new(repo.Repository)),
+		wire.Bind(new(repo.AuditRepository), new(repo.Repository)),
+		wire.Bind(new(repo.SavedSearchRepository), new(repo.Repository)),
+		wire.Bind(new(repo.PriceAlertRepository), new(repo.Repository)),
+		auditdriver.NewAuditSink,
+		mailerdriver.NewMailer,
+		idp.NewOIDC,
+		wire.Bind(new(idp.IdentityProvider), new(*idp.OIDC)),
+		ratelimit.NewLimiter,
+		flight.NewCachePolicy,
+		flight.NewPopularSearches,
+		flight.NewCacheWarmer,
 		flight.NewSearchFlightsUseCase,
+		flight.NewListProvidersUsageUseCase,
+		auditlog.NewAuditor,
+		auditlog.NewListAuditEventsUseCase,
 		auth.NewLoginUseCase,
+		auth.NewRegisterUseCase,
+		auth.NewRefreshUseCase,
+		auth.NewSessions,
+		auth.NewLogoutUseCase,
+		auth.NewRevokeSessionsUseCase,
+		auth.NewUpdateUserRolesUseCase,
+		auth.NewStartOIDCLoginUseCase,
+		auth.NewFinishOIDCLoginUseCase,
+		auth.NewEmailVerifier,
+		auth.NewSendEmailVerificationUseCase,
+		auth.NewVerifyEmailUseCase,
+		auth.NewForgotPasswordUseCase,
+		auth.NewResetPasswordUseCase,
+		auth.NewLoginThrottle,
+		auth.NewListLoginLockoutsUseCase,
+		auth.NewUnlockLoginUseCase,
+		apikey.NewCreateAPIKeyUseCase,
+		apikey.NewListAPIKeysUseCase,
+		apikey.NewRevokeAPIKeyUseCase,
+		apikey.NewAuthenticateAPIKeyUseCase,
+		cacheadmin.NewGetCacheStatsUseCase,
+		cacheadmin.NewGetCacheWarmerStatusUseCase,
+		cacheadmin.NewListCacheKeysUseCase,
+		cacheadmin.NewPurgeCacheKeysUseCase,
+		cacheadmin.NewFlushRateLimitUseCase,
+		organization.NewCreateOrganizationUseCase,
+		organization.NewListOrganizationsUseCase,
+		organization.NewListOrganizationProvidersUseCase,
+		organization.NewConfigureProviderUseCase,
+		organization.NewUpdateUserOrganizationUseCase,
+		savedsearch.NewCreateSavedSearchUseCase,
+		savedsearch.NewListSavedSearchesUseCase,
+		savedsearch.NewDeleteSavedSearchUseCase,
+		savedsearch.NewRunSavedSearchUseCase,
+		pricealert.NewWatcher,
+		pricealert.NewCreatePriceAlertUseCase,
+		pricealert.NewListPriceAlertsUseCase,
+		pricealert.NewDeletePriceAlertUseCase,
+		pricealert.NewListPriceAlertChecksUseCase,
 		handler.NewDocHandler,
 		handler.NewHealthHandler,
 		handler.NewFlightHandler,
 		handler.NewAuthHandler,
+		handler.NewProviderHandler,
+		handler.NewCacheHandler,
+		handler.NewAPIKeyHandler,
+		handler.NewJWKSHandler,
+		handler.NewOrganizationHandler,
+		handler.NewAuditHandler,
+		handler.NewSavedSearchHandler,
+		handler.NewPriceAlertHandler,
 		middleware.NewMiddleware,
 		router.NewRouter,
 		Build,
@@ -89,18 +245,91 @@ func NewTest(
 	wire.Build(
 		// Add any test-specific providers here
 		jwtutil.NewJWT,
-		amadeusapi.NewAmadeusAPI,
-		serpapi.NewSerpAPI,
-		duffelapi.NewDuffelAPI,
-		flightapi.NewFlightAPIs,
-		wire.Bind(new(cache.Cache), new(*rediscache.RedisCache)),
-		rediscache.NewRedisCache,
+		jwtutil.NewDenylist,
+		hasher.New,
+		wire.Bind(new(hasher.Hasher), new(*hasher.Bcrypt)),
+		secretbox.New,
+		flightapi.NewMeter,
+		flightapi.NewTenantClients,
+		wire.Bind(new(flightapi.Resolver), new(*flightapi.TenantClients)),
+		organization.NewTenantConfigs,
+		wire.Bind(
+			new(flightapi.TenantConfigLoader),
+			new(*organization.TenantConfigs),
+		),
+		cachedriver.NewCache,
+		repodriver.NewRepository,
+		wire.Bind(new(repo.UserRepository), new(repo.Repository)),
+		wire.Bind(new(repo.APIKeyRepository), new(repo.Repository)),
+		wire.Bind(new(repo.IdentityRepository), new(repo.Repository)),
+		wire.Bind(new(repo.OrganizationRepository), new(repo.Repository)),
+		wire.Bind(new(repo.AuditRepository), new(repo.Repository)),
+		wire.Bind(new(repo.SavedSearchRepository), new(repo.Repository)),
+		wire.Bind(new(repo.PriceAlertRepository), new(repo.Repository)),
+		auditdriver.NewAuditSink,
+		mailerdriver.NewMailer,
+		idp.NewOIDC,
+		wire.Bind(new(idp.IdentityProvider), new(*idp.OIDC)),
+		ratelimit.NewLimiter,
+		flight.NewCachePolicy,
+		flight.NewPopularSearches,
+		flight.NewCacheWarmer,
 		flight.NewSearchFlightsUseCase,
+		flight.NewListProvidersUsageUseCase,
+		auditlog.NewAuditor,
+		auditlog.NewListAuditEventsUseCase,
 		auth.NewLoginUseCase,
+		auth.NewRegisterUseCase,
+		auth.NewRefreshUseCase,
+		auth.NewSessions,
+		auth.NewLogoutUseCase,
+		auth.NewRevokeSessionsUseCase,
+		auth.NewUpdateUserRolesUseCase,
+		auth.NewStartOIDCLoginUseCase,
+		auth.NewFinishOIDCLoginUseCase,
+		auth.NewEmailVerifier,
+		auth.NewSendEmailVerificationUseCase,
+		auth.NewVerifyEmailUseCase,
+		auth.NewForgotPasswordUseCase,
+		auth.NewResetPasswordUseCase,
+		auth.NewLoginThrottle,
+		auth.NewListLoginLockoutsUseCase,
+		auth.NewUnlockLoginUseCase,
+		apikey.NewCreateAPIKeyUseCase,
+		apikey.NewListAPIKeysUseCase,
+		apikey.NewRevokeAPIKeyUseCase,
+		apikey.NewAuthenticateAPIKeyUseCase,
+		cacheadmin.NewGetCacheStatsUseCase,
+		cacheadmin.NewGetCacheWarmerStatusUseCase,
+		cacheadmin.NewListCacheKeysUseCase,
+		cacheadmin.NewPurgeCacheKeysUseCase,
+		cacheadmin.NewFlushRateLimitUseCase,
+		organization.NewCreateOrganizationUseCase,
+		organization.NewListOrganizationsUseCase,
+		organization.NewListOrganizationProvidersUseCase,
+		organization.NewConfigureProviderUseCase,
+		organization.NewUpdateUserOrganizationUseCase,
+		savedsearch.NewCreateSavedSearchUseCase,
+		savedsearch.NewListSavedSearchesUseCase,
+		savedsearch.NewDeleteSavedSearchUseCase,
+		savedsearch.NewRunSavedSearchUseCase,
+		pricealert.NewWatcher,
+		pricealert.NewCreatePriceAlertUseCase,
+		pricealert.NewListPriceAlertsUseCase,
+		pricealert.NewDeletePriceAlertUseCase,
+		pricealert.NewListPriceAlertChecksUseCase,
 		handler.NewDocHandler,
 		handler.NewHealthHandler,
 		handler.NewFlightHandler,
 		handler.NewAuthHandler,
+		handler.NewProviderHandler,
+		handler.NewCacheHandler,
+		handler.NewAPIKeyHandler,
+		handler.NewJWKSHandler,
+		handler.NewOrganizationHandler,
+		handler.NewAuditHandler,
+		handler.NewSavedSearchHandler,
+		handler.NewPriceAlertHandler,
 		middleware.NewMiddleware,
 		router.NewRouter,
 		Build,
@@ -117,18 +346,91 @@ func NewProd(
 	wire.Build(
 		// Add any production-specific providers here
 		jwtutil.NewJWT,
-		amadeusapi.NewAmadeusAPI,
-		serpapi.NewSerpAPI,
-		duffelapi.NewDuffelAPI,
-		flightapi.NewFlightAPIs,
-		wire.Bind(new(cache.Cache), new(*rediscache.RedisCache)),
-		rediscache.NewRedisCache,
+		jwtutil.NewDenylist,
+		hasher.New,
+		wire.Bind(new(hasher.Hasher), new(*hasher.Bcrypt)),
+		secretbox.New,
+		flightapi.NewMeter,
+		flightapi.NewTenantClients,
+		wire.Bind(new(flightapi.Resolver), new(*flightapi.TenantClients)),
+		organization.NewTenantConfigs,
+		wire.Bind(
+			new(flightapi.TenantConfigLoader),
+			new(*organization.TenantConfigs),
+		),
+		cachedriver.NewCache,
+		repodriver.NewRepository,
+		wire.Bind(new(repo.UserRepository), new(repo.Repository)),
+		wire.Bind(new(repo.APIKeyRepository), new(repo.Repository)),
+		wire.Bind(new(repo.IdentityRepository), new(repo.Repository)),
+		wire.Bind(new(repo.OrganizationRepository), new(repo.Repository)),
+		wire.Bind(new(repo.AuditRepository), new(repo.Repository)),
+		wire.Bind(new(repo.SavedSearchRepository), new(repo.Repository)),
+		wire.Bind(new(repo.PriceAlertRepository), new(repo.Repository)),
+		auditdriver.NewAuditSink,
+		mailerdriver.NewMailer,
+		idp.NewOIDC,
+		wire.Bind(new(idp.IdentityProvider), new(*idp.OIDC)),
+		ratelimit.NewLimiter,
+		flight.NewCachePolicy,
+		flight.NewPopularSearches,
+		flight.NewCacheWarmer,
 		flight.NewSearchFlightsUseCase,
+		flight.NewListProvidersUsageUseCase,
+		auditlog.NewAuditor,
+		auditlog.NewListAuditEventsUseCase,
 		auth.NewLoginUseCase,
+		auth.NewRegisterUseCase,
+		auth.NewRefreshUseCase,
+		auth.NewSessions,
+		auth.NewLogoutUseCase,
+		auth.NewRevokeSessionsUseCase,
+		auth.NewUpdateUserRolesUseCase,
+		auth.NewStartOIDCLoginUseCase,
+		auth.NewFinishOIDCLoginUseCase,
+		auth.NewEmailVerifier,
+		auth.NewSendEmailVerificationUseCase,
+		auth.NewVerifyEmailUseCase,
+		auth.NewForgotPasswordUseCase,
+		auth.NewResetPasswordUseCase,
+		auth.NewLoginThrottle,
+		auth.NewListLoginLockoutsUseCase,
+		auth.NewUnlockLoginUseCase,
+		apikey.NewCreateAPIKeyUseCase,
+		apikey.NewListAPIKeysUseCase,
+		apikey.NewRevokeAPIKeyUseCase,
+		apikey.NewAuthenticateAPIKeyUseCase,
+		cacheadmin.NewGetCacheStatsUseCase,
+		cacheadmin.NewGetCacheWarmerStatusUseCase,
+		cacheadmin.NewListCacheKeysUseCase,
+		cacheadmin.NewPurgeCacheKeysUseCase,
+		cacheadmin.NewFlushRateLimitUseCase,
+		organization.NewCreateOrganizationUseCase,
+		organization.NewListOrganizationsUseCase,
+		organization.NewListOrganizationProvidersUseCase,
+		organization.NewConfigureProviderUseCase,
+		organization.NewUpdateUserOrganizationUseCase,
+		savedsearch.NewCreateSavedSearchUseCase,
+		savedsearch.NewListSavedSearchesUseCase,
+		savedsearch.NewDeleteSavedSearchUseCase,
+		savedsearch.NewRunSavedSearchUseCase,
+		pricealert.NewWatcher,
+		pricealert.NewCreatePriceAlertUseCase,
+		pricealert.NewListPriceAlertsUseCase,
+		pricealert.NewDeletePriceAlertUseCase,
+		pricealert.NewListPriceAlertChecksUseCase,
 		handler.NewDocHandler,
 		handler.NewHealthHandler,
 		handler.NewFlightHandler,
 		handler.NewAuthHandler,
+		handler.NewProviderHandler,
+		handler.NewCacheHandler,
+		handler.NewAPIKeyHandler,
+		handler.NewJWKSHandler,
+		handler.NewOrganizationHandler,
+		handler.NewAuditHandler,
+		handler.NewSavedSearchHandler,
+		handler.NewPriceAlertHandler,
 		middleware.NewMiddleware,
 		router.NewRouter,
 		Build,
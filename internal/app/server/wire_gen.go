@@ -11,15 +11,25 @@ import (
 	"github.com/danielmesquitta/flight-api/internal/app/server/middleware"
 	"github.com/danielmesquitta/flight-api/internal/app/server/router"
 	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/apikey"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auditlog"
 	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auth"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/cacheadmin"
 	"github.com/danielmesquitta/flight-api/internal/domain/usecase/flight"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/organization"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/pricealert"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/savedsearch"
+	"github.com/danielmesquitta/flight-api/internal/pkg/hasher"
 	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
+	"github.com/danielmesquitta/flight-api/internal/pkg/ratelimit"
+	"github.com/danielmesquitta/flight-api/internal/pkg/secretbox"
 	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
-	"github.com/danielmesquitta/flight-api/internal/provider/cache/rediscache"
+	"github.com/danielmesquitta/flight-api/internal/provider/audit/auditdriver"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/cachedriver"
 	"github.com/danielmesquitta/flight-api/internal/provider/flightapi"
-	"github.com/danielmesquitta/flight-api/internal/provider/flightapi/amadeusapi"
-	"github.com/danielmesquitta/flight-api/internal/provider/flightapi/duffelapi"
-	"github.com/danielmesquitta/flight-api/internal/provider/flightapi/serpapi"
+	"github.com/danielmesquitta/flight-api/internal/provider/idp"
+	"github.com/danielmesquitta/flight-api/internal/provider/mailer/mailerdriver"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/repodriver"
 	"testing"
 )
 
@@ -28,79 +38,319 @@ import (
 // NewDev wires up the application in dev mode.
 func NewDev(v validator.Validator, e *env.Env, t *testing.T) *App {
 	jwt := jwtutil.NewJWT(e)
-	middlewareMiddleware := middleware.NewMiddleware(e, jwt)
+	cache := cachedriver.NewCache(e)
+	denylist := jwtutil.NewDenylist(cache)
+	limiter := ratelimit.NewLimiter(e, cache)
+	repository := repodriver.NewRepository(e)
+	authenticateAPIKeyUseCase := apikey.NewAuthenticateAPIKeyUseCase(v, repository, repository)
+	auditSink := auditdriver.NewAuditSink(e, repository)
+	auditor := auditlog.NewAuditor(auditSink)
+	middlewareMiddleware := middleware.NewMiddleware(e, jwt, denylist, limiter, authenticateAPIKeyUseCase, auditor)
 	healthHandler := handler.NewHealthHandler()
 	docHandler := handler.NewDocHandler()
-	loginUseCase := auth.NewLoginUseCase(v, jwt)
-	authHandler := handler.NewAuthHandler(loginUseCase)
-	redisCache := rediscache.NewRedisCache(e)
-	amadeusAPI := amadeusapi.NewAmadeusAPI(e)
-	serpAPI := serpapi.NewSerpAPI(e)
-	duffelAPI := duffelapi.NewDuffelAPI(e)
-	v2 := flightapi.NewFlightAPIs(amadeusAPI, serpAPI, duffelAPI)
-	searchFlightsUseCase := flight.NewSearchFlightsUseCase(v, redisCache, v2)
+	sessions := auth.NewSessions(e, jwt, denylist, cache)
+	loginThrottle := auth.NewLoginThrottle(e, cache)
+	bcrypt := hasher.New()
+	loginUseCase := auth.NewLoginUseCase(v, sessions, loginThrottle, bcrypt, repository, auditor)
+	mailer := mailerdriver.NewMailer(e)
+	emailVerifier := auth.NewEmailVerifier(e, cache, mailer)
+	registerUseCase := auth.NewRegisterUseCase(v, bcrypt, repository, emailVerifier, auditor)
+	refreshUseCase := auth.NewRefreshUseCase(v, sessions, repository, auditor)
+	logoutUseCase := auth.NewLogoutUseCase(v, sessions, auditor)
+	revokeSessionsUseCase := auth.NewRevokeSessionsUseCase(v, sessions, repository)
+	updateUserRolesUseCase := auth.NewUpdateUserRolesUseCase(v, repository)
+	oidc := idp.NewOIDC(e)
+	startOIDCLoginUseCase := auth.NewStartOIDCLoginUseCase(v, cache, oidc)
+	finishOIDCLoginUseCase := auth.NewFinishOIDCLoginUseCase(v, cache, oidc, sessions, repository, repository, auditor)
+	sendEmailVerificationUseCase := auth.NewSendEmailVerificationUseCase(v, emailVerifier, repository)
+	verifyEmailUseCase := auth.NewVerifyEmailUseCase(v, cache, repository, auditor)
+	forgotPasswordUseCase := auth.NewForgotPasswordUseCase(v, e, cache, mailer, repository, auditor)
+	resetPasswordUseCase := auth.NewResetPasswordUseCase(v, cache, bcrypt, sessions, repository, auditor)
+	listLoginLockoutsUseCase := auth.NewListLoginLockoutsUseCase(loginThrottle)
+	unlockLoginUseCase := auth.NewUnlockLoginUseCase(v, loginThrottle)
+	authHandler := handler.NewAuthHandler(loginUseCase, registerUseCase, refreshUseCase, logoutUseCase, revokeSessionsUseCase, updateUserRolesUseCase, startOIDCLoginUseCase, finishOIDCLoginUseCase, sendEmailVerificationUseCase, verifyEmailUseCase, forgotPasswordUseCase, resetPasswordUseCase, listLoginLockoutsUseCase, unlockLoginUseCase)
+	meter := flightapi.NewMeter(e, cache)
+	box := secretbox.New(e)
+	tenantConfigs := organization.NewTenantConfigs(box, repository)
+	tenantClients := flightapi.NewTenantClients(e, cache, meter, tenantConfigs)
+	cachePolicy := flight.NewCachePolicy(e, cache)
+	popularSearches := flight.NewPopularSearches(cache)
+	searchFlightsUseCase := flight.NewSearchFlightsUseCase(v, cache, tenantClients, cachePolicy, e, popularSearches)
 	flightHandler := handler.NewFlightHandler(searchFlightsUseCase)
-	routerRouter := router.NewRouter(e, middlewareMiddleware, healthHandler, docHandler, authHandler, flightHandler)
-	app := Build(middlewareMiddleware, routerRouter, redisCache)
+	listProvidersUsageUseCase := flight.NewListProvidersUsageUseCase(meter)
+	providerHandler := handler.NewProviderHandler(listProvidersUsageUseCase)
+	getCacheStatsUseCase := cacheadmin.NewGetCacheStatsUseCase(cache)
+	cacheWarmer := flight.NewCacheWarmer(e, cache, meter, searchFlightsUseCase, popularSearches)
+	getCacheWarmerStatusUseCase := cacheadmin.NewGetCacheWarmerStatusUseCase(cacheWarmer)
+	listCacheKeysUseCase := cacheadmin.NewListCacheKeysUseCase(v, cache)
+	purgeCacheKeysUseCase := cacheadmin.NewPurgeCacheKeysUseCase(v, cache)
+	flushRateLimitUseCase := cacheadmin.NewFlushRateLimitUseCase(v, cache)
+	cacheHandler := handler.NewCacheHandler(getCacheStatsUseCase, getCacheWarmerStatusUseCase, listCacheKeysUseCase, purgeCacheKeysUseCase, flushRateLimitUseCase)
+	createAPIKeyUseCase := apikey.NewCreateAPIKeyUseCase(v, repository)
+	listAPIKeysUseCase := apikey.NewListAPIKeysUseCase(v, repository)
+	revokeAPIKeyUseCase := apikey.NewRevokeAPIKeyUseCase(v, repository)
+	apiKeyHandler := handler.NewAPIKeyHandler(createAPIKeyUseCase, listAPIKeysUseCase, revokeAPIKeyUseCase)
+	jwksHandler := handler.NewJWKSHandler(jwt)
+	createOrganizationUseCase := organization.NewCreateOrganizationUseCase(v, repository)
+	listOrganizationsUseCase := organization.NewListOrganizationsUseCase(repository)
+	listOrganizationProvidersUseCase := organization.NewListOrganizationProvidersUseCase(v, repository)
+	configureProviderUseCase := organization.NewConfigureProviderUseCase(v, box, repository, tenantClients)
+	updateUserOrganizationUseCase := organization.NewUpdateUserOrganizationUseCase(v, repository, repository)
+	organizationHandler := handler.NewOrganizationHandler(createOrganizationUseCase, listOrganizationsUseCase, listOrganizationProvidersUseCase, configureProviderUseCase, updateUserOrganizationUseCase)
+	listAuditEventsUseCase := auditlog.NewListAuditEventsUseCase(v, auditSink)
+	auditHandler := handler.NewAuditHandler(listAuditEventsUseCase)
+	createSavedSearchUseCase := savedsearch.NewCreateSavedSearchUseCase(v, repository)
+	listSavedSearchesUseCase := savedsearch.NewListSavedSearchesUseCase(v, repository)
+	deleteSavedSearchUseCase := savedsearch.NewDeleteSavedSearchUseCase(v, repository)
+	runSavedSearchUseCase := savedsearch.NewRunSavedSearchUseCase(v, repository, searchFlightsUseCase)
+	savedSearchHandler := handler.NewSavedSearchHandler(createSavedSearchUseCase, listSavedSearchesUseCase, deleteSavedSearchUseCase, runSavedSearchUseCase)
+	createPriceAlertUseCase := pricealert.NewCreatePriceAlertUseCase(v, e, repository)
+	listPriceAlertsUseCase := pricealert.NewListPriceAlertsUseCase(v, repository)
+	deletePriceAlertUseCase := pricealert.NewDeletePriceAlertUseCase(v, repository)
+	listPriceAlertChecksUseCase := pricealert.NewListPriceAlertChecksUseCase(v, repository)
+	priceAlertHandler := handler.NewPriceAlertHandler(createPriceAlertUseCase, listPriceAlertsUseCase, deletePriceAlertUseCase, listPriceAlertChecksUseCase)
+	routerRouter := router.NewRouter(e, middlewareMiddleware, healthHandler, docHandler, authHandler, flightHandler, providerHandler, cacheHandler, apiKeyHandler, jwksHandler, organizationHandler, auditHandler, savedSearchHandler, priceAlertHandler)
+	watcher := pricealert.NewWatcher(e, cache, meter, searchFlightsUseCase, repository, repository, mailer)
+	app := Build(middlewareMiddleware, routerRouter, cacheWarmer, watcher)
 	return app
 }
 
 // NewStaging wires up the application in staging mode.
 func NewStaging(v validator.Validator, e *env.Env, t *testing.T) *App {
 	jwt := jwtutil.NewJWT(e)
-	middlewareMiddleware := middleware.NewMiddleware(e, jwt)
+	cache := cachedriver.NewCache(e)
+	denylist := jwtutil.NewDenylist(cache)
+	limiter := ratelimit.NewLimiter(e, cache)
+	repository := repodriver.NewRepository(e)
+	authenticateAPIKeyUseCase := apikey.NewAuthenticateAPIKeyUseCase(v, repository, repository)
+	auditSink := auditdriver.NewAuditSink(e, repository)
+	auditor := auditlog.NewAuditor(auditSink)
+	middlewareMiddleware := middleware.NewMiddleware(e, jwt, denylist, limiter, authenticateAPIKeyUseCase, auditor)
 	healthHandler := handler.NewHealthHandler()
 	docHandler := handler.NewDocHandler()
-	loginUseCase := auth.NewLoginUseCase(v, jwt)
-	authHandler := handler.NewAuthHandler(loginUseCase)
-	redisCache := rediscache.NewRedisCache(e)
-	amadeusAPI := amadeusapi.NewAmadeusAPI(e)
-	serpAPI := serpapi.NewSerpAPI(e)
-	duffelAPI := duffelapi.NewDuffelAPI(e)
-	v2 := flightapi.NewFlightAPIs(amadeusAPI, serpAPI, duffelAPI)
-	searchFlightsUseCase := flight.NewSearchFlightsUseCase(v, redisCache, v2)
+	sessions := auth.NewSessions(e, jwt, denylist, cache)
+	loginThrottle := auth.NewLoginThrottle(e, cache)
+	bcrypt := hasher.New()
+	loginUseCase := auth.NewLoginUseCase(v, sessions, loginThrottle, bcrypt, repository, auditor)
+	mailer := mailerdriver.NewMailer(e)
+	emailVerifier := auth.NewEmailVerifier(e, cache, mailer)
+	registerUseCase := auth.NewRegisterUseCase(v, bcrypt, repository, emailVerifier, auditor)
+	refreshUseCase := auth.NewRefreshUseCase(v, sessions, repository, auditor)
+	logoutUseCase := auth.NewLogoutUseCase(v, sessions, auditor)
+	revokeSessionsUseCase := auth.NewRevokeSessionsUseCase(v, sessions, repository)
+	updateUserRolesUseCase := auth.NewUpdateUserRolesUseCase(v, repository)
+	oidc := idp.NewOIDC(e)
+	startOIDCLoginUseCase := auth.NewStartOIDCLoginUseCase(v, cache, oidc)
+	finishOIDCLoginUseCase := auth.NewFinishOIDCLoginUseCase(v, cache, oidc, sessions, repository, repository, auditor)
+	sendEmailVerificationUseCase := auth.NewSendEmailVerificationUseCase(v, emailVerifier, repository)
+	verifyEmailUseCase := auth.NewVerifyEmailUseCase(v, cache, repository, auditor)
+	forgotPasswordUseCase := auth.NewForgotPasswordUseCase(v, e, cache, mailer, repository, auditor)
+	resetPasswordUseCase := auth.NewResetPasswordUseCase(v, cache, bcrypt, sessions, repository, auditor)
+	listLoginLockoutsUseCase := auth.NewListLoginLockoutsUseCase(loginThrottle)
+	unlockLoginUseCase := auth.NewUnlockLoginUseCase(v, loginThrottle)
+	authHandler := handler.NewAuthHandler(loginUseCase, registerUseCase, refreshUseCase, logoutUseCase, revokeSessionsUseCase, updateUserRolesUseCase, startOIDCLoginUseCase, finishOIDCLoginUseCase, sendEmailVerificationUseCase, verifyEmailUseCase, forgotPasswordUseCase, resetPasswordUseCase, listLoginLockoutsUseCase, unlockLoginUseCase)
+	meter := flightapi.NewMeter(e, cache)
+	box := secretbox.New(e)
+	tenantConfigs := organization.NewTenantConfigs(box, repository)
+	tenantClients := flightapi.NewTenantClients(e, cache, meter, tenantConfigs)
+	cachePolicy := flight.NewCachePolicy(e, cache)
+	popularSearches := flight.NewPopularSearches(cache)
+	searchFlightsUseCase := flight.NewSearchFlightsUseCase(v, cache, tenantClients, cachePolicy, e, popularSearches)
 	flightHandler := handler.NewFlightHandler(searchFlightsUseCase)
-	routerRouter := router.NewRouter(e, middlewareMiddleware, healthHandler, docHandler, authHandler, flightHandler)
-	app := Build(middlewareMiddleware, routerRouter, redisCache)
+	listProvidersUsageUseCase := flight.NewListProvidersUsageUseCase(meter)
+	providerHandler := handler.NewProviderHandler(listProvidersUsageUseCase)
+	getCacheStatsUseCase := cacheadmin.NewGetCacheStatsUseCase(cache)
+	cacheWarmer := flight.NewCacheWarmer(e, cache, meter, searchFlightsUseCase, popularSearches)
+	getCacheWarmerStatusUseCase := cacheadmin.NewGetCacheWarmerStatusUseCase(cacheWarmer)
+	listCacheKeysUseCase := cacheadmin.NewListCacheKeysUseCase(v, cache)
+	purgeCacheKeysUseCase := cacheadmin.NewPurgeCacheKeysUseCase(v, cache)
+	flushRateLimitUseCase := cacheadmin.NewFlushRateLimitUseCase(v, cache)
+	cacheHandler := handler.NewCacheHandler(getCacheStatsUseCase, getCacheWarmerStatusUseCase, listCacheKeysUseCase, purgeCacheKeysUseCase, flushRateLimitUseCase)
+	createAPIKeyUseCase := apikey.NewCreateAPIKeyUseCase(v, repository)
+	listAPIKeysUseCase := apikey.NewListAPIKeysUseCase(v, repository)
+	revokeAPIKeyUseCase := apikey.NewRevokeAPIKeyUseCase(v, repository)
+	apiKeyHandler := handler.NewAPIKeyHandler(createAPIKeyUseCase, listAPIKeysUseCase, revokeAPIKeyUseCase)
+	jwksHandler := handler.NewJWKSHandler(jwt)
+	createOrganizationUseCase := organization.NewCreateOrganizationUseCase(v, repository)
+	listOrganizationsUseCase := organization.NewListOrganizationsUseCase(repository)
+	listOrganizationProvidersUseCase := organization.NewListOrganizationProvidersUseCase(v, repository)
+	configureProviderUseCase := organization.NewConfigureProviderUseCase(v, box, repository, tenantClients)
+	updateUserOrganizationUseCase := organization.NewUpdateUserOrganizationUseCase(v, repository, repository)
+	organizationHandler := handler.NewOrganizationHandler(createOrganizationUseCase, listOrganizationsUseCase, listOrganizationProvidersUseCase, configureProviderUseCase, updateUserOrganizationUseCase)
+	listAuditEventsUseCase := auditlog.NewListAuditEventsUseCase(v, auditSink)
+	auditHandler := handler.NewAuditHandler(listAuditEventsUseCase)
+	createSavedSearchUseCase := savedsearch.NewCreateSavedSearchUseCase(v, repository)
+	listSavedSearchesUseCase := savedsearch.NewListSavedSearchesUseCase(v, repository)
+	deleteSavedSearchUseCase := savedsearch.NewDeleteSavedSearchUseCase(v, repository)
+	runSavedSearchUseCase := savedsearch.NewRunSavedSearchUseCase(v, repository, searchFlightsUseCase)
+	savedSearchHandler := handler.NewSavedSearchHandler(createSavedSearchUseCase, listSavedSearchesUseCase, deleteSavedSearchUseCase, runSavedSearchUseCase)
+	createPriceAlertUseCase := pricealert.NewCreatePriceAlertUseCase(v, e, repository)
+	listPriceAlertsUseCase := pricealert.NewListPriceAlertsUseCase(v, repository)
+	deletePriceAlertUseCase := pricealert.NewDeletePriceAlertUseCase(v, repository)
+	listPriceAlertChecksUseCase := pricealert.NewListPriceAlertChecksUseCase(v, repository)
+	priceAlertHandler := handler.NewPriceAlertHandler(createPriceAlertUseCase, listPriceAlertsUseCase, deletePriceAlertUseCase, listPriceAlertChecksUseCase)
+	routerRouter := router.NewRouter(e, middlewareMiddleware, healthHandler, docHandler, authHandler, flightHandler, providerHandler, cacheHandler, apiKeyHandler, jwksHandler, organizationHandler, auditHandler, savedSearchHandler, priceAlertHandler)
+	watcher := pricealert.NewWatcher(e, cache, meter, searchFlightsUseCase, repository, repository, mailer)
+	app := Build(middlewareMiddleware, routerRouter, cacheWarmer, watcher)
 	return app
 }
 
 // NewTest wires up the application in test mode.
 func NewTest(v validator.Validator, e *env.Env, t *testing.T) *App {
 	jwt := jwtutil.NewJWT(e)
-	middlewareMiddleware := middleware.NewMiddleware(e, jwt)
+	cache := cachedriver.NewCache(e)
+	denylist := jwtutil.NewDenylist(cache)
+	limiter := ratelimit.NewLimiter(e, cache)
+	repository := repodriver.NewRepository(e)
+	authenticateAPIKeyUseCase := apikey.NewAuthenticateAPIKeyUseCase(v, repository, repository)
+	auditSink := auditdriver.NewAuditSink(e, repository)
+	auditor := auditlog.NewAuditor(auditSink)
+	middlewareMiddleware := middleware.NewMiddleware(e, jwt, denylist, limiter, authenticateAPIKeyUseCase, auditor)
 	healthHandler := handler.NewHealthHandler()
 	docHandler := handler.NewDocHandler()
-	loginUseCase := auth.NewLoginUseCase(v, jwt)
-	authHandler := handler.NewAuthHandler(loginUseCase)
-	redisCache := rediscache.NewRedisCache(e)
-	amadeusAPI := amadeusapi.NewAmadeusAPI(e)
-	serpAPI := serpapi.NewSerpAPI(e)
-	duffelAPI := duffelapi.NewDuffelAPI(e)
-	v2 := flightapi.NewFlightAPIs(amadeusAPI, serpAPI, duffelAPI)
-	searchFlightsUseCase := flight.NewSearchFlightsUseCase(v, redisCache, v2)
+	sessions := auth.NewSessions(e, jwt, denylist, cache)
+	loginThrottle := auth.NewLoginThrottle(e, cache)
+	bcrypt := hasher.New()
+	loginUseCase := auth.NewLoginUseCase(v, sessions, loginThrottle, bcrypt, repository, auditor)
+	mailer := mailerdriver.NewMailer(e)
+	emailVerifier := auth.NewEmailVerifier(e, cache, mailer)
+	registerUseCase := auth.NewRegisterUseCase(v, bcrypt, repository, emailVerifier, auditor)
+	refreshUseCase := auth.NewRefreshUseCase(v, sessions, repository, auditor)
+	logoutUseCase := auth.NewLogoutUseCase(v, sessions, auditor)
+	revokeSessionsUseCase := auth.NewRevokeSessionsUseCase(v, sessions, repository)
+	updateUserRolesUseCase := auth.NewUpdateUserRolesUseCase(v, repository)
+	oidc := idp.NewOIDC(e)
+	startOIDCLoginUseCase := auth.NewStartOIDCLoginUseCase(v, cache, oidc)
+	finishOIDCLoginUseCase := auth.NewFinishOIDCLoginUseCase(v, cache, oidc, sessions, repository, repository, auditor)
+	sendEmailVerificationUseCase := auth.NewSendEmailVerificationUseCase(v, emailVerifier, repository)
+	verifyEmailUseCase := auth.NewVerifyEmailUseCase(v, cache, repository, auditor)
+	forgotPasswordUseCase := auth.NewForgotPasswordUseCase(v, e, cache, mailer, repository, auditor)
+	resetPasswordUseCase := auth.NewResetPasswordUseCase(v, cache, bcrypt, sessions, repository, auditor)
+	listLoginLockoutsUseCase := auth.NewListLoginLockoutsUseCase(loginThrottle)
+	unlockLoginUseCase := auth.NewUnlockLoginUseCase(v, loginThrottle)
+	authHandler := handler.NewAuthHandler(loginUseCase, registerUseCase, refreshUseCase, logoutUseCase, revokeSessionsUseCase, updateUserRolesUseCase, startOIDCLoginUseCase, finishOIDCLoginUseCase, sendEmailVerificationUseCase, verifyEmailUseCase, forgotPasswordUseCase, resetPasswordUseCase, listLoginLockoutsUseCase, unlockLoginUseCase)
+	meter := flightapi.NewMeter(e, cache)
+	box := secretbox.New(e)
+	tenantConfigs := organization.NewTenantConfigs(box, repository)
+	tenantClients := flightapi.NewTenantClients(e, cache, meter, tenantConfigs)
+	cachePolicy := flight.NewCachePolicy(e, cache)
+	popularSearches := flight.NewPopularSearches(cache)
+	searchFlightsUseCase := flight.NewSearchFlightsUseCase(v, cache, tenantClients, cachePolicy, e, popularSearches)
 	flightHandler := handler.NewFlightHandler(searchFlightsUseCase)
-	routerRouter := router.NewRouter(e, middlewareMiddleware, healthHandler, docHandler, authHandler, flightHandler)
-	app := Build(middlewareMiddleware, routerRouter, redisCache)
+	listProvidersUsageUseCase := flight.NewListProvidersUsageUseCase(meter)
+	providerHandler := handler.NewProviderHandler(listProvidersUsageUseCase)
+	getCacheStatsUseCase := cacheadmin.NewGetCacheStatsUseCase(cache)
+	cacheWarmer := flight.NewCacheWarmer(e, cache, meter, searchFlightsUseCase, popularSearches)
+	getCacheWarmerStatusUseCase := cacheadmin.NewGetCacheWarmerStatusUseCase(cacheWarmer)
+	listCacheKeysUseCase := cacheadmin.NewListCacheKeysUseCase(v, cache)
+	purgeCacheKeysUseCase := cacheadmin.NewPurgeCacheKeysUseCase(v, cache)
+	flushRateLimitUseCase := cacheadmin.NewFlushRateLimitUseCase(v, cache)
+	cacheHandler := handler.NewCacheHandler(getCacheStatsUseCase, getCacheWarmerStatusUseCase, listCacheKeysUseCase, purgeCacheKeysUseCase, flushRateLimitUseCase)
+	createAPIKeyUseCase := apikey.NewCreateAPIKeyUseCase(v, repository)
+	listAPIKeysUseCase := apikey.NewListAPIKeysUseCase(v, repository)
+	revokeAPIKeyUseCase := apikey.NewRevokeAPIKeyUseCase(v, repository)
+	apiKeyHandler := handler.NewAPIKeyHandler(createAPIKeyUseCase, listAPIKeysUseCase, revokeAPIKeyUseCase)
+	jwksHandler := handler.NewJWKSHandler(jwt)
+	createOrganizationUseCase := organization.NewCreateOrganizationUseCase(v, repository)
+	listOrganizationsUseCase := organization.NewListOrganizationsUseCase(repository)
+	listOrganizationProvidersUseCase := organization.NewListOrganizationProvidersUseCase(v, repository)
+	configureProviderUseCase := organization.NewConfigureProviderUseCase(v, box, repository, tenantClients)
+	updateUserOrganizationUseCase := organization.NewUpdateUserOrganizationUseCase(v, repository, repository)
+	organizationHandler := handler.NewOrganizationHandler(createOrganizationUseCase, listOrganizationsUseCase, listOrganizationProvidersUseCase, configureProviderUseCase, updateUserOrganizationUseCase)
+	listAuditEventsUseCase := auditlog.NewListAuditEventsUseCase(v, auditSink)
+	auditHandler := handler.NewAuditHandler(listAuditEventsUseCase)
+	createSavedSearchUseCase := savedsearch.NewCreateSavedSearchUseCase(v, repository)
+	listSavedSearchesUseCase := savedsearch.NewListSavedSearchesUseCase(v, repository)
+	deleteSavedSearchUseCase := savedsearch.NewDeleteSavedSearchUseCase(v, repository)
+	runSavedSearchUseCase := savedsearch.NewRunSavedSearchUseCase(v, repository, searchFlightsUseCase)
+	savedSearchHandler := handler.NewSavedSearchHandler(createSavedSearchUseCase, listSavedSearchesUseCase, deleteSavedSearchUseCase, runSavedSearchUseCase)
+	createPriceAlertUseCase := pricealert.NewCreatePriceAlertUseCase(v, e, repository)
+	listPriceAlertsUseCase := pricealert.NewListPriceAlertsUseCase(v, repository)
+	deletePriceAlertUseCase := pricealert.NewDeletePriceAlertUseCase(v, repository)
+	listPriceAlertChecksUseCase := pricealert.NewListPriceAlertChecksUseCase(v, repository)
+	priceAlertHandler := handler.NewPriceAlertHandler(createPriceAlertUseCase, listPriceAlertsUseCase, deletePriceAlertUseCase, listPriceAlertChecksUseCase)
+	routerRouter := router.NewRouter(e, middlewareMiddleware, healthHandler, docHandler, authHandler, flightHandler, providerHandler, cacheHandler, apiKeyHandler, jwksHandler, organizationHandler, auditHandler, savedSearchHandler, priceAlertHandler)
+	watcher := pricealert.NewWatcher(e, cache, meter, searchFlightsUseCase, repository, repository, mailer)
+	app := Build(middlewareMiddleware, routerRouter, cacheWarmer, watcher)
 	return app
 }
 
 // NewProd wires up the application in prod mode.
 func NewProd(v validator.Validator, e *env.Env, t *testing.T) *App {
 	jwt := jwtutil.NewJWT(e)
-	middlewareMiddleware := middleware.NewMiddleware(e, jwt)
+	cache := cachedriver.NewCache(e)
+	denylist := jwtutil.NewDenylist(cache)
+	limiter := ratelimit.NewLimiter(e, cache)
+	repository := repodriver.NewRepository(e)
+	authenticateAPIKeyUseCase := apikey.NewAuthenticateAPIKeyUseCase(v, repository, repository)
+	auditSink := auditdriver.NewAuditSink(e, repository)
+	auditor := auditlog.NewAuditor(auditSink)
+	middlewareMiddleware := middleware.NewMiddleware(e, jwt, denylist, limiter, authenticateAPIKeyUseCase, auditor)
 	healthHandler := handler.NewHealthHandler()
 	docHandler := handler.NewDocHandler()
-	loginUseCase := auth.NewLoginUseCase(v, jwt)
-	authHandler := handler.NewAuthHandler(loginUseCase)
-	redisCache := rediscache.NewRedisCache(e)
-	amadeusAPI := amadeusapi.NewAmadeusAPI(e)
-	serpAPI := serpapi.NewSerpAPI(e)
-	duffelAPI := duffelapi.NewDuffelAPI(e)
-	v2 := flightapi.NewFlightAPIs(amadeusAPI, serpAPI, duffelAPI)
-	searchFlightsUseCase := flight.NewSearchFlightsUseCase(v, redisCache, v2)
+	sessions := auth.NewSessions(e, jwt, denylist, cache)
+	loginThrottle := auth.NewLoginThrottle(e, cache)
+	bcrypt := hasher.New()
+	loginUseCase := auth.NewLoginUseCase(v, sessions, loginThrottle, bcrypt, repository, auditor)
+	mailer := mailerdriver.NewMailer(e)
+	emailVerifier := auth.NewEmailVerifier(e, cache, mailer)
+	registerUseCase := auth.NewRegisterUseCase(v, bcrypt, repository, emailVerifier, auditor)
+	refreshUseCase := auth.NewRefreshUseCase(v, sessions, repository, auditor)
+	logoutUseCase := auth.NewLogoutUseCase(v, sessions, auditor)
+	revokeSessionsUseCase := auth.NewRevokeSessionsUseCase(v, sessions, repository)
+	updateUserRolesUseCase := auth.NewUpdateUserRolesUseCase(v, repository)
+	oidc := idp.NewOIDC(e)
+	startOIDCLoginUseCase := auth.NewStartOIDCLoginUseCase(v, cache, oidc)
+	finishOIDCLoginUseCase := auth.NewFinishOIDCLoginUseCase(v, cache, oidc, sessions, repository, repository, auditor)
+	sendEmailVerificationUseCase := auth.NewSendEmailVerificationUseCase(v, emailVerifier, repository)
+	verifyEmailUseCase := auth.NewVerifyEmailUseCase(v, cache, repository, auditor)
+	forgotPasswordUseCase := auth.NewForgotPasswordUseCase(v, e, cache, mailer, repository, auditor)
+	resetPasswordUseCase := auth.NewResetPasswordUseCase(v, cache, bcrypt, sessions, repository, auditor)
+	listLoginLockoutsUseCase := auth.NewListLoginLockoutsUseCase(loginThrottle)
+	unlockLoginUseCase := auth.NewUnlockLoginUseCase(v, loginThrottle)
+	authHandler := handler.NewAuthHandler(loginUseCase, registerUseCase, refreshUseCase, logoutUseCase, revokeSessionsUseCase, updateUserRolesUseCase, startOIDCLoginUseCase, finishOIDCLoginUseCase, sendEmailVerificationUseCase, verifyEmailUseCase, forgotPasswordUseCase, resetPasswordUseCase, listLoginLockoutsUseCase, unlockLoginUseCase)
+	meter := flightapi.NewMeter(e, cache)
+	box := secretbox.New(e)
+	tenantConfigs := organization.NewTenantConfigs(box, repository)
+	tenantClients := flightapi.NewTenantClients(e, cache, meter, tenantConfigs)
+	cachePolicy := flight.NewCachePolicy(e, cache)
+	popularSearches := flight.NewPopularSearches(cache)
+	searchFlightsUseCase := flight.NewSearchFlightsUseCase(v, cache, tenantClients, cachePolicy, e, popularSearches)
 	flightHandler := handler.NewFlightHandler(searchFlightsUseCase)
-	routerRouter := router.NewRouter(e, middlewareMiddleware, healthHandler, docHandler, authHandler, flightHandler)
-	app := Build(middlewareMiddleware, routerRouter, redisCache)
+	listProvidersUsageUseCase := flight.NewListProvidersUsageUseCase(meter)
+	providerHandler := handler.NewProviderHandler(listProvidersUsageUseCase)
+	getCacheStatsUseCase := cacheadmin.NewGetCacheStatsUseCase(cache)
+	cacheWarmer := flight.NewCacheWarmer(e, cache, meter, searchFlightsUseCase, popularSearches)
+	getCacheWarmerStatusUseCase := cacheadmin.NewGetCacheWarmerStatusUseCase(cacheWarmer)
+	listCacheKeysUseCase := cacheadmin.NewListCacheKeysUseCase(v, cache)
+	purgeCacheKeysUseCase := cacheadmin.NewPurgeCacheKeysUseCase(v, cache)
+	flushRateLimitUseCase := cacheadmin.NewFlushRateLimitUseCase(v, cache)
+	cacheHandler := handler.NewCacheHandler(getCacheStatsUseCase, getCacheWarmerStatusUseCase, listCacheKeysUseCase, purgeCacheKeysUseCase, flushRateLimitUseCase)
+	createAPIKeyUseCase := apikey.NewCreateAPIKeyUseCase(v, repository)
+	listAPIKeysUseCase := apikey.NewListAPIKeysUseCase(v, repository)
+	revokeAPIKeyUseCase := apikey.NewRevokeAPIKeyUseCase(v, repository)
+	apiKeyHandler := handler.NewAPIKeyHandler(createAPIKeyUseCase, listAPIKeysUseCase, revokeAPIKeyUseCase)
+	jwksHandler := handler.NewJWKSHandler(jwt)
+	createOrganizationUseCase := organization.NewCreateOrganizationUseCase(v, repository)
+	listOrganizationsUseCase := organization.NewListOrganizationsUseCase(repository)
+	listOrganizationProvidersUseCase := organization.NewListOrganizationProvidersUseCase(v, repository)
+	configureProviderUseCase := organization.NewConfigureProviderUseCase(v, box, repository, tenantClients)
+	updateUserOrganizationUseCase := organization.NewUpdateUserOrganizationUseCase(v, repository, repository)
+	organizationHandler := handler.NewOrganizationHandler(createOrganizationUseCase, listOrganizationsUseCase, listOrganizationProvidersUseCase, configureProviderUseCase, updateUserOrganizationUseCase)
+	listAuditEventsUseCase := auditlog.NewListAuditEventsUseCase(v, auditSink)
+	auditHandler := handler.NewAuditHandler(listAuditEventsUseCase)
+	createSavedSearchUseCase := savedsearch.NewCreateSavedSearchUseCase(v, repository)
+	listSavedSearchesUseCase := savedsearch.NewListSavedSearchesUseCase(v, repository)
+	deleteSavedSearchUseCase := savedsearch.NewDeleteSavedSearchUseCase(v, repository)
+	runSavedSearchUseCase := savedsearch.NewRunSavedSearchUseCase(v, repository, searchFlightsUseCase)
+	savedSearchHandler := handler.NewSavedSearchHandler(createSavedSearchUseCase, listSavedSearchesUseCase, deleteSavedSearchUseCase, runSavedSearchUseCase)
+	createPriceAlertUseCase := pricealert.NewCreatePriceAlertUseCase(v, e, repository)
+	listPriceAlertsUseCase := pricealert.NewListPriceAlertsUseCase(v, repository)
+	deletePriceAlertUseCase := pricealert.NewDeletePriceAlertUseCase(v, repository)
+	listPriceAlertChecksUseCase := pricealert.NewListPriceAlertChecksUseCase(v, repository)
+	priceAlertHandler := handler.NewPriceAlertHandler(createPriceAlertUseCase, listPriceAlertsUseCase, deletePriceAlertUseCase, listPriceAlertChecksUseCase)
+	routerRouter := router.NewRouter(e, middlewareMiddleware, healthHandler, docHandler, authHandler, flightHandler, providerHandler, cacheHandler, apiKeyHandler, jwksHandler, organizationHandler, auditHandler, savedSearchHandler, priceAlertHandler)
+	watcher := pricealert.NewWatcher(e, cache, meter, searchFlightsUseCase, repository, repository, mailer)
+	app := Build(middlewareMiddleware, routerRouter, cacheWarmer, watcher)
 	return app
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	root "github.com/danielmesquitta/flight-api"
 	"github.com/danielmesquitta/flight-api/internal/domain/errs"
@@ -24,17 +25,211 @@ const (
 	EnvironmentTest        Environment = "test"
 )
 
+type CacheDriver string
+
+const (
+	CacheDriverRedis    CacheDriver = "redis"
+	CacheDriverInMemory CacheDriver = "memory"
+	CacheDriverTiered   CacheDriver = "tiered"
+)
+
+type DatabaseDriver string
+
+const (
+	DatabaseDriverSQLite   DatabaseDriver = "sqlite"
+	DatabaseDriverPostgres DatabaseDriver = "postgres"
+	DatabaseDriverInMemory DatabaseDriver = "memory"
+)
+
+type MailerDriver string
+
+const (
+	MailerDriverSMTP MailerDriver = "smtp"
+	MailerDriverFile MailerDriver = "file"
+)
+
+type AuditDriver string
+
+const (
+	AuditDriverDatabase AuditDriver = "database"
+	AuditDriverFile     AuditDriver = "file"
+)
+
+type CacheCodec string
+
+const (
+	CacheCodecJSON    CacheCodec = "json"
+	CacheCodecMsgpack CacheCodec = "msgpack"
+	CacheCodecGob     CacheCodec = "gob"
+)
+
+type CacheCompression string
+
+const (
+	CacheCompressionNone   CacheCompression = "none"
+	CacheCompressionZstd   CacheCompression = "zstd"
+	CacheCompressionSnappy CacheCompression = "snappy"
+)
+
 type Env struct {
 	v validator.Validator
 
 	Environment             Environment `mapstructure:"ENVIRONMENT"                 validate:"required,oneof=development production staging test"`
 	Port                    string      `mapstructure:"PORT"`
-	RedisDatabaseURL        string      `mapstructure:"REDIS_DATABASE_URL"          validate:"required"`
-	JWTAccessTokenSecretKey string      `mapstructure:"JWT_ACCESS_TOKEN_SECRET_KEY" validate:"required"`
+	CacheDriver             CacheDriver `mapstructure:"CACHE_DRIVER"                validate:"omitempty,oneof=redis memory tiered"`
+	RedisDatabaseURL        string      `mapstructure:"REDIS_DATABASE_URL"          validate:"required_unless=CacheDriver memory"`
+	InMemoryCacheMaxEntries int         `mapstructure:"IN_MEMORY_CACHE_MAX_ENTRIES" validate:"min=0"`
+	JWTAccessTokenSecretKey string      `mapstructure:"JWT_ACCESS_TOKEN_SECRET_KEY" validate:"required_without=JWTSigningKeys"`
 	AmadeusAPIKey           string      `mapstructure:"AMADEUS_API_KEY"             validate:"required"`
 	AmadeusAPISecret        string      `mapstructure:"AMADEUS_API_SECRET"          validate:"required"`
 	SerpAPIKey              string      `mapstructure:"SERP_API_KEY"                validate:"required"`
 	DuffelAPIKey            string      `mapstructure:"DUFFEL_API_KEY"              validate:"required"`
+
+	// Basic auth with the admin credentials is disabled unless both are
+	// set.
+	AdminUsername string `mapstructure:"ADMIN_USERNAME" validate:"required_with=AdminPassword"`
+	AdminPassword string `mapstructure:"ADMIN_PASSWORD" validate:"required_with=AdminUsername"`
+
+	// Access tokens are short-lived, and renewed with refresh tokens signed
+	// with their own key.
+	JWTRefreshTokenSecretKey string        `mapstructure:"JWT_REFRESH_TOKEN_SECRET_KEY" validate:"required"`
+	JWTAccessTokenTTL        time.Duration `mapstructure:"JWT_ACCESS_TOKEN_TTL"         validate:"min=0"`
+	JWTRefreshTokenTTL       time.Duration `mapstructure:"JWT_REFRESH_TOKEN_TTL"        validate:"min=0"`
+
+	// Access tokens are signed with the first of these keys instead of the
+	// secret key, and verified with any of them, so that a retired key is
+	// kept, as a public key, until the tokens it signed expire. Given as
+	// KID=KEY, comma separated, where KEY is the path to a PEM file or the
+	// PEM itself, with newlines escaped as \n. RSA keys sign with RS256 and
+	// Ed25519 keys with EdDSA.
+	JWTSigningKeys string `mapstructure:"JWT_SIGNING_KEYS"`
+
+	// OpenID Connect providers users may sign in with, given as
+	// NAME|ISSUER|CLIENT_ID|CLIENT_SECRET, comma separated. Providers
+	// redirect back to the redirect URL, where {provider} is replaced by
+	// the name of the provider.
+	OIDCProviders   string `mapstructure:"OIDC_PROVIDERS"`
+	OIDCRedirectURL string `mapstructure:"OIDC_REDIRECT_URL" validate:"omitempty,url"`
+
+	// Password reset and e-mail verification links point to APP_URL, and
+	// their tokens can be used once before they expire.
+	AppURL                    string        `mapstructure:"APP_URL"                      validate:"omitempty,url"`
+	PasswordResetTokenTTL     time.Duration `mapstructure:"PASSWORD_RESET_TOKEN_TTL"     validate:"min=0"`
+	EmailVerificationTokenTTL time.Duration `mapstructure:"EMAIL_VERIFICATION_TOKEN_TTL" validate:"min=0"`
+
+	// How e-mails are sent. The file driver, meant for development, logs
+	// them, and appends them as JSON lines to MAILER_FILE_PATH when set.
+	MailerDriver   MailerDriver `mapstructure:"MAILER_DRIVER"    validate:"omitempty,oneof=smtp file"`
+	MailerFrom     string       `mapstructure:"MAILER_FROM"      validate:"omitempty,email"`
+	MailerFilePath string       `mapstructure:"MAILER_FILE_PATH"`
+	SMTPHost       string       `mapstructure:"SMTP_HOST"        validate:"required_if=MailerDriver smtp"`
+	SMTPPort       int          `mapstructure:"SMTP_PORT"        validate:"min=0"`
+	SMTPUsername   string       `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword   string       `mapstructure:"SMTP_PASSWORD"`
+
+	// Where audit events are appended: the database, or a file of JSON
+	// lines at AUDIT_FILE_PATH.
+	AuditDriver   AuditDriver `mapstructure:"AUDIT_DRIVER"    validate:"omitempty,oneof=database file"`
+	AuditFilePath string      `mapstructure:"AUDIT_FILE_PATH" validate:"required_if=AuditDriver file"`
+
+	// Where users are stored. DATABASE_URL is a file path or URI for
+	// SQLite, and a connection string for Postgres.
+	DatabaseDriver DatabaseDriver `mapstructure:"DATABASE_DRIVER" validate:"omitempty,oneof=sqlite postgres memory"`
+	DatabaseURL    string         `mapstructure:"DATABASE_URL"    validate:"required_unless=DatabaseDriver memory"`
+
+	// Provider budgets, where a zero limit means unlimited
+	// and costs are expressed in cents.
+	AmadeusAPIDailyCallLimit int64 `mapstructure:"AMADEUS_API_DAILY_CALL_LIMIT" validate:"min=0"`
+	AmadeusAPIDailyCostLimit int64 `mapstructure:"AMADEUS_API_DAILY_COST_LIMIT" validate:"min=0"`
+	AmadeusAPICostPerCall    int64 `mapstructure:"AMADEUS_API_COST_PER_CALL"    validate:"min=0"`
+	SerpAPIDailyCallLimit    int64 `mapstructure:"SERP_API_DAILY_CALL_LIMIT"    validate:"min=0"`
+	SerpAPIDailyCostLimit    int64 `mapstructure:"SERP_API_DAILY_COST_LIMIT"    validate:"min=0"`
+	SerpAPICostPerCall       int64 `mapstructure:"SERP_API_COST_PER_CALL"       validate:"min=0"`
+	DuffelAPIDailyCallLimit  int64 `mapstructure:"DUFFEL_API_DAILY_CALL_LIMIT"  validate:"min=0"`
+	DuffelAPIDailyCostLimit  int64 `mapstructure:"DUFFEL_API_DAILY_COST_LIMIT"  validate:"min=0"`
+	DuffelAPICostPerCall     int64 `mapstructure:"DUFFEL_API_COST_PER_CALL"     validate:"min=0"`
+
+	// How long each provider search result is cached for.
+	AmadeusAPICacheTTL time.Duration `mapstructure:"AMADEUS_API_CACHE_TTL" validate:"min=0"`
+	SerpAPICacheTTL    time.Duration `mapstructure:"SERP_API_CACHE_TTL"    validate:"min=0"`
+	DuffelAPICacheTTL  time.Duration `mapstructure:"DUFFEL_API_CACHE_TTL"  validate:"min=0"`
+
+	// Organizations search the providers enabled for them with their own
+	// credentials, encrypted at rest with PROVIDER_CREDENTIALS_KEY, a base64
+	// encoded 32 bytes key, required once there are organizations, while
+	// users without one use the keys above.
+	// The clients of an organization are reused until the TTL, when its
+	// providers are loaded again.
+	ProviderCredentialsKey string        `mapstructure:"PROVIDER_CREDENTIALS_KEY" validate:"omitempty,base64"`
+	TenantClientsTTL       time.Duration `mapstructure:"TENANT_CLIENTS_TTL"       validate:"min=0"`
+
+	// How Redis stores structured values, compressing the ones larger than
+	// the threshold in bytes.
+	CacheCodec                CacheCodec       `mapstructure:"CACHE_CODEC"                 validate:"omitempty,oneof=json msgpack gob"`
+	CacheCompression          CacheCompression `mapstructure:"CACHE_COMPRESSION"           validate:"omitempty,oneof=none zstd snappy"`
+	CacheCompressionThreshold int              `mapstructure:"CACHE_COMPRESSION_THRESHOLD" validate:"min=0"`
+
+	// How long entries are kept in the in-process tier of the tiered cache.
+	TieredCacheL1TTL time.Duration `mapstructure:"TIERED_CACHE_L1_TTL" validate:"min=0"`
+
+	// After the soft TTL a cached search is served stale while it is
+	// refreshed in background, after the hard TTL it is no longer served.
+	// Searches departing at least DAYS days from now use the TTLs of their
+	// tier instead, given as DAYS:SOFT:HARD, comma separated, and both
+	// TTLs shrink as the prices of the route change more often between
+	// searches, by the volatility weight. Routes may override all of them
+	// as ORIGIN-DESTINATION:SOFT:HARD, comma separated, e.g.
+	// GRU-JFK:1m:10m,LAX-JFK:10s:1m.
+	SearchCacheSoftTTL          time.Duration `mapstructure:"SEARCH_CACHE_SOFT_TTL"          validate:"min=0"`
+	SearchCacheHardTTL          time.Duration `mapstructure:"SEARCH_CACHE_HARD_TTL"          validate:"min=0"`
+	SearchCacheDepartureTTLs    string        `mapstructure:"SEARCH_CACHE_DEPARTURE_TTLS"`
+	SearchCacheVolatilityWeight float64       `mapstructure:"SEARCH_CACHE_VOLATILITY_WEIGHT" validate:"min=0"`
+	SearchCacheRoutes           string        `mapstructure:"SEARCH_CACHE_ROUTES"`
+
+	// When enabled, a single instance searches the providers at a time for
+	// the same search, while the others wait for its cached result.
+	SearchLockEnabled bool          `mapstructure:"SEARCH_LOCK_ENABLED"`
+	SearchLockTTL     time.Duration `mapstructure:"SEARCH_LOCK_TTL"     validate:"min=0"`
+	SearchLockWait    time.Duration `mapstructure:"SEARCH_LOCK_WAIT"    validate:"min=0"`
+
+	// Requests allowed per client, as BUDGET[:PLAN]=LIMIT/WINDOW, comma
+	// separated, e.g. search=20/1m,search:pro=200/1m. Routes are counted
+	// against the search, login or default budget, and plans without
+	// their own limit use the limit of the budget. Every request is also
+	// counted per IP address, before authentication, against the ip
+	// budget.
+	RateLimits string `mapstructure:"RATE_LIMITS"`
+
+	// Failed logins are counted per account and per IP address within the
+	// window. Each failure of an account delays its next login, twice as
+	// long as the previous one up to the max delay, and reaching the max
+	// failures locks the account or IP address out for the duration.
+	LoginFailureWindow    time.Duration `mapstructure:"LOGIN_FAILURE_WINDOW"      validate:"min=0"`
+	LoginMaxFailures      int64         `mapstructure:"LOGIN_MAX_FAILURES"        validate:"min=0"`
+	LoginMaxFailuresPerIP int64         `mapstructure:"LOGIN_MAX_FAILURES_PER_IP" validate:"min=0"`
+	LoginLockoutDuration  time.Duration `mapstructure:"LOGIN_LOCKOUT_DURATION"    validate:"min=0"`
+	LoginDelay            time.Duration `mapstructure:"LOGIN_DELAY"               validate:"min=0"`
+	LoginMaxDelay         time.Duration `mapstructure:"LOGIN_MAX_DELAY"           validate:"min=0"`
+
+	// The cache warmer refreshes the most searched routes and dates every
+	// interval, while no provider has used more than the given percentage
+	// of its daily budget.
+	CacheWarmerEnabled        bool          `mapstructure:"CACHE_WARMER_ENABLED"`
+	CacheWarmerInterval       time.Duration `mapstructure:"CACHE_WARMER_INTERVAL"         validate:"min=0"`
+	CacheWarmerTopN           int           `mapstructure:"CACHE_WARMER_TOP_N"            validate:"min=0"`
+	CacheWarmerMaxBudgetUsage int64         `mapstructure:"CACHE_WARMER_MAX_BUDGET_USAGE" validate:"min=0,max=100"`
+
+	// The price alert watcher checks the active alerts every interval,
+	// searching concurrently up to the given number of alerts, and skips
+	// the alerts of tenants whose providers have used more than the given
+	// percentage of their daily budget. Users can keep up to the max
+	// alerts each, and the latest max checks of each alert are kept.
+	PriceAlertWatcherEnabled        bool          `mapstructure:"PRICE_ALERT_WATCHER_ENABLED"`
+	PriceAlertWatcherInterval       time.Duration `mapstructure:"PRICE_ALERT_WATCHER_INTERVAL"         validate:"min=0"`
+	PriceAlertWatcherConcurrency    int           `mapstructure:"PRICE_ALERT_WATCHER_CONCURRENCY"      validate:"min=0"`
+	PriceAlertWatcherMaxBudgetUsage int64         `mapstructure:"PRICE_ALERT_WATCHER_MAX_BUDGET_USAGE" validate:"min=0,max=100"`
+	PriceAlertMaxPerUser            int           `mapstructure:"PRICE_ALERT_MAX_PER_USER"             validate:"min=0"`
+	PriceAlertMaxChecks             int           `mapstructure:"PRICE_ALERT_MAX_CHECKS"               validate:"min=0"`
 }
 
 func NewEnv(v validator.Validator) *Env {
@@ -103,5 +298,132 @@ func (e *Env) validate() error {
 	if e.Port == "" {
 		e.Port = "8080"
 	}
+	if e.CacheDriver == "" {
+		e.CacheDriver = CacheDriverRedis
+	}
+	if e.JWTAccessTokenTTL == 0 {
+		e.JWTAccessTokenTTL = 15 * time.Minute
+	}
+	if e.JWTRefreshTokenTTL == 0 {
+		e.JWTRefreshTokenTTL = 30 * 24 * time.Hour
+	}
+	if e.AppURL == "" {
+		e.AppURL = "http://localhost:" + e.Port
+	}
+	if e.PasswordResetTokenTTL == 0 {
+		e.PasswordResetTokenTTL = time.Hour
+	}
+	if e.EmailVerificationTokenTTL == 0 {
+		e.EmailVerificationTokenTTL = 48 * time.Hour
+	}
+	if e.AuditDriver == "" {
+		e.AuditDriver = AuditDriverDatabase
+	}
+	if e.MailerDriver == "" {
+		e.MailerDriver = MailerDriverFile
+	}
+	if e.MailerFrom == "" {
+		e.MailerFrom = "no-reply@flightapi.local"
+	}
+	if e.SMTPPort == 0 {
+		e.SMTPPort = 587
+	}
+	if e.OIDCRedirectURL == "" {
+		e.OIDCRedirectURL = "http://localhost:" + e.Port +
+			"/api/v1/auth/oidc/{provider}/callback"
+	}
+	if e.DatabaseDriver == "" {
+		e.DatabaseDriver = DatabaseDriverSQLite
+	}
+	if e.InMemoryCacheMaxEntries == 0 {
+		e.InMemoryCacheMaxEntries = 10_000
+	}
+	if e.CacheCodec == "" {
+		e.CacheCodec = CacheCodecJSON
+	}
+	if e.CacheCompression == "" {
+		e.CacheCompression = CacheCompressionNone
+	}
+	if e.CacheCompressionThreshold == 0 {
+		e.CacheCompressionThreshold = 1024
+	}
+	if e.TieredCacheL1TTL == 0 {
+		e.TieredCacheL1TTL = 5 * time.Second
+	}
+	if e.AmadeusAPICacheTTL == 0 {
+		e.AmadeusAPICacheTTL = time.Minute
+	}
+	if e.SerpAPICacheTTL == 0 {
+		e.SerpAPICacheTTL = time.Minute
+	}
+	if e.DuffelAPICacheTTL == 0 {
+		e.DuffelAPICacheTTL = time.Minute
+	}
+	if e.TenantClientsTTL == 0 {
+		e.TenantClientsTTL = time.Minute
+	}
+	if e.SearchCacheSoftTTL == 0 {
+		e.SearchCacheSoftTTL = 30 * time.Second
+	}
+	if e.SearchCacheHardTTL == 0 {
+		e.SearchCacheHardTTL = 5 * time.Minute
+	}
+	if e.SearchCacheDepartureTTLs == "" {
+		e.SearchCacheDepartureTTLs = "7:2m:15m,30:10m:1h,90:30m:6h"
+	}
+	if e.SearchCacheVolatilityWeight == 0 {
+		e.SearchCacheVolatilityWeight = 10
+	}
+	if e.SearchLockTTL == 0 {
+		e.SearchLockTTL = time.Minute
+	}
+	if e.SearchLockWait == 0 {
+		e.SearchLockWait = 10 * time.Second
+	}
+	if e.RateLimits == "" {
+		e.RateLimits = "default=60/1m,search=20/1m,login=5/1m,ip=300/1m"
+	}
+	if e.LoginFailureWindow == 0 {
+		e.LoginFailureWindow = 15 * time.Minute
+	}
+	if e.LoginMaxFailures == 0 {
+		e.LoginMaxFailures = 5
+	}
+	if e.LoginMaxFailuresPerIP == 0 {
+		e.LoginMaxFailuresPerIP = 20
+	}
+	if e.LoginLockoutDuration == 0 {
+		e.LoginLockoutDuration = 15 * time.Minute
+	}
+	if e.LoginDelay == 0 {
+		e.LoginDelay = time.Second
+	}
+	if e.LoginMaxDelay == 0 {
+		e.LoginMaxDelay = 30 * time.Second
+	}
+	if e.CacheWarmerInterval == 0 {
+		e.CacheWarmerInterval = 10 * time.Minute
+	}
+	if e.CacheWarmerTopN == 0 {
+		e.CacheWarmerTopN = 20
+	}
+	if e.CacheWarmerMaxBudgetUsage == 0 {
+		e.CacheWarmerMaxBudgetUsage = 80
+	}
+	if e.PriceAlertWatcherInterval == 0 {
+		e.PriceAlertWatcherInterval = time.Hour
+	}
+	if e.PriceAlertWatcherConcurrency == 0 {
+		e.PriceAlertWatcherConcurrency = 4
+	}
+	if e.PriceAlertWatcherMaxBudgetUsage == 0 {
+		e.PriceAlertWatcherMaxBudgetUsage = 60
+	}
+	if e.PriceAlertMaxPerUser == 0 {
+		e.PriceAlertMaxPerUser = 10
+	}
+	if e.PriceAlertMaxChecks == 0 {
+		e.PriceAlertMaxChecks = 1000
+	}
 	return nil
 }
@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+// Scope is a permission granted to an API key.
+type Scope = string
+
+const (
+	ScopeFlightsSearch Scope = "flights:search"
+)
+
+// Scopes are every scope, all granted to users logged in with their
+// password.
+var Scopes = []Scope{
+	ScopeFlightsSearch,
+}
+
+// APIKey authenticates a machine client on behalf of a user. Only the
+// hash of the key is stored.
+type APIKey struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+	// Prefix is the start of the key, to tell keys apart.
+	Prefix     string    `json:"prefix"`
+	Hash       string    `json:"-"`
+	Scopes     []Scope   `json:"scopes"`
+	ExpiresAt  time.Time `json:"expires_at,omitzero"`
+	LastUsedAt time.Time `json:"last_used_at,omitzero"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// IsExpired reports whether the key expired at the given time.
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt)
+}
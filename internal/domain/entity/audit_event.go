@@ -0,0 +1,77 @@
+package entity
+
+import "time"
+
+// AuditAction is what an audit event records.
+type AuditAction = string
+
+const (
+	AuditActionLogin              AuditAction = "auth.login"
+	AuditActionRegister           AuditAction = "auth.register"
+	AuditActionLogout             AuditAction = "auth.logout"
+	AuditActionRefresh            AuditAction = "auth.refresh"
+	AuditActionOIDCLogin          AuditAction = "auth.oidc_login"
+	AuditActionPasswordForgot     AuditAction = "auth.password_forgot"
+	AuditActionPasswordReset      AuditAction = "auth.password_reset"
+	AuditActionEmailVerify        AuditAction = "auth.email_verify"
+	AuditActionAPIKeyCreate       AuditAction = "api_key.create"
+	AuditActionAPIKeyRevoke       AuditAction = "api_key.revoke"
+	AuditActionCachePurge         AuditAction = "admin.cache_purge"
+	AuditActionRateLimitFlush     AuditAction = "admin.rate_limit_flush"
+	AuditActionUserRolesUpdate    AuditAction = "admin.user_roles_update"
+	AuditActionUserSessionsRevoke AuditAction = "admin.user_sessions_revoke"
+	AuditActionOrganizationAssign AuditAction = "admin.organization_assign"
+	AuditActionLoginUnlock        AuditAction = "admin.login_unlock"
+	AuditActionOrganizationCreate AuditAction = "admin.organization_create"
+	AuditActionProviderConfigure  AuditAction = "admin.provider_configure"
+)
+
+// AuditOutcome is whether an audited action succeeded.
+type AuditOutcome = string
+
+const (
+	AuditOutcomeSuccess AuditOutcome = "success"
+	AuditOutcomeFailure AuditOutcome = "failure"
+)
+
+// AuditEvent records who did what to which target, from where, and
+// whether it succeeded. Events are only ever appended.
+type AuditEvent struct {
+	ID string `json:"id"`
+	// Actor did the action, as user:{id}, apikey:{id}, admin:{username}
+	// or, before they are authenticated, email:{address}.
+	Actor     string       `json:"actor"`
+	IP        string       `json:"ip"`
+	RequestID string       `json:"request_id"`
+	Action    AuditAction  `json:"action"`
+	Target    string       `json:"target"`
+	Outcome   AuditOutcome `json:"outcome"`
+	// Reason tells why the action failed.
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuditEventFilter selects audit events. Empty fields match any event.
+type AuditEventFilter struct {
+	Actor   string       `json:"actor"`
+	IP      string       `json:"ip"`
+	Action  AuditAction  `json:"action"`
+	Target  string       `json:"target"`
+	Outcome AuditOutcome `json:"outcome"`
+	From    time.Time    `json:"from"`
+	To      time.Time    `json:"to"`
+	// Limit is how many events to return at most, newest first.
+	Limit int `json:"limit"`
+}
+
+// Match reports whether the event is selected by the filter, regardless
+// of its limit.
+func (f *AuditEventFilter) Match(event AuditEvent) bool {
+	return (f.Actor == "" || f.Actor == event.Actor) &&
+		(f.IP == "" || f.IP == event.IP) &&
+		(f.Action == "" || f.Action == event.Action) &&
+		(f.Target == "" || f.Target == event.Target) &&
+		(f.Outcome == "" || f.Outcome == event.Outcome) &&
+		(f.From.IsZero() || !event.CreatedAt.Before(f.From)) &&
+		(f.To.IsZero() || event.CreatedAt.Before(f.To))
+}
@@ -13,4 +13,6 @@ type Flight struct {
 	Price        int64     `json:"price"`
 	IsCheapest   bool      `json:"is_cheapest"`
 	IsFastest    bool      `json:"is_fastest"`
+	// ExpiresAt is when the provider stops honoring the offer, if known.
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
 }
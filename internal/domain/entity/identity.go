@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// Identity links a user to their account at an external identity
+// provider, identified by the provider and the subject it assigned.
+type Identity struct {
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
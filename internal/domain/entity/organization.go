@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// Organization is a tenant, such as an agency reselling the API, which
+// searches the providers with its own contracts.
+type Organization struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OrganizationProvider enables a flight provider for an organization.
+// Its credentials are encrypted at rest, and never returned.
+type OrganizationProvider struct {
+	OrganizationID string    `json:"organization_id"`
+	Provider       string    `json:"provider"`
+	Enabled        bool      `json:"enabled"`
+	Credentials    []byte    `json:"-"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
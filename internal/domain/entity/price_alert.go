@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+// PriceAlert watches the cheapest price of a route and date, notifying
+// its user when it drops below a target price, or by a percentage.
+type PriceAlert struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	Origin      string    `json:"origin"`
+	Destination string    `json:"destination"`
+	Date        time.Time `json:"date"`
+	// TargetPrice is the price, in cents, to notify below, if any.
+	TargetPrice int64 `json:"target_price,omitempty"`
+	// DropPercent is how much the price must drop from the base price to
+	// notify, if at all.
+	DropPercent int64 `json:"drop_percent,omitempty"`
+	// Active alerts are watched until their date passes.
+	Active bool `json:"active"`
+	// BasePrice is the price drops are measured from: the first price
+	// seen, then the last one notified.
+	BasePrice     int64     `json:"base_price,omitempty"`
+	LastPrice     int64     `json:"last_price,omitempty"`
+	LastCheckedAt time.Time `json:"last_checked_at,omitzero"`
+	NotifiedAt    time.Time `json:"notified_at,omitzero"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Triggered reports whether the price must be notified: if it is below
+// the target price and lower than the price last notified, or if it
+// dropped by the drop percentage from the base price.
+func (a *PriceAlert) Triggered(price int64) bool {
+	if a.TargetPrice > 0 && price < a.TargetPrice &&
+		(a.NotifiedAt.IsZero() || price < a.BasePrice) {
+		return true
+	}
+
+	return a.DropPercent > 0 && a.BasePrice > 0 &&
+		price*100 <= a.BasePrice*(100-a.DropPercent)
+}
+
+// PriceAlertCheck records the cheapest price found by a check of an
+// alert, and whether it was notified.
+type PriceAlertCheck struct {
+	ID        string    `json:"id"`
+	AlertID   string    `json:"alert_id"`
+	Price     int64     `json:"price"`
+	Notified  bool      `json:"notified"`
+	CheckedAt time.Time `json:"checked_at"`
+}
@@ -0,0 +1,8 @@
+package entity
+
+// Role grants a user access to restricted routes.
+type Role = string
+
+const (
+	RoleAdmin Role = "admin"
+)
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// SavedSearch is a flight search a user saved to run again. Its date is
+// resolved each time it runs, so that a search for "+30d" keeps looking
+// 30 days ahead.
+type SavedSearch struct {
+	ID          string `json:"id"`
+	UserID      string `json:"user_id"`
+	Name        string `json:"name"`
+	Origin      string `json:"origin"`
+	Destination string `json:"destination"`
+	// Date is a date, as 2006-01-02, or relative to the day the search
+	// runs, such as "tomorrow", "+30d" or "next friday".
+	Date        string    `json:"date"`
+	SortBy      string    `json:"sort_by,omitempty"`
+	SortOrder   string    `json:"sort_order,omitempty"`
+	MaxPrice    int64     `json:"max_price,omitempty"`
+	MaxDuration int64     `json:"max_duration,omitempty"`
+	PageSize    int       `json:"page_size,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
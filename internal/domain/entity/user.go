@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+type User struct {
+	ID           string `json:"id"`
+	Email        string `json:"email"`
+	PasswordHash string `json:"-"`
+	Plan         string `json:"plan,omitzero"`
+	Roles        []Role `json:"roles"`
+	// OrganizationID is the tenant the user belongs to, empty for the
+	// default one.
+	OrganizationID string `json:"organization_id,omitzero"`
+	// EmailVerifiedAt is zero until the user proves they own the e-mail.
+	EmailVerifiedAt time.Time `json:"email_verified_at,omitzero"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
@@ -0,0 +1,16 @@
+package errs
+
+var (
+	ErrAPIKeyNotFound = New(
+		"API key not found",
+		ErrCodeNotFound,
+	)
+	ErrAPIKeyExpirationInPast = New(
+		"API key expiration must be in the future",
+		ErrCodeValidation,
+	)
+	ErrInvalidAPIKey = New(
+		"Invalid or expired API key",
+		ErrCodeUnauthorized,
+	)
+)
@@ -0,0 +1,12 @@
+package errs
+
+var (
+	ErrCacheStatsUnavailable = New(
+		"Cache statistics are not available for the configured cache driver",
+		ErrCodeNotFound,
+	)
+	ErrCacheKeysFilterRequired = New(
+		"A pattern, route or date is required to purge cache keys",
+		ErrCodeValidation,
+	)
+)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"runtime/debug"
+	"time"
 )
 
 type Err struct {
@@ -11,6 +12,9 @@ type Err struct {
 	StackTrace string
 	Code       Code
 	Errors     []ErrorItem
+	// RetryAfter is how long to wait before trying again, when known,
+	// for errors with ErrCodeTooManyRequests.
+	RetryAfter time.Duration
 }
 
 type ErrorItem struct {
@@ -21,11 +25,13 @@ type ErrorItem struct {
 type Code string
 
 const (
-	ErrCodeUnknown      Code = "unknown"
-	ErrCodeNotFound     Code = "not_found"
-	ErrCodeUnauthorized Code = "unauthorized"
-	ErrCodeForbidden    Code = "forbidden"
-	ErrCodeValidation   Code = "validation_error"
+	ErrCodeUnknown         Code = "unknown"
+	ErrCodeNotFound        Code = "not_found"
+	ErrCodeUnauthorized    Code = "unauthorized"
+	ErrCodeForbidden       Code = "forbidden"
+	ErrCodeValidation      Code = "validation_error"
+	ErrCodeConflict        Code = "conflict"
+	ErrCodeTooManyRequests Code = "too_many_requests"
 )
 
 // NewErr creates a new Err instance from either an error or a string,
@@ -5,4 +5,16 @@ var (
 		"No flight was found for this origin and destination in the given date",
 		ErrCodeNotFound,
 	)
+	ErrFlightAPIBudgetExhausted = New(
+		"Flight API daily budget exhausted",
+		ErrCodeForbidden,
+	)
+	ErrFlightProviderNotFound = New(
+		"Flight provider not found",
+		ErrCodeNotFound,
+	)
+	ErrNoFlightProviderEnabled = New(
+		"No flight provider is enabled for this organization",
+		ErrCodeNotFound,
+	)
 )
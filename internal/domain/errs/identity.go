@@ -0,0 +1,32 @@
+package errs
+
+var (
+	ErrIdentityNotFound = New(
+		"Identity not found",
+		ErrCodeNotFound,
+	)
+	ErrIdentityAlreadyExists = New(
+		"This identity is already linked to a user",
+		ErrCodeConflict,
+	)
+	ErrOIDCProviderNotFound = New(
+		"OpenID Connect provider not found",
+		ErrCodeNotFound,
+	)
+	ErrInvalidOIDCState = New(
+		"Invalid or expired OpenID Connect login, start it again",
+		ErrCodeUnauthorized,
+	)
+	ErrInvalidIDToken = New(
+		"Invalid ID token",
+		ErrCodeUnauthorized,
+	)
+	ErrUnverifiedEmail = New(
+		"The e-mail of the identity provider account is not verified",
+		ErrCodeForbidden,
+	)
+	ErrUnverifiedUserEmail = New(
+		"Verify the e-mail of your account to sign in with this provider",
+		ErrCodeForbidden,
+	)
+)
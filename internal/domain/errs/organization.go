@@ -0,0 +1,16 @@
+package errs
+
+var (
+	ErrOrganizationNotFound = New(
+		"Organization not found",
+		ErrCodeNotFound,
+	)
+	ErrOrganizationAlreadyExists = New(
+		"An organization with this name already exists",
+		ErrCodeConflict,
+	)
+	ErrOrganizationProviderCredentialsMissing = New(
+		"Credentials are required to enable this provider",
+		ErrCodeValidation,
+	)
+)
@@ -0,0 +1,16 @@
+package errs
+
+var (
+	ErrPriceAlertNotFound = New(
+		"Price alert not found",
+		ErrCodeNotFound,
+	)
+	ErrPriceAlertDateInPast = New(
+		"Price alert date must be today or later",
+		ErrCodeValidation,
+	)
+	ErrPriceAlertLimitReached = New(
+		"Price alert limit reached, delete an alert to create another one",
+		ErrCodeConflict,
+	)
+)
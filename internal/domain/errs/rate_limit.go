@@ -0,0 +1,8 @@
+package errs
+
+var (
+	ErrTooManyRequests = New(
+		"Too many requests, try again later",
+		ErrCodeTooManyRequests,
+	)
+)
@@ -0,0 +1,17 @@
+package errs
+
+var (
+	ErrSavedSearchNotFound = New(
+		"Saved search not found",
+		ErrCodeNotFound,
+	)
+	ErrSavedSearchAlreadyExists = New(
+		"A saved search with this name already exists",
+		ErrCodeConflict,
+	)
+	ErrInvalidSavedSearchDate = New(
+		"Invalid date, use YYYY-MM-DD, today, tomorrow, +N followed by "+
+			"d, w or m, or next followed by a weekday",
+		ErrCodeValidation,
+	)
+)
@@ -0,0 +1,48 @@
+package errs
+
+var (
+	ErrUserNotFound = New(
+		"User not found",
+		ErrCodeNotFound,
+	)
+	ErrUserAlreadyExists = New(
+		"A user with this e-mail already exists",
+		ErrCodeConflict,
+	)
+	ErrInvalidCredentials = New(
+		"Invalid e-mail or password",
+		ErrCodeUnauthorized,
+	)
+	ErrInvalidRefreshToken = New(
+		"Invalid or expired refresh token",
+		ErrCodeUnauthorized,
+	)
+	ErrRefreshTokenReused = New(
+		"Refresh token already used, its session was revoked",
+		ErrCodeUnauthorized,
+	)
+	ErrTokenRevoked = New(
+		"Token was revoked",
+		ErrCodeUnauthorized,
+	)
+	ErrMissingRole = New(
+		"Missing the role required to access this resource",
+		ErrCodeForbidden,
+	)
+	ErrMissingScope = New(
+		"Missing the scope required to access this resource",
+		ErrCodeForbidden,
+	)
+	ErrInvalidPasswordResetToken = New(
+		"Invalid or expired password reset token",
+		ErrCodeUnauthorized,
+	)
+	ErrInvalidEmailVerificationToken = New(
+		"Invalid or expired e-mail verification token",
+		ErrCodeUnauthorized,
+	)
+	ErrEmailAlreadyVerified = New(
+		"The e-mail is already verified",
+		ErrCodeConflict,
+	)
+)
@@ -0,0 +1,33 @@
+// Package apikey manages the API keys machine clients authenticate with.
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const (
+	// keyPrefix starts every key, so that leaked keys are easy to spot.
+	keyPrefix = "fa_"
+	// keySize is how many random bytes a key has.
+	keySize = 32
+	// displayPrefixLen is how many characters of a key are kept to tell
+	// keys apart.
+	displayPrefixLen = len(keyPrefix) + 8
+)
+
+// generateKey returns a new random key.
+func generateKey() string {
+	b := make([]byte, keySize)
+	_, _ = rand.Read(b)
+	return keyPrefix + base64.RawURLEncoding.EncodeToString(b)
+}
+
+// hashKey returns the hash a key is stored and looked up by. Keys are
+// random enough that a fast hash is as safe as a password hash.
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
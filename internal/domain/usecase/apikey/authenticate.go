@@ -0,0 +1,97 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+// touchInterval is how stale the last use of a key may get, so that
+// busy keys don't write to the database on every request.
+const touchInterval = time.Minute
+
+type AuthenticateAPIKeyUseCase struct {
+	v validator.Validator
+	r repo.APIKeyRepository
+	u repo.UserRepository
+}
+
+func NewAuthenticateAPIKeyUseCase(
+	v validator.Validator,
+	r repo.APIKeyRepository,
+	u repo.UserRepository,
+) *AuthenticateAPIKeyUseCase {
+	return &AuthenticateAPIKeyUseCase{
+		v: v,
+		r: r,
+		u: u,
+	}
+}
+
+type AuthenticateAPIKeyUseCaseInput struct {
+	Key string `json:"key" validate:"required"`
+}
+
+type AuthenticateAPIKeyUseCaseOutput struct {
+	APIKey entity.APIKey `json:"api_key"`
+	User   entity.User   `json:"user"`
+}
+
+// Execute returns the key and its owner, unless the key is unknown or
+// expired.
+func (a *AuthenticateAPIKeyUseCase) Execute(
+	ctx context.Context,
+	in AuthenticateAPIKeyUseCaseInput,
+) (*AuthenticateAPIKeyUseCaseOutput, error) {
+	if err := a.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+	if !strings.HasPrefix(in.Key, keyPrefix) {
+		return nil, errs.ErrInvalidAPIKey
+	}
+
+	key, err := a.r.GetAPIKeyByHash(ctx, hashKey(in.Key))
+	if errors.Is(err, errs.ErrAPIKeyNotFound) {
+		return nil, errs.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	now := time.Now()
+	if key.IsExpired(now) {
+		return nil, errs.ErrInvalidAPIKey
+	}
+
+	user, err := a.u.GetUserByID(ctx, key.UserID)
+	if errors.Is(err, errs.ErrUserNotFound) {
+		return nil, errs.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	if now.Sub(key.LastUsedAt) >= touchInterval {
+		if err := a.r.TouchAPIKey(ctx, key.ID, now); err != nil {
+			slog.ErrorContext(
+				ctx,
+				"failed to record API key use",
+				"error", err,
+			)
+		} else {
+			key.LastUsedAt = now
+		}
+	}
+
+	return &AuthenticateAPIKeyUseCaseOutput{
+		APIKey: *key,
+		User:   *user,
+	}, nil
+}
@@ -0,0 +1,114 @@
+package apikey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/mockrepo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthenticateAPIKeyUseCase_Execute(t *testing.T) {
+	key := generateKey()
+	user := &entity.User{ID: "1", Email: "johndoe@email.com", Plan: "pro"}
+	apiKey := &entity.APIKey{
+		ID:     "key",
+		UserID: "1",
+		Hash:   hashKey(key),
+		Scopes: []entity.Scope{entity.ScopeFlightsSearch},
+	}
+
+	type Test struct {
+		name    string
+		r       *mockrepo.MockRepository
+		args    AuthenticateAPIKeyUseCaseInput
+		wantErr error
+	}
+	tests := []Test{
+		func() Test {
+			r := mockrepo.NewMockRepository(t)
+			r.EXPECT().GetAPIKeyByHash(mock.Anything, apiKey.Hash).
+				Return(apiKey, nil)
+			r.EXPECT().GetUserByID(mock.Anything, "1").Return(user, nil)
+			r.EXPECT().TouchAPIKey(mock.Anything, "key", mock.Anything).
+				Return(nil)
+
+			return Test{
+				name: "authenticates and records the use",
+				r:    r,
+				args: AuthenticateAPIKeyUseCaseInput{Key: key},
+			}
+		}(),
+		func() Test {
+			recentlyUsed := *apiKey
+			recentlyUsed.LastUsedAt = time.Now()
+
+			r := mockrepo.NewMockRepository(t)
+			r.EXPECT().GetAPIKeyByHash(mock.Anything, apiKey.Hash).
+				Return(&recentlyUsed, nil)
+			r.EXPECT().GetUserByID(mock.Anything, "1").Return(user, nil)
+
+			return Test{
+				name: "doesn't record uses again right away",
+				r:    r,
+				args: AuthenticateAPIKeyUseCaseInput{Key: key},
+			}
+		}(),
+		func() Test {
+			expired := *apiKey
+			expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+			r := mockrepo.NewMockRepository(t)
+			r.EXPECT().GetAPIKeyByHash(mock.Anything, apiKey.Hash).
+				Return(&expired, nil)
+
+			return Test{
+				name:    "fails with an expired key",
+				r:       r,
+				args:    AuthenticateAPIKeyUseCaseInput{Key: key},
+				wantErr: errs.ErrInvalidAPIKey,
+			}
+		}(),
+		func() Test {
+			r := mockrepo.NewMockRepository(t)
+			r.EXPECT().GetAPIKeyByHash(mock.Anything, mock.Anything).
+				Return(nil, errs.ErrAPIKeyNotFound)
+
+			return Test{
+				name:    "fails with an unknown key",
+				r:       r,
+				args:    AuthenticateAPIKeyUseCaseInput{Key: generateKey()},
+				wantErr: errs.ErrInvalidAPIKey,
+			}
+		}(),
+		{
+			name:    "fails with a malformed key",
+			r:       mockrepo.NewMockRepository(t),
+			args:    AuthenticateAPIKeyUseCaseInput{Key: "key"},
+			wantErr: errs.ErrInvalidAPIKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAuthenticateAPIKeyUseCase(validator.New(), tt.r, tt.r)
+
+			got, err := a.Execute(context.Background(), tt.args)
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, "key", got.APIKey.ID)
+			assert.Equal(t, *user, got.User)
+			assert.False(t, got.APIKey.LastUsedAt.IsZero())
+		})
+	}
+}
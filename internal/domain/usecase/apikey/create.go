@@ -0,0 +1,75 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	"github.com/google/uuid"
+)
+
+type CreateAPIKeyUseCase struct {
+	v validator.Validator
+	r repo.APIKeyRepository
+}
+
+func NewCreateAPIKeyUseCase(
+	v validator.Validator,
+	r repo.APIKeyRepository,
+) *CreateAPIKeyUseCase {
+	return &CreateAPIKeyUseCase{
+		v: v,
+		r: r,
+	}
+}
+
+type CreateAPIKeyUseCaseInput struct {
+	UserID    string         `json:"-"                    validate:"required"`
+	Name      string         `json:"name"                 validate:"required,max=100"`
+	Scopes    []entity.Scope `json:"scopes"               validate:"required,min=1,dive,oneof=flights:search"`
+	ExpiresAt time.Time      `json:"expires_at,omitzero"`
+}
+
+type CreateAPIKeyUseCaseOutput struct {
+	APIKey entity.APIKey `json:"api_key"`
+	// Key is only returned once, as just its hash is stored.
+	Key string `json:"key"`
+}
+
+func (c *CreateAPIKeyUseCase) Execute(
+	ctx context.Context,
+	in CreateAPIKeyUseCaseInput,
+) (*CreateAPIKeyUseCaseOutput, error) {
+	if err := c.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	now := time.Now()
+	if !in.ExpiresAt.IsZero() && !in.ExpiresAt.After(now) {
+		return nil, errs.ErrAPIKeyExpirationInPast
+	}
+
+	key := generateKey()
+	apiKey := entity.APIKey{
+		ID:        uuid.NewString(),
+		UserID:    in.UserID,
+		Name:      in.Name,
+		Prefix:    key[:displayPrefixLen],
+		Hash:      hashKey(key),
+		Scopes:    in.Scopes,
+		ExpiresAt: in.ExpiresAt,
+		CreatedAt: now,
+	}
+
+	if err := c.r.CreateAPIKey(ctx, apiKey); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &CreateAPIKeyUseCaseOutput{
+		APIKey: apiKey,
+		Key:    key,
+	}, nil
+}
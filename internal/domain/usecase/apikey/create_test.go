@@ -0,0 +1,98 @@
+package apikey
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/mockrepo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateAPIKeyUseCase_Execute(t *testing.T) {
+	type Test struct {
+		name    string
+		r       *mockrepo.MockAPIKeyRepository
+		args    CreateAPIKeyUseCaseInput
+		wantErr error
+	}
+	tests := []Test{
+		func() Test {
+			r := mockrepo.NewMockAPIKeyRepository(t)
+			r.EXPECT().
+				CreateAPIKey(mock.Anything, mock.MatchedBy(
+					func(key entity.APIKey) bool {
+						return key.UserID == "1" &&
+							strings.HasPrefix(key.Prefix, keyPrefix) &&
+							len(key.Hash) == 64
+					},
+				)).
+				Return(nil)
+
+			return Test{
+				name: "creates a key",
+				r:    r,
+				args: CreateAPIKeyUseCaseInput{
+					UserID:    "1",
+					Name:      "backend",
+					Scopes:    []entity.Scope{entity.ScopeFlightsSearch},
+					ExpiresAt: time.Now().Add(time.Hour),
+				},
+			}
+		}(),
+		{
+			name: "fails with an unknown scope",
+			r:    mockrepo.NewMockAPIKeyRepository(t),
+			args: CreateAPIKeyUseCaseInput{
+				UserID: "1",
+				Name:   "backend",
+				Scopes: []entity.Scope{"admin"},
+			},
+			wantErr: errs.New("", errs.ErrCodeValidation),
+		},
+		{
+			name: "fails without scopes",
+			r:    mockrepo.NewMockAPIKeyRepository(t),
+			args: CreateAPIKeyUseCaseInput{
+				UserID: "1",
+				Name:   "backend",
+			},
+			wantErr: errs.New("", errs.ErrCodeValidation),
+		},
+		{
+			name: "fails with an expiration in the past",
+			r:    mockrepo.NewMockAPIKeyRepository(t),
+			args: CreateAPIKeyUseCaseInput{
+				UserID:    "1",
+				Name:      "backend",
+				Scopes:    []entity.Scope{entity.ScopeFlightsSearch},
+				ExpiresAt: time.Now().Add(-time.Hour),
+			},
+			wantErr: errs.ErrAPIKeyExpirationInPast,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCreateAPIKeyUseCase(validator.New(), tt.r)
+
+			got, err := c.Execute(context.Background(), tt.args)
+
+			if tt.wantErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, errs.New(tt.wantErr).Code, errs.New(err).Code)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, hashKey(got.Key), got.APIKey.Hash)
+			assert.True(t, strings.HasPrefix(got.Key, got.APIKey.Prefix))
+			assert.Equal(t, tt.args.Scopes, got.APIKey.Scopes)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+type ListAPIKeysUseCase struct {
+	v validator.Validator
+	r repo.APIKeyRepository
+}
+
+func NewListAPIKeysUseCase(
+	v validator.Validator,
+	r repo.APIKeyRepository,
+) *ListAPIKeysUseCase {
+	return &ListAPIKeysUseCase{
+		v: v,
+		r: r,
+	}
+}
+
+type ListAPIKeysUseCaseInput struct {
+	UserID string `json:"-" validate:"required"`
+}
+
+type ListAPIKeysUseCaseOutput struct {
+	Data []entity.APIKey `json:"data"`
+}
+
+func (l *ListAPIKeysUseCase) Execute(
+	ctx context.Context,
+	in ListAPIKeysUseCaseInput,
+) (*ListAPIKeysUseCaseOutput, error) {
+	if err := l.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	keys, err := l.r.ListAPIKeys(ctx, in.UserID)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &ListAPIKeysUseCaseOutput{Data: keys}, nil
+}
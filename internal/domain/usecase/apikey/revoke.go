@@ -0,0 +1,45 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+type RevokeAPIKeyUseCase struct {
+	v validator.Validator
+	r repo.APIKeyRepository
+}
+
+func NewRevokeAPIKeyUseCase(
+	v validator.Validator,
+	r repo.APIKeyRepository,
+) *RevokeAPIKeyUseCase {
+	return &RevokeAPIKeyUseCase{
+		v: v,
+		r: r,
+	}
+}
+
+type RevokeAPIKeyUseCaseInput struct {
+	UserID string `json:"-" validate:"required"`
+	ID     string `json:"-" validate:"required"`
+}
+
+// Execute deletes the key, which can't be used anymore.
+func (r *RevokeAPIKeyUseCase) Execute(
+	ctx context.Context,
+	in RevokeAPIKeyUseCaseInput,
+) error {
+	if err := r.v.Validate(in); err != nil {
+		return errs.New(err)
+	}
+
+	if err := r.r.DeleteAPIKey(ctx, in.UserID, in.ID); err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
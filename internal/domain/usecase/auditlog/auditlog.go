@@ -0,0 +1,106 @@
+package auditlog
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/audit"
+	"github.com/google/uuid"
+)
+
+// Request is where an audited action comes from.
+type Request struct {
+	IP        string
+	RequestID string
+}
+
+type requestContextKey struct{}
+
+// WithRequest returns a context carrying the request, which events
+// recorded with it are stamped with.
+func WithRequest(ctx context.Context, r Request) context.Context {
+	return context.WithValue(ctx, requestContextKey{}, r)
+}
+
+// RequestFrom returns the request carried by the context, if any.
+func RequestFrom(ctx context.Context) Request {
+	r, _ := ctx.Value(requestContextKey{}).(Request)
+	return r
+}
+
+func UserActor(userID string) string {
+	return "user:" + userID
+}
+
+func APIKeyActor(apiKeyID string) string {
+	return "apikey:" + apiKeyID
+}
+
+func AdminActor(username string) string {
+	return "admin:" + username
+}
+
+func EmailActor(email string) string {
+	return "email:" + email
+}
+
+// Auditor records security relevant actions to the audit sink. Failing
+// to record an event is logged, but doesn't fail the action, so that an
+// unavailable sink doesn't lock everyone out.
+type Auditor struct {
+	s audit.AuditSink
+}
+
+func NewAuditor(
+	s audit.AuditSink,
+) *Auditor {
+	return &Auditor{
+		s: s,
+	}
+}
+
+// Record appends the event, with the outcome of the action given by the
+// error it failed with, if any, and the request from the context.
+func (a *Auditor) Record(
+	ctx context.Context,
+	event entity.AuditEvent,
+	actionErr error,
+) {
+	request := RequestFrom(ctx)
+
+	event.ID = uuid.NewString()
+	event.IP = request.IP
+	event.RequestID = request.RequestID
+	event.CreatedAt = time.Now()
+	event.Outcome = entity.AuditOutcomeSuccess
+	if actionErr != nil {
+		event.Outcome = entity.AuditOutcomeFailure
+		event.Reason = reason(actionErr)
+	}
+
+	// The event is recorded even if the request was canceled right after
+	// the action.
+	err := a.s.Record(context.WithoutCancel(ctx), event)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"failed to record audit event",
+			"action", event.Action,
+			"actor", event.Actor,
+			"error", err,
+		)
+	}
+}
+
+// reason returns the message of errors meant for clients, and hides the
+// details of unexpected ones.
+func reason(err error) string {
+	appErr := errs.New(err)
+	if appErr.Code == errs.ErrCodeUnknown {
+		return "internal error"
+	}
+	return appErr.Message
+}
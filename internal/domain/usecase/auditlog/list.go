@@ -0,0 +1,72 @@
+package auditlog
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/audit"
+)
+
+const defaultAuditEventsLimit = 100
+
+type ListAuditEventsUseCase struct {
+	v validator.Validator
+	s audit.AuditSink
+}
+
+func NewListAuditEventsUseCase(
+	v validator.Validator,
+	s audit.AuditSink,
+) *ListAuditEventsUseCase {
+	return &ListAuditEventsUseCase{
+		v: v,
+		s: s,
+	}
+}
+
+type ListAuditEventsUseCaseInput struct {
+	Actor   string              `json:"actor"`
+	IP      string              `json:"ip"`
+	Action  entity.AuditAction  `json:"action"`
+	Target  string              `json:"target"`
+	Outcome entity.AuditOutcome `json:"outcome" validate:"omitempty,oneof=success failure"`
+	From    time.Time           `json:"from"`
+	To      time.Time           `json:"to"`
+	Limit   int                 `json:"limit"   validate:"omitempty,min=1,max=1000"`
+}
+
+type ListAuditEventsUseCaseOutput struct {
+	Data []entity.AuditEvent `json:"data"`
+}
+
+func (l *ListAuditEventsUseCase) Execute(
+	ctx context.Context,
+	in ListAuditEventsUseCaseInput,
+) (*ListAuditEventsUseCaseOutput, error) {
+	if err := l.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	if in.Limit == 0 {
+		in.Limit = defaultAuditEventsLimit
+	}
+
+	events, err := l.s.Query(ctx, entity.AuditEventFilter{
+		Actor:   in.Actor,
+		IP:      in.IP,
+		Action:  in.Action,
+		Target:  in.Target,
+		Outcome: in.Outcome,
+		From:    in.From,
+		To:      in.To,
+		Limit:   in.Limit,
+	})
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &ListAuditEventsUseCaseOutput{Data: events}, nil
+}
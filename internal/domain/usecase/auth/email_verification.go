@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auditlog"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+	"github.com/danielmesquitta/flight-api/internal/provider/mailer"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+// EmailVerifier e-mails users a link to verify they own their e-mail.
+type EmailVerifier struct {
+	e *env.Env
+	c cache.Cache
+	m mailer.Mailer
+}
+
+func NewEmailVerifier(
+	e *env.Env,
+	c cache.Cache,
+	m mailer.Mailer,
+) *EmailVerifier {
+	return &EmailVerifier{
+		e: e,
+		c: c,
+		m: m,
+	}
+}
+
+// Send e-mails the user a new verification link. Links sent before remain
+// valid until they expire.
+func (v *EmailVerifier) Send(ctx context.Context, user entity.User) error {
+	token, err := issueOneTimeToken(
+		ctx,
+		v.c,
+		oneTimeTokenEmailVerification,
+		user.ID,
+		v.e.EmailVerificationTokenTTL,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	link := v.e.AppURL + "/verify-email?token=" + url.QueryEscape(token)
+
+	err = v.m.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your e-mail",
+		Body: fmt.Sprintf(
+			"Verify your e-mail by opening the link below, valid for %s:\n\n"+
+				"%s\n\nVerification token: %s\n",
+			v.e.EmailVerificationTokenTTL,
+			link,
+			token,
+		),
+	})
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+type SendEmailVerificationUseCase struct {
+	v  validator.Validator
+	ev *EmailVerifier
+	r  repo.UserRepository
+}
+
+func NewSendEmailVerificationUseCase(
+	v validator.Validator,
+	ev *EmailVerifier,
+	r repo.UserRepository,
+) *SendEmailVerificationUseCase {
+	return &SendEmailVerificationUseCase{
+		v:  v,
+		ev: ev,
+		r:  r,
+	}
+}
+
+type SendEmailVerificationUseCaseInput struct {
+	UserID string `json:"user_id" validate:"required"`
+}
+
+// Execute sends the user another verification link, unless they are
+// already verified.
+func (s *SendEmailVerificationUseCase) Execute(
+	ctx context.Context,
+	in SendEmailVerificationUseCaseInput,
+) error {
+	if err := s.v.Validate(in); err != nil {
+		return errs.New(err)
+	}
+
+	user, err := s.r.GetUserByID(ctx, in.UserID)
+	if err != nil {
+		return errs.New(err)
+	}
+	if !user.EmailVerifiedAt.IsZero() {
+		return errs.ErrEmailAlreadyVerified
+	}
+
+	if err := s.ev.Send(ctx, *user); err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+type VerifyEmailUseCase struct {
+	v validator.Validator
+	c cache.Cache
+	r repo.UserRepository
+	a *auditlog.Auditor
+}
+
+func NewVerifyEmailUseCase(
+	v validator.Validator,
+	c cache.Cache,
+	r repo.UserRepository,
+	a *auditlog.Auditor,
+) *VerifyEmailUseCase {
+	return &VerifyEmailUseCase{
+		v: v,
+		c: c,
+		r: r,
+		a: a,
+	}
+}
+
+type VerifyEmailUseCaseInput struct {
+	Token string `json:"token" validate:"required"`
+}
+
+func (v *VerifyEmailUseCase) Execute(
+	ctx context.Context,
+	in VerifyEmailUseCaseInput,
+) error {
+	if err := v.v.Validate(in); err != nil {
+		return errs.New(err)
+	}
+
+	userID, ok, err := redeemOneTimeToken(
+		ctx,
+		v.c,
+		oneTimeTokenEmailVerification,
+		in.Token,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+	if !ok {
+		v.a.Record(ctx, entity.AuditEvent{
+			Action: entity.AuditActionEmailVerify,
+		}, errs.ErrInvalidEmailVerificationToken)
+		return errs.ErrInvalidEmailVerificationToken
+	}
+
+	err = v.r.VerifyUserEmail(ctx, userID, time.Now())
+	v.a.Record(ctx, entity.AuditEvent{
+		Actor:  auditlog.UserActor(userID),
+		Action: entity.AuditActionEmailVerify,
+		Target: auditlog.UserActor(userID),
+	}, err)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/inmemorycache"
+	"github.com/danielmesquitta/flight-api/internal/provider/mailer/filemailer"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/inmemoryrepo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailVerification(t *testing.T) {
+	ctx := context.Background()
+	v := validator.New()
+	e, mailPath := newMailerEnv(t)
+
+	c := inmemorycache.NewInMemoryCache(e)
+	r := inmemoryrepo.NewInMemoryRepository()
+	ev := NewEmailVerifier(e, c, filemailer.NewFileMailer(e))
+
+	send := NewSendEmailVerificationUseCase(v, ev, r)
+	verify := NewVerifyEmailUseCase(v, c, r, newAuditor(r))
+
+	user := entity.User{ID: "1", Email: "johndoe@email.com"}
+	assert.Nil(t, r.CreateUser(ctx, user))
+
+	in := SendEmailVerificationUseCaseInput{UserID: user.ID}
+	assert.Nil(t, send.Execute(ctx, in))
+
+	token := lastMailToken(t, mailPath)
+
+	err := verify.Execute(ctx, VerifyEmailUseCaseInput{Token: "invalid"})
+	assert.ErrorIs(t, err, errs.ErrInvalidEmailVerificationToken)
+
+	assert.Nil(t, verify.Execute(ctx, VerifyEmailUseCaseInput{Token: token}))
+
+	got, err := r.GetUserByID(ctx, user.ID)
+	assert.Nil(t, err)
+	assert.False(t, got.EmailVerifiedAt.IsZero())
+
+	err = verify.Execute(ctx, VerifyEmailUseCaseInput{Token: token})
+	assert.ErrorIs(t, err, errs.ErrInvalidEmailVerificationToken)
+
+	err = send.Execute(ctx, in)
+	assert.ErrorIs(t, err, errs.ErrEmailAlreadyVerified)
+}
@@ -2,35 +2,57 @@ package auth
 
 import (
 	"context"
-	"time"
+	"errors"
 
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
 	"github.com/danielmesquitta/flight-api/internal/domain/errs"
-	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auditlog"
+	"github.com/danielmesquitta/flight-api/internal/pkg/hasher"
 	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
 )
 
+// missingUserPasswordHash is compared against when the user doesn't
+// exist, so that login takes as long as with a wrong password, and
+// doesn't tell which e-mails are registered.
+const missingUserPasswordHash = "$2a$10$sMI.MEZEgA7EsRrldt0QlOajmQQNJL1tZAXd7aXG3nNbF5O5O4p/a"
+
 type LoginUseCase struct {
 	v validator.Validator
-	j *jwtutil.JWT
+	s *Sessions
+	t *LoginThrottle
+	h hasher.Hasher
+	r repo.UserRepository
+	a *auditlog.Auditor
 }
 
 func NewLoginUseCase(
 	v validator.Validator,
-	j *jwtutil.JWT,
+	s *Sessions,
+	t *LoginThrottle,
+	h hasher.Hasher,
+	r repo.UserRepository,
+	a *auditlog.Auditor,
 ) *LoginUseCase {
 	return &LoginUseCase{
 		v: v,
-		j: j,
+		s: s,
+		t: t,
+		h: h,
+		r: r,
+		a: a,
 	}
 }
 
 type LoginUseCaseInput struct {
 	Email    string `json:"email"    validate:"required,email"`
 	Password string `json:"password" validate:"required"`
+	// IP is the address the login comes from, counted against on failure.
+	IP string `json:"-"`
 }
 
 type LoginUseCaseOutput struct {
-	AccessToken string `json:"access_token"`
+	Tokens
 }
 
 func (l *LoginUseCase) Execute(
@@ -41,17 +63,62 @@ func (l *LoginUseCase) Execute(
 		return nil, errs.New(err)
 	}
 
-	in7Days := time.Now().Add(time.Hour * 24 * 7)
-	tokenClaims := jwtutil.UserClaims{
-		Issuer:    in.Email,
-		IssuedAt:  time.Now(),
-		ExpiresAt: in7Days,
+	email := normalizeEmail(in.Email)
+	attempt := entity.AuditEvent{
+		Actor:  auditlog.EmailActor(email),
+		Action: entity.AuditActionLogin,
+		Target: auditlog.EmailActor(email),
+	}
+
+	if err := l.t.Check(ctx, email, in.IP); err != nil {
+		l.a.Record(ctx, attempt, err)
+		return nil, errs.New(err)
+	}
+
+	// Failures are counted for unknown e-mails too, so that lockouts
+	// don't tell which e-mails are registered.
+	fail := func() error {
+		l.t.Fail(ctx, email, in.IP)
+		l.a.Record(ctx, attempt, errs.ErrInvalidCredentials)
+		return errs.ErrInvalidCredentials
+	}
+
+	user, err := l.r.GetUserByEmail(ctx, email)
+	if errors.Is(err, errs.ErrUserNotFound) {
+		_, _ = l.h.Compare(missingUserPasswordHash, in.Password)
+		return nil, fail()
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	// Users created by signing in with an identity provider have no
+	// password, until they reset it.
+	if user.PasswordHash == "" {
+		_, _ = l.h.Compare(missingUserPasswordHash, in.Password)
+		return nil, fail()
+	}
+
+	ok, err := l.h.Compare(user.PasswordHash, in.Password)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	if !ok {
+		return nil, fail()
 	}
 
-	accessToken, err := l.j.NewToken(tokenClaims, jwtutil.TokenTypeAccess)
+	l.t.Succeed(ctx, email)
+
+	tokens, err := l.s.Issue(ctx, *user, "")
 	if err != nil {
 		return nil, errs.New(err)
 	}
 
-	return &LoginUseCaseOutput{AccessToken: accessToken}, nil
+	l.a.Record(ctx, entity.AuditEvent{
+		Actor:  auditlog.UserActor(user.ID),
+		Action: entity.AuditActionLogin,
+		Target: auditlog.UserActor(user.ID),
+	}, nil)
+
+	return &LoginUseCaseOutput{Tokens: *tokens}, nil
 }
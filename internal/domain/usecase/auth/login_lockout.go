@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+)
+
+type ListLoginLockoutsUseCase struct {
+	t *LoginThrottle
+}
+
+func NewListLoginLockoutsUseCase(
+	t *LoginThrottle,
+) *ListLoginLockoutsUseCase {
+	return &ListLoginLockoutsUseCase{
+		t: t,
+	}
+}
+
+type ListLoginLockoutsUseCaseOutput struct {
+	Data []LoginLockout `json:"data"`
+}
+
+func (l *ListLoginLockoutsUseCase) Execute(
+	ctx context.Context,
+) (*ListLoginLockoutsUseCaseOutput, error) {
+	lockouts, err := l.t.Lockouts(ctx)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &ListLoginLockoutsUseCaseOutput{Data: lockouts}, nil
+}
+
+type UnlockLoginUseCase struct {
+	v validator.Validator
+	t *LoginThrottle
+}
+
+func NewUnlockLoginUseCase(
+	v validator.Validator,
+	t *LoginThrottle,
+) *UnlockLoginUseCase {
+	return &UnlockLoginUseCase{
+		v: v,
+		t: t,
+	}
+}
+
+type UnlockLoginUseCaseInput struct {
+	Kind    LoginLockoutKind `json:"kind"    validate:"required,oneof=account ip"`
+	Subject string           `json:"subject" validate:"required"`
+}
+
+// Execute lets an account or IP address login again right away.
+func (u *UnlockLoginUseCase) Execute(
+	ctx context.Context,
+	in UnlockLoginUseCaseInput,
+) error {
+	if err := u.v.Validate(in); err != nil {
+		return errs.New(err)
+	}
+
+	if in.Kind == LoginLockoutKindAccount {
+		in.Subject = normalizeEmail(in.Subject)
+	}
+
+	if err := u.t.Unlock(ctx, in.Kind, in.Subject); err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
@@ -3,60 +3,134 @@ package auth
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/danielmesquitta/flight-api/internal/config"
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auditlog"
+	"github.com/danielmesquitta/flight-api/internal/pkg/hasher"
 	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
 	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/audit/dbaudit"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/inmemorycache"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/inmemoryrepo"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/mockrepo"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestLoginUseCase_Execute(t *testing.T) {
-	tests := []struct {
+	h := hasher.New()
+	passwordHash, err := h.Hash("P@ssw0rd")
+	assert.Nil(t, err)
+
+	user := &entity.User{
+		ID:           "1",
+		Email:        "johndoe@email.com",
+		PasswordHash: passwordHash,
+	}
+
+	type Test struct {
 		name    string
+		r       *mockrepo.MockUserRepository
 		args    LoginUseCaseInput
-		wantErr bool
-	}{
-		{
-			name: "signs in",
-			args: LoginUseCaseInput{
-				Email:    "johndoe@email.com",
-				Password: "P@ssw0rd",
-			},
-			wantErr: false,
-		},
+		wantErr error
+	}
+	tests := []Test{
+		func() Test {
+			r := mockrepo.NewMockUserRepository(t)
+			r.EXPECT().
+				GetUserByEmail(mock.Anything, "johndoe@email.com").
+				Return(user, nil)
+
+			return Test{
+				name: "signs in",
+				r:    r,
+				args: LoginUseCaseInput{
+					Email:    "JohnDoe@email.com",
+					Password: "P@ssw0rd",
+				},
+			}
+		}(),
+		func() Test {
+			r := mockrepo.NewMockUserRepository(t)
+			r.EXPECT().
+				GetUserByEmail(mock.Anything, "johndoe@email.com").
+				Return(user, nil)
+
+			return Test{
+				name: "fails with wrong password",
+				r:    r,
+				args: LoginUseCaseInput{
+					Email:    "johndoe@email.com",
+					Password: "wrong",
+				},
+				wantErr: errs.ErrInvalidCredentials,
+			}
+		}(),
+		func() Test {
+			r := mockrepo.NewMockUserRepository(t)
+			r.EXPECT().
+				GetUserByEmail(mock.Anything, "janedoe@email.com").
+				Return(nil, errs.ErrUserNotFound)
+
+			return Test{
+				name: "fails with unknown email",
+				r:    r,
+				args: LoginUseCaseInput{
+					Email:    "janedoe@email.com",
+					Password: "P@ssw0rd",
+				},
+				wantErr: errs.ErrInvalidCredentials,
+			}
+		}(),
+		func() Test {
+			r := mockrepo.NewMockUserRepository(t)
+			r.EXPECT().
+				GetUserByEmail(mock.Anything, "johndoe@email.com").
+				Return(&entity.User{ID: "1", Email: "johndoe@email.com"}, nil)
+
+			return Test{
+				name: "fails for users without password",
+				r:    r,
+				args: LoginUseCaseInput{
+					Email:    "johndoe@email.com",
+					Password: "P@ssw0rd",
+				},
+				wantErr: errs.ErrInvalidCredentials,
+			}
+		}(),
 		{
 			name: "fails with invalid email",
+			r:    mockrepo.NewMockUserRepository(t),
 			args: LoginUseCaseInput{
 				Email:    "invalidemail.com",
 				Password: "P@ssw0rd",
 			},
-			wantErr: true,
-		},
-		{
-			name: "fails without email",
-			args: LoginUseCaseInput{
-				Email:    "",
-				Password: "P@ssw0rd",
-			},
-			wantErr: true,
+			wantErr: errs.New("", errs.ErrCodeValidation),
 		},
 		{
 			name: "fails without password",
+			r:    mockrepo.NewMockUserRepository(t),
 			args: LoginUseCaseInput{
-				Email:    "",
-				Password: "P@ssw0rd",
+				Email:    "johndoe@email.com",
+				Password: "",
 			},
-			wantErr: true,
+			wantErr: errs.New("", errs.ErrCodeValidation),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			l := newLoginUseCase()
+			l := newLoginUseCase(h, tt.r)
 
 			got, err := l.Execute(context.Background(), tt.args)
 
-			if tt.wantErr {
+			if tt.wantErr != nil {
 				assert.NotNil(t, err)
+				assert.Equal(t, codeOf(tt.wantErr), codeOf(err))
 				assert.Nil(t, got)
 				return
 			}
@@ -64,15 +138,173 @@ func TestLoginUseCase_Execute(t *testing.T) {
 			assert.Nil(t, err)
 			assert.NotNil(t, got)
 			assert.NotEmpty(t, got.AccessToken)
+			assert.NotEmpty(t, got.RefreshToken)
+		})
+	}
+}
+
+func TestLoginUseCase_Execute_Audit(t *testing.T) {
+	h := hasher.New()
+	passwordHash, err := h.Hash("P@ssw0rd")
+	assert.Nil(t, err)
+
+	r := mockrepo.NewMockUserRepository(t)
+	r.EXPECT().
+		GetUserByEmail(mock.Anything, "johndoe@email.com").
+		Return(&entity.User{
+			ID:           "1",
+			Email:        "johndoe@email.com",
+			PasswordHash: passwordHash,
+		}, nil)
+
+	audits := inmemoryrepo.NewInMemoryRepository()
+	l := newLoginUseCase(h, r)
+	l.t.e.LoginDelay = time.Nanosecond
+	l.a = newAuditor(audits)
+
+	ctx := auditlog.WithRequest(context.Background(), auditlog.Request{
+		IP:        "1.1.1.1",
+		RequestID: "request-1",
+	})
+	for _, password := range []string{"wrong", "P@ssw0rd"} {
+		_, _ = l.Execute(ctx, LoginUseCaseInput{
+			Email:    "johndoe@email.com",
+			Password: password,
 		})
 	}
+
+	events, err := audits.ListAuditEvents(ctx, entity.AuditEventFilter{})
+	assert.Nil(t, err)
+	if assert.Len(t, events, 2) {
+		success, failure := events[0], events[1]
+		assert.Equal(t, "email:johndoe@email.com", failure.Actor)
+		assert.Equal(t, entity.AuditOutcomeFailure, failure.Outcome)
+		assert.Equal(t, errs.ErrInvalidCredentials.Message, failure.Reason)
+		assert.Equal(t, "1.1.1.1", failure.IP)
+		assert.Equal(t, "request-1", failure.RequestID)
+		assert.Equal(t, "user:1", success.Actor)
+		assert.Equal(t, entity.AuditActionLogin, success.Action)
+		assert.Equal(t, entity.AuditOutcomeSuccess, success.Outcome)
+	}
+}
+
+func TestLoginUseCase_Execute_Throttle(t *testing.T) {
+	h := hasher.New()
+	passwordHash, err := h.Hash("P@ssw0rd")
+	assert.Nil(t, err)
+
+	r := mockrepo.NewMockUserRepository(t)
+	r.EXPECT().
+		GetUserByEmail(mock.Anything, "johndoe@email.com").
+		Return(&entity.User{
+			ID:           "1",
+			Email:        "johndoe@email.com",
+			PasswordHash: passwordHash,
+		}, nil)
+	r.EXPECT().
+		GetUserByEmail(mock.Anything, mock.Anything).
+		Return(nil, errs.ErrUserNotFound)
+
+	login := func(
+		l *LoginUseCase,
+		email, password, ip string,
+	) error {
+		_, err := l.Execute(context.Background(), LoginUseCaseInput{
+			Email:    email,
+			Password: password,
+			IP:       ip,
+		})
+		return err
+	}
+
+	t.Run("delays the next login after a failure", func(t *testing.T) {
+		l := newLoginUseCase(h, r)
+		l.t.e.LoginDelay = time.Minute
+
+		err := login(l, "johndoe@email.com", "wrong", "1.1.1.1")
+		assert.Equal(t, errs.ErrCodeUnauthorized, codeOf(err))
+
+		err = login(l, "johndoe@email.com", "P@ssw0rd", "1.1.1.1")
+		assert.Equal(t, errs.ErrCodeTooManyRequests, codeOf(err))
+		assert.Positive(t, errs.New(err).RetryAfter)
+	})
+
+	t.Run("locks the account out until unlocked", func(t *testing.T) {
+		l := newLoginUseCase(h, r)
+		l.t.e.LoginDelay = time.Nanosecond
+		l.t.e.LoginMaxFailures = 3
+
+		for range 3 {
+			err := login(l, "johndoe@email.com", "wrong", "1.1.1.1")
+			assert.Equal(t, errs.ErrCodeUnauthorized, codeOf(err))
+		}
+
+		err := login(l, "johndoe@email.com", "P@ssw0rd", "2.2.2.2")
+		assert.Equal(t, errs.ErrCodeTooManyRequests, codeOf(err))
+
+		lockouts, err := NewListLoginLockoutsUseCase(l.t).
+			Execute(context.Background())
+		assert.Nil(t, err)
+		assert.Len(t, lockouts.Data, 1)
+		assert.Equal(t, LoginLockoutKindAccount, lockouts.Data[0].Kind)
+		assert.Equal(t, "johndoe@email.com", lockouts.Data[0].Subject)
+		assert.Equal(t, int64(3), lockouts.Data[0].Failures)
+
+		err = NewUnlockLoginUseCase(validator.New(), l.t).Execute(
+			context.Background(),
+			UnlockLoginUseCaseInput{
+				Kind:    LoginLockoutKindAccount,
+				Subject: "JohnDoe@email.com",
+			},
+		)
+		assert.Nil(t, err)
+
+		err = login(l, "johndoe@email.com", "P@ssw0rd", "2.2.2.2")
+		assert.Nil(t, err)
+	})
+
+	t.Run("locks the IP address out", func(t *testing.T) {
+		l := newLoginUseCase(h, r)
+		l.t.e.LoginMaxFailuresPerIP = 2
+
+		for _, email := range []string{"a@email.com", "b@email.com"} {
+			err := login(l, email, "wrong", "1.1.1.1")
+			assert.Equal(t, errs.ErrCodeUnauthorized, codeOf(err))
+		}
+
+		err := login(l, "johndoe@email.com", "P@ssw0rd", "1.1.1.1")
+		assert.Equal(t, errs.ErrCodeTooManyRequests, codeOf(err))
+
+		err = login(l, "johndoe@email.com", "P@ssw0rd", "2.2.2.2")
+		assert.Nil(t, err)
+	})
 }
 
-func newLoginUseCase() *LoginUseCase {
+func newLoginUseCase(
+	h hasher.Hasher,
+	r *mockrepo.MockUserRepository,
+) *LoginUseCase {
 	v := validator.New()
 	e := config.LoadConfig(v)
 	return &LoginUseCase{
 		v: v,
-		j: jwtutil.NewJWT(e),
+		s: newSessions(e),
+		t: NewLoginThrottle(e, inmemorycache.NewInMemoryCache(e)),
+		h: h,
+		r: r,
+		a: newAuditor(inmemoryrepo.NewInMemoryRepository()),
 	}
 }
+
+func newAuditor(r repo.AuditRepository) *auditlog.Auditor {
+	return auditlog.NewAuditor(dbaudit.NewDBSink(r))
+}
+
+func newSessions(e *env.Env) *Sessions {
+	c := inmemorycache.NewInMemoryCache(e)
+	return NewSessions(e, jwtutil.NewJWT(e), jwtutil.NewDenylist(c), c)
+}
+
+func codeOf(err error) errs.Code {
+	return errs.New(err).Code
+}
@@ -0,0 +1,296 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+)
+
+type LoginLockoutKind = string
+
+const (
+	LoginLockoutKindAccount LoginLockoutKind = "account"
+	LoginLockoutKindIP      LoginLockoutKind = "ip"
+)
+
+// LoginLockout is an account, by e-mail, or an IP address that can't
+// login until it expires, after failing too many times.
+type LoginLockout struct {
+	Kind     LoginLockoutKind `json:"kind"`
+	Subject  string           `json:"subject"`
+	Failures int64            `json:"failures"`
+	Until    time.Time        `json:"until"`
+}
+
+type loginDelay struct {
+	Until time.Time `json:"until"`
+}
+
+func loginFailuresKey(kind LoginLockoutKind, subject string) string {
+	return "auth:login:failures:" + kind + ":" + subject
+}
+
+func loginDelayKey(email string) string {
+	return "auth:login:delay:" + email
+}
+
+func loginLockoutKey(kind LoginLockoutKind, subject string) string {
+	return "auth:login:lockout:" + kind + ":" + subject
+}
+
+const loginLockoutsPattern = "auth:login:lockout:*"
+
+// LoginThrottle counts failed logins per account and per IP address in
+// the cache. Each failure of an account delays its next attempt, twice
+// as long as the previous one, and too many failures within the window
+// lock the account or IP address out. Logins are allowed if the cache
+// fails, as with rate limits.
+type LoginThrottle struct {
+	e *env.Env
+	c cache.Cache
+}
+
+func NewLoginThrottle(
+	e *env.Env,
+	c cache.Cache,
+) *LoginThrottle {
+	return &LoginThrottle{
+		e: e,
+		c: c,
+	}
+}
+
+// Check returns an error if the account or IP address can't login yet,
+// with how long until it can.
+func (t *LoginThrottle) Check(
+	ctx context.Context,
+	email string,
+	ip string,
+) error {
+	until, err := t.blockedUntil(ctx, email, ip)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"failed to check login throttle",
+			"error", err,
+		)
+		return nil
+	}
+
+	wait := time.Until(until)
+	if wait <= 0 {
+		return nil
+	}
+
+	blocked := errs.New(
+		fmt.Sprintf(
+			"Too many failed login attempts, try again in %d seconds",
+			int64(math.Ceil(wait.Seconds())),
+		),
+		errs.ErrCodeTooManyRequests,
+	)
+	blocked.RetryAfter = wait
+
+	return blocked
+}
+
+func (t *LoginThrottle) blockedUntil(
+	ctx context.Context,
+	email string,
+	ip string,
+) (time.Time, error) {
+	var until time.Time
+
+	delay := loginDelay{}
+	if _, err := t.c.Scan(ctx, loginDelayKey(email), &delay); err != nil {
+		return until, err
+	}
+	until = delay.Until
+
+	for _, key := range []string{
+		loginLockoutKey(LoginLockoutKindAccount, email),
+		loginLockoutKey(LoginLockoutKindIP, ip),
+	} {
+		lockout := LoginLockout{}
+		if _, err := t.c.Scan(ctx, key, &lockout); err != nil {
+			return until, err
+		}
+		if lockout.Until.After(until) {
+			until = lockout.Until
+		}
+	}
+
+	return until, nil
+}
+
+// Fail counts a failed login of the account from the IP address.
+func (t *LoginThrottle) Fail(
+	ctx context.Context,
+	email string,
+	ip string,
+) {
+	if err := t.fail(ctx, email, ip); err != nil {
+		slog.ErrorContext(
+			ctx,
+			"failed to count failed login",
+			"error", err,
+		)
+	}
+}
+
+func (t *LoginThrottle) fail(
+	ctx context.Context,
+	email string,
+	ip string,
+) error {
+	now := time.Now()
+
+	failures, err := t.c.Increment(
+		ctx,
+		loginFailuresKey(LoginLockoutKindAccount, email),
+		1,
+		t.e.LoginFailureWindow,
+	)
+	if err != nil {
+		return err
+	}
+
+	if failures >= t.e.LoginMaxFailures {
+		err := t.lock(ctx, LoginLockoutKindAccount, email, failures, now)
+		if err != nil {
+			return err
+		}
+	} else {
+		// The delay doubles with each failure, and the exponent is capped
+		// so that it doesn't overflow before reaching the max delay.
+		delay := t.e.LoginDelay << min(failures-1, 32)
+		if delay <= 0 || delay > t.e.LoginMaxDelay {
+			delay = t.e.LoginMaxDelay
+		}
+
+		err := t.c.Set(
+			ctx,
+			loginDelayKey(email),
+			loginDelay{Until: now.Add(delay)},
+			delay,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if ip == "" {
+		return nil
+	}
+
+	failures, err = t.c.Increment(
+		ctx,
+		loginFailuresKey(LoginLockoutKindIP, ip),
+		1,
+		t.e.LoginFailureWindow,
+	)
+	if err != nil {
+		return err
+	}
+
+	if failures >= t.e.LoginMaxFailuresPerIP {
+		return t.lock(ctx, LoginLockoutKindIP, ip, failures, now)
+	}
+
+	return nil
+}
+
+func (t *LoginThrottle) lock(
+	ctx context.Context,
+	kind LoginLockoutKind,
+	subject string,
+	failures int64,
+	now time.Time,
+) error {
+	return t.c.Set(
+		ctx,
+		loginLockoutKey(kind, subject),
+		LoginLockout{
+			Kind:     kind,
+			Subject:  subject,
+			Failures: failures,
+			Until:    now.Add(t.e.LoginLockoutDuration),
+		},
+		t.e.LoginLockoutDuration,
+	)
+}
+
+// Succeed forgets the failed logins of the account. Failures of the IP
+// address are kept, so that an attacker can't reset them by logging in
+// to an account of their own.
+func (t *LoginThrottle) Succeed(
+	ctx context.Context,
+	email string,
+) {
+	err := t.c.Delete(
+		ctx,
+		loginFailuresKey(LoginLockoutKindAccount, email),
+		loginDelayKey(email),
+	)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"failed to reset failed logins",
+			"error", err,
+		)
+	}
+}
+
+// Unlock forgets the failed logins and lockout of an account or IP
+// address.
+func (t *LoginThrottle) Unlock(
+	ctx context.Context,
+	kind LoginLockoutKind,
+	subject string,
+) error {
+	keys := []string{
+		loginFailuresKey(kind, subject),
+		loginLockoutKey(kind, subject),
+	}
+	if kind == LoginLockoutKindAccount {
+		keys = append(keys, loginDelayKey(subject))
+	}
+
+	if err := t.c.Delete(ctx, keys...); err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+// Lockouts returns the accounts and IP addresses currently locked out.
+func (t *LoginThrottle) Lockouts(
+	ctx context.Context,
+) ([]LoginLockout, error) {
+	lockouts := []LoginLockout{}
+
+	for info, err := range t.c.Keys(ctx, loginLockoutsPattern) {
+		if err != nil {
+			return nil, errs.New(err)
+		}
+
+		lockout := LoginLockout{}
+		ok, err := t.c.Scan(ctx, info.Key, &lockout)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+		if !ok {
+			continue
+		}
+
+		lockouts = append(lockouts, lockout)
+	}
+
+	return lockouts, nil
+}
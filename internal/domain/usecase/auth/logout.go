@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auditlog"
+	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+)
+
+type LogoutUseCase struct {
+	v validator.Validator
+	s *Sessions
+	a *auditlog.Auditor
+}
+
+func NewLogoutUseCase(
+	v validator.Validator,
+	s *Sessions,
+	a *auditlog.Auditor,
+) *LogoutUseCase {
+	return &LogoutUseCase{
+		v: v,
+		s: s,
+		a: a,
+	}
+}
+
+type LogoutUseCaseInput struct {
+	// Claims are the ones of the access token used to log out.
+	Claims *jwtutil.UserClaims `validate:"required"`
+}
+
+// Execute revokes the access token and every refresh token of its login.
+func (l *LogoutUseCase) Execute(
+	ctx context.Context,
+	in LogoutUseCaseInput,
+) error {
+	if err := l.v.Validate(in); err != nil {
+		return errs.New(err)
+	}
+
+	err := l.s.Revoke(ctx, in.Claims)
+	l.a.Record(ctx, entity.AuditEvent{
+		Actor:  auditlog.UserActor(in.Claims.Subject),
+		Action: entity.AuditActionLogout,
+		Target: auditlog.UserActor(in.Claims.Subject),
+	}, err)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/danielmesquitta/flight-api/internal/config"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/inmemoryrepo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogoutUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	user := entity.User{ID: "1", Email: "johndoe@email.com"}
+
+	v := validator.New()
+	e := config.LoadConfig(v)
+	s := newSessions(e)
+	j := jwtutil.NewJWT(e)
+
+	l := NewLogoutUseCase(
+		v,
+		s,
+		newAuditor(inmemoryrepo.NewInMemoryRepository()),
+	)
+
+	tokens, err := s.Issue(ctx, user, "")
+	assert.Nil(t, err)
+	otherTokens, err := s.Issue(ctx, user, "")
+	assert.Nil(t, err)
+
+	claims, err := j.Parse(tokens.AccessToken, jwtutil.TokenTypeAccess)
+	assert.Nil(t, err)
+
+	err = l.Execute(ctx, LogoutUseCaseInput{Claims: claims})
+	assert.Nil(t, err)
+
+	revoked, err := s.d.IsRevoked(ctx, claims)
+	assert.Nil(t, err)
+	assert.True(t, revoked)
+
+	_, err = s.Redeem(ctx, tokens.RefreshToken)
+	assert.Equal(t, errs.ErrInvalidRefreshToken, err)
+
+	otherClaims, err := j.Parse(
+		otherTokens.AccessToken,
+		jwtutil.TokenTypeAccess,
+	)
+	assert.Nil(t, err)
+
+	revoked, err = s.d.IsRevoked(ctx, otherClaims)
+	assert.Nil(t, err)
+	assert.False(t, revoked, "other logins stay valid")
+
+	_, err = s.Redeem(ctx, otherTokens.RefreshToken)
+	assert.Nil(t, err)
+
+	err = l.Execute(ctx, LogoutUseCaseInput{})
+	assert.Equal(t, errs.ErrCodeValidation, codeOf(err))
+}
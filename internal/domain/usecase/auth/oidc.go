@@ -0,0 +1,282 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auditlog"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+	"github.com/danielmesquitta/flight-api/internal/provider/idp"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+// oidcLoginTTL is how long users have to sign in with the provider once
+// the login is started.
+const oidcLoginTTL = 10 * time.Minute
+
+// oidcLogin is kept in the cache, under its state, between the start of
+// the login and the callback of the provider.
+type oidcLogin struct {
+	Provider string `json:"provider"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+func oidcLoginKey(state string) string {
+	return "auth:oidc:" + state
+}
+
+type StartOIDCLoginUseCase struct {
+	v validator.Validator
+	c cache.Cache
+	p idp.IdentityProvider
+}
+
+func NewStartOIDCLoginUseCase(
+	v validator.Validator,
+	c cache.Cache,
+	p idp.IdentityProvider,
+) *StartOIDCLoginUseCase {
+	return &StartOIDCLoginUseCase{
+		v: v,
+		c: c,
+		p: p,
+	}
+}
+
+type StartOIDCLoginUseCaseInput struct {
+	Provider string `json:"provider" validate:"required"`
+}
+
+type StartOIDCLoginUseCaseOutput struct {
+	// URL is where the user signs in with the provider.
+	URL string `json:"url"`
+}
+
+func (s *StartOIDCLoginUseCase) Execute(
+	ctx context.Context,
+	in StartOIDCLoginUseCaseInput,
+) (*StartOIDCLoginUseCaseOutput, error) {
+	if err := s.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	login := oidcLogin{
+		Provider: in.Provider,
+		Nonce:    randomString(),
+		Verifier: oauth2.GenerateVerifier(),
+	}
+	state := randomString()
+
+	url, err := s.p.AuthCodeURL(
+		ctx,
+		login.Provider,
+		state,
+		login.Nonce,
+		login.Verifier,
+	)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	err = s.c.Set(ctx, oidcLoginKey(state), login, oidcLoginTTL)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &StartOIDCLoginUseCaseOutput{URL: url}, nil
+}
+
+// FinishOIDCLoginUseCase signs in the user the provider redirected back,
+// creating their account on the first login. Accounts are linked by
+// e-mail, as long as the provider verified it.
+type FinishOIDCLoginUseCase struct {
+	v validator.Validator
+	c cache.Cache
+	p idp.IdentityProvider
+	s *Sessions
+	r repo.UserRepository
+	i repo.IdentityRepository
+	a *auditlog.Auditor
+}
+
+func NewFinishOIDCLoginUseCase(
+	v validator.Validator,
+	c cache.Cache,
+	p idp.IdentityProvider,
+	s *Sessions,
+	r repo.UserRepository,
+	i repo.IdentityRepository,
+	a *auditlog.Auditor,
+) *FinishOIDCLoginUseCase {
+	return &FinishOIDCLoginUseCase{
+		v: v,
+		c: c,
+		p: p,
+		s: s,
+		r: r,
+		i: i,
+		a: a,
+	}
+}
+
+type FinishOIDCLoginUseCaseInput struct {
+	Provider string `json:"provider" validate:"required"`
+	Code     string `json:"code"     validate:"required"`
+	State    string `json:"state"    validate:"required"`
+}
+
+type FinishOIDCLoginUseCaseOutput struct {
+	Tokens
+}
+
+func (f *FinishOIDCLoginUseCase) Execute(
+	ctx context.Context,
+	in FinishOIDCLoginUseCaseInput,
+) (*FinishOIDCLoginUseCaseOutput, error) {
+	if err := f.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	// The state is single use, so that a leaked callback URL can't be
+	// replayed, and taken from the cache, so that concurrent callbacks
+	// can't both use it.
+	var login oidcLogin
+	ok, err := f.c.Take(ctx, oidcLoginKey(in.State), &login)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	if !ok || login.Provider != in.Provider {
+		f.a.Record(ctx, entity.AuditEvent{
+			Action: entity.AuditActionOIDCLogin,
+		}, errs.ErrInvalidOIDCState)
+		return nil, errs.ErrInvalidOIDCState
+	}
+
+	identity, err := f.p.Exchange(
+		ctx,
+		login.Provider,
+		in.Code,
+		login.Verifier,
+		login.Nonce,
+	)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	user, err := f.provision(ctx, login.Provider, identity)
+	if err != nil {
+		f.a.Record(ctx, entity.AuditEvent{
+			Action: entity.AuditActionOIDCLogin,
+		}, err)
+		return nil, errs.New(err)
+	}
+
+	tokens, err := f.s.Issue(ctx, *user, "")
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	f.a.Record(ctx, entity.AuditEvent{
+		Actor:  auditlog.UserActor(user.ID),
+		Action: entity.AuditActionOIDCLogin,
+		Target: auditlog.UserActor(user.ID),
+	}, nil)
+
+	return &FinishOIDCLoginUseCaseOutput{Tokens: *tokens}, nil
+}
+
+// provision returns the user linked to the identity, linking it to the
+// user with the same e-mail, or to a new user, on the first login. Users
+// who haven't verified their e-mail aren't linked, as anyone could have
+// registered it, and would sign in as them.
+func (f *FinishOIDCLoginUseCase) provision(
+	ctx context.Context,
+	provider string,
+	identity *idp.Identity,
+) (*entity.User, error) {
+	linked, err := f.i.GetIdentity(ctx, provider, identity.Subject)
+	if err == nil {
+		return f.r.GetUserByID(ctx, linked.UserID)
+	}
+	if !errors.Is(err, errs.ErrIdentityNotFound) {
+		return nil, errs.New(err)
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, errs.ErrUnverifiedEmail
+	}
+
+	email := normalizeEmail(identity.Email)
+	user, err := f.r.GetUserByEmail(ctx, email)
+	if errors.Is(err, errs.ErrUserNotFound) {
+		user, err = f.createUser(ctx, email)
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	if user.EmailVerifiedAt.IsZero() {
+		return nil, errs.ErrUnverifiedUserEmail
+	}
+
+	err = f.i.CreateIdentity(ctx, entity.Identity{
+		Provider:  provider,
+		Subject:   identity.Subject,
+		UserID:    user.ID,
+		Email:     email,
+		CreatedAt: time.Now(),
+	})
+	if errors.Is(err, errs.ErrIdentityAlreadyExists) {
+		// Linked by a concurrent login of the same user.
+		return f.provision(ctx, provider, identity)
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return user, nil
+}
+
+// createUser creates a user without a password, who can only sign in with
+// their identity providers, until they reset it. The provider verified
+// their e-mail.
+func (f *FinishOIDCLoginUseCase) createUser(
+	ctx context.Context,
+	email string,
+) (*entity.User, error) {
+	now := time.Now()
+	user := entity.User{
+		ID:              uuid.NewString(),
+		Email:           email,
+		Roles:           []entity.Role{},
+		EmailVerifiedAt: now,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	err := f.r.CreateUser(ctx, user)
+	if errors.Is(err, errs.ErrUserAlreadyExists) {
+		// Created by a concurrent login of the same user.
+		return f.r.GetUserByEmail(ctx, email)
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &user, nil
+}
+
+func randomString() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
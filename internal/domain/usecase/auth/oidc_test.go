@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/inmemorycache"
+	"github.com/danielmesquitta/flight-api/internal/provider/idp"
+	"github.com/danielmesquitta/flight-api/internal/provider/idp/idptest"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/inmemoryrepo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDCLogin(t *testing.T) {
+	ctx := context.Background()
+	v := validator.New()
+	server := idptest.NewServer(t)
+
+	e := *config.LoadConfig(v)
+	e.OIDCProviders = server.Provider("acme")
+	e.OIDCRedirectURL = "http://localhost/callback/{provider}"
+
+	c := inmemorycache.NewInMemoryCache(&e)
+	j := jwtutil.NewJWT(&e)
+	s := NewSessions(&e, j, jwtutil.NewDenylist(c), c)
+	r := inmemoryrepo.NewInMemoryRepository()
+	p := idp.NewOIDC(&e)
+
+	start := NewStartOIDCLoginUseCase(v, c, p)
+	finish := NewFinishOIDCLoginUseCase(v, c, p, s, r, r, newAuditor(r))
+
+	login := func(provider string) (*FinishOIDCLoginUseCaseOutput, error) {
+		started, err := start.Execute(ctx, StartOIDCLoginUseCaseInput{
+			Provider: "acme",
+		})
+		if !assert.Nil(t, err) {
+			return nil, err
+		}
+		assert.Contains(t, started.URL, "code_challenge=")
+
+		code, state := server.Authorize(t, started.URL)
+
+		return finish.Execute(ctx, FinishOIDCLoginUseCaseInput{
+			Provider: provider,
+			Code:     code,
+			State:    state,
+		})
+	}
+
+	subject := func(accessToken string) string {
+		claims, err := j.Parse(accessToken, jwtutil.TokenTypeAccess)
+		assert.Nil(t, err)
+		return claims.Subject
+	}
+
+	existing := entity.User{
+		ID:              "1",
+		Email:           "janedoe@email.com",
+		PasswordHash:    "hash",
+		EmailVerifiedAt: time.Now(),
+	}
+	assert.Nil(t, r.CreateUser(ctx, existing))
+
+	unverified := entity.User{
+		ID:           "2",
+		Email:        "jimdoe@email.com",
+		PasswordHash: "hash",
+	}
+	assert.Nil(t, r.CreateUser(ctx, unverified))
+
+	t.Run("provisions a user on the first login", func(t *testing.T) {
+		first, err := login("acme")
+		assert.Nil(t, err)
+
+		user, err := r.GetUserByEmail(ctx, "johndoe@email.com")
+		assert.Nil(t, err)
+		assert.Equal(t, user.ID, subject(first.AccessToken))
+		assert.Empty(t, user.PasswordHash)
+
+		second, err := login("acme")
+		assert.Nil(t, err)
+		assert.Equal(t, user.ID, subject(second.AccessToken))
+	})
+
+	t.Run("links a user with the same email", func(t *testing.T) {
+		server.SetUser(idp.Identity{
+			Subject:       "jane",
+			Email:         "JaneDoe@email.com",
+			EmailVerified: true,
+		})
+
+		got, err := login("acme")
+		assert.Nil(t, err)
+		assert.Equal(t, existing.ID, subject(got.AccessToken))
+	})
+
+	t.Run("fails to link a user with an unverified email", func(t *testing.T) {
+		server.SetUser(idp.Identity{
+			Subject:       "jim",
+			Email:         "jimdoe@email.com",
+			EmailVerified: true,
+		})
+
+		_, err := login("acme")
+		assert.ErrorIs(t, err, errs.ErrUnverifiedUserEmail)
+
+		_, err = r.GetIdentity(ctx, "acme", "jim")
+		assert.ErrorIs(t, err, errs.ErrIdentityNotFound)
+	})
+
+	t.Run("fails with an unverified email", func(t *testing.T) {
+		server.SetUser(idp.Identity{
+			Subject: "unverified",
+			Email:   "unverified@email.com",
+		})
+
+		_, err := login("acme")
+		assert.ErrorIs(t, err, errs.ErrUnverifiedEmail)
+	})
+
+	t.Run("fails with another provider", func(t *testing.T) {
+		_, err := login("other")
+		assert.ErrorIs(t, err, errs.ErrInvalidOIDCState)
+	})
+
+	t.Run("fails with an unknown provider", func(t *testing.T) {
+		_, err := start.Execute(ctx, StartOIDCLoginUseCaseInput{
+			Provider: "other",
+		})
+		assert.ErrorIs(t, err, errs.ErrOIDCProviderNotFound)
+	})
+
+	t.Run("fails with a reused state", func(t *testing.T) {
+		server.SetUser(idp.Identity{
+			Subject:       "jane",
+			Email:         "janedoe@email.com",
+			EmailVerified: true,
+		})
+
+		started, err := start.Execute(ctx, StartOIDCLoginUseCaseInput{
+			Provider: "acme",
+		})
+		assert.Nil(t, err)
+		code, state := server.Authorize(t, started.URL)
+
+		in := FinishOIDCLoginUseCaseInput{
+			Provider: "acme",
+			Code:     code,
+			State:    state,
+		}
+		_, err = finish.Execute(ctx, in)
+		assert.Nil(t, err)
+
+		_, err = finish.Execute(ctx, in)
+		assert.ErrorIs(t, err, errs.ErrInvalidOIDCState)
+	})
+}
@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+)
+
+// oneTimeTokenPurpose keeps tokens issued for one purpose from being
+// redeemed for another.
+type oneTimeTokenPurpose string
+
+const (
+	oneTimeTokenPasswordReset     oneTimeTokenPurpose = "password_reset"
+	oneTimeTokenEmailVerification oneTimeTokenPurpose = "email_verification"
+)
+
+// oneTimeToken is kept in the cache under the hash of the token sent by
+// e-mail, so that a leaked cache doesn't leak usable tokens.
+type oneTimeToken struct {
+	UserID string `json:"user_id"`
+}
+
+func oneTimeTokenKey(purpose oneTimeTokenPurpose, token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "auth:" + string(purpose) + ":" + hex.EncodeToString(sum[:])
+}
+
+// issueOneTimeToken returns a token for the user that can be redeemed
+// once, until it expires after ttl.
+func issueOneTimeToken(
+	ctx context.Context,
+	c cache.Cache,
+	purpose oneTimeTokenPurpose,
+	userID string,
+	ttl time.Duration,
+) (string, error) {
+	token := randomString()
+
+	err := c.Set(
+		ctx,
+		oneTimeTokenKey(purpose, token),
+		oneTimeToken{UserID: userID},
+		ttl,
+	)
+	if err != nil {
+		return "", errs.New(err)
+	}
+
+	return token, nil
+}
+
+// redeemOneTimeToken returns the id of the user the token was issued to,
+// and deletes it, or reports that it was already used or expired. The
+// token is taken from the cache, so that only one of many concurrent
+// redemptions succeeds.
+func redeemOneTimeToken(
+	ctx context.Context,
+	c cache.Cache,
+	purpose oneTimeTokenPurpose,
+	token string,
+) (userID string, ok bool, err error) {
+	var value oneTimeToken
+	ok, err = c.Take(ctx, oneTimeTokenKey(purpose, token), &value)
+	if err != nil {
+		return "", false, errs.New(err)
+	}
+	if !ok {
+		return "", false, nil
+	}
+
+	return value.UserID, true, nil
+}
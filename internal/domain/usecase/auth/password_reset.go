@@ -0,0 +1,219 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auditlog"
+	"github.com/danielmesquitta/flight-api/internal/pkg/hasher"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+	"github.com/danielmesquitta/flight-api/internal/provider/mailer"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+// forgotPasswordTimeout bounds the lookup of the user and the sending of
+// the reset e-mail, which outlive the request.
+const forgotPasswordTimeout = time.Minute
+
+type ForgotPasswordUseCase struct {
+	v validator.Validator
+	e *env.Env
+	c cache.Cache
+	m mailer.Mailer
+	r repo.UserRepository
+	a *auditlog.Auditor
+}
+
+func NewForgotPasswordUseCase(
+	v validator.Validator,
+	e *env.Env,
+	c cache.Cache,
+	m mailer.Mailer,
+	r repo.UserRepository,
+	a *auditlog.Auditor,
+) *ForgotPasswordUseCase {
+	return &ForgotPasswordUseCase{
+		v: v,
+		e: e,
+		c: c,
+		m: m,
+		r: r,
+		a: a,
+	}
+}
+
+type ForgotPasswordUseCaseInput struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// Execute e-mails the user a link to reset their password. The e-mail is
+// sent in the background, and failures are only logged, so that neither
+// the response nor how long it takes tell which e-mails are registered.
+func (f *ForgotPasswordUseCase) Execute(
+	ctx context.Context,
+	in ForgotPasswordUseCaseInput,
+) error {
+	if err := f.v.Validate(in); err != nil {
+		return errs.New(err)
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(
+			context.WithoutCancel(ctx),
+			forgotPasswordTimeout,
+		)
+		defer cancel()
+
+		if err := f.send(ctx, normalizeEmail(in.Email)); err != nil {
+			slog.ErrorContext(
+				ctx,
+				"failed to send password reset e-mail",
+				"error", err,
+			)
+		}
+	}()
+
+	return nil
+}
+
+// send e-mails the reset link to the user with the e-mail, if any.
+func (f *ForgotPasswordUseCase) send(ctx context.Context, email string) error {
+	event := entity.AuditEvent{
+		Actor:  auditlog.EmailActor(email),
+		Action: entity.AuditActionPasswordForgot,
+		Target: auditlog.EmailActor(email),
+	}
+
+	user, err := f.r.GetUserByEmail(ctx, email)
+	if errors.Is(err, errs.ErrUserNotFound) {
+		f.a.Record(ctx, event, nil)
+		return nil
+	}
+	if err != nil {
+		return errs.New(err)
+	}
+
+	token, err := issueOneTimeToken(
+		ctx,
+		f.c,
+		oneTimeTokenPasswordReset,
+		user.ID,
+		f.e.PasswordResetTokenTTL,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	link := f.e.AppURL + "/reset-password?token=" + url.QueryEscape(token)
+
+	err = f.m.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Reset your password by opening the link below, valid for %s:\n\n"+
+				"%s\n\nReset token: %s\n\n"+
+				"If you didn't ask to reset your password, ignore this "+
+				"e-mail.\n",
+			f.e.PasswordResetTokenTTL,
+			link,
+			token,
+		),
+	})
+	event.Target = auditlog.UserActor(user.ID)
+	f.a.Record(ctx, event, err)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+type ResetPasswordUseCase struct {
+	v validator.Validator
+	c cache.Cache
+	h hasher.Hasher
+	s *Sessions
+	r repo.UserRepository
+	a *auditlog.Auditor
+}
+
+func NewResetPasswordUseCase(
+	v validator.Validator,
+	c cache.Cache,
+	h hasher.Hasher,
+	s *Sessions,
+	r repo.UserRepository,
+	a *auditlog.Auditor,
+) *ResetPasswordUseCase {
+	return &ResetPasswordUseCase{
+		v: v,
+		c: c,
+		h: h,
+		s: s,
+		r: r,
+		a: a,
+	}
+}
+
+type ResetPasswordUseCaseInput struct {
+	Token    string `json:"token"    validate:"required"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+// Execute sets the password of the user the token was sent to, and ends
+// their sessions, in case they were signed in by whoever knew the old
+// password.
+func (r *ResetPasswordUseCase) Execute(
+	ctx context.Context,
+	in ResetPasswordUseCaseInput,
+) error {
+	if err := r.v.Validate(in); err != nil {
+		return errs.New(err)
+	}
+
+	userID, ok, err := redeemOneTimeToken(
+		ctx,
+		r.c,
+		oneTimeTokenPasswordReset,
+		in.Token,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+	if !ok {
+		r.a.Record(ctx, entity.AuditEvent{
+			Action: entity.AuditActionPasswordReset,
+		}, errs.ErrInvalidPasswordResetToken)
+		return errs.ErrInvalidPasswordResetToken
+	}
+
+	passwordHash, err := r.h.Hash(in.Password)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	err = r.r.UpdateUserPassword(ctx, userID, passwordHash, time.Now())
+	if err != nil {
+		return errs.New(err)
+	}
+
+	if _, err := r.s.RevokeUser(ctx, userID); err != nil {
+		return errs.New(err)
+	}
+
+	r.a.Record(ctx, entity.AuditEvent{
+		Actor:  auditlog.UserActor(userID),
+		Action: entity.AuditActionPasswordReset,
+		Target: auditlog.UserActor(userID),
+	}, nil)
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config"
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/hasher"
+	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/inmemorycache"
+	"github.com/danielmesquitta/flight-api/internal/provider/mailer/filemailer"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/inmemoryrepo"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMailerEnv returns the config with e-mails appended to a file of the
+// test, at the returned path.
+func newMailerEnv(t *testing.T) (*env.Env, string) {
+	e := *config.LoadConfig(validator.New())
+	e.MailerFilePath = filepath.Join(t.TempDir(), "mail.jsonl")
+	return &e, e.MailerFilePath
+}
+
+var mailTokenRegexp = regexp.MustCompile(`token: (\S+)`)
+
+// waitForMails waits until n e-mails were sent, for e-mails sent in the
+// background.
+func waitForMails(t *testing.T, mailPath string, n int) {
+	assert.Eventually(t, func() bool {
+		messages, err := filemailer.Read(mailPath)
+		return err == nil && len(messages) >= n
+	}, time.Second, 10*time.Millisecond)
+}
+
+// lastMailToken returns the token of the last e-mail sent.
+func lastMailToken(t *testing.T, mailPath string) string {
+	messages, err := filemailer.Read(mailPath)
+	assert.Nil(t, err)
+	if !assert.NotEmpty(t, messages) {
+		return ""
+	}
+
+	match := mailTokenRegexp.FindStringSubmatch(
+		messages[len(messages)-1].Body,
+	)
+	if !assert.Len(t, match, 2) {
+		return ""
+	}
+
+	return match[1]
+}
+
+func TestPasswordReset(t *testing.T) {
+	ctx := context.Background()
+	v := validator.New()
+	h := hasher.New()
+	e, mailPath := newMailerEnv(t)
+
+	c := inmemorycache.NewInMemoryCache(e)
+	s := NewSessions(e, jwtutil.NewJWT(e), jwtutil.NewDenylist(c), c)
+	r := inmemoryrepo.NewInMemoryRepository()
+	m := filemailer.NewFileMailer(e)
+
+	forgot := NewForgotPasswordUseCase(v, e, c, m, r, newAuditor(r))
+	reset := NewResetPasswordUseCase(v, c, h, s, r, newAuditor(r))
+
+	passwordHash, err := h.Hash("P@ssw0rd")
+	assert.Nil(t, err)
+	user := entity.User{
+		ID:           "1",
+		Email:        "johndoe@email.com",
+		PasswordHash: passwordHash,
+	}
+	assert.Nil(t, r.CreateUser(ctx, user))
+
+	tokens, err := s.Issue(ctx, user, "")
+	assert.Nil(t, err)
+
+	err = forgot.Execute(ctx, ForgotPasswordUseCaseInput{
+		Email: "janedoe@email.com",
+	})
+	assert.Nil(t, err)
+
+	messages, err := filemailer.Read(mailPath)
+	assert.Nil(t, err)
+	assert.Empty(t, messages)
+
+	err = forgot.Execute(ctx, ForgotPasswordUseCaseInput{
+		Email: "JohnDoe@email.com",
+	})
+	assert.Nil(t, err)
+	waitForMails(t, mailPath, 1)
+
+	in := ResetPasswordUseCaseInput{
+		Token:    lastMailToken(t, mailPath),
+		Password: "N3wP@ssw0rd",
+	}
+
+	err = reset.Execute(ctx, ResetPasswordUseCaseInput{
+		Token:    in.Token,
+		Password: "short",
+	})
+	assert.Equal(t, errs.ErrCodeValidation, codeOf(err))
+
+	assert.Nil(t, reset.Execute(ctx, in))
+
+	got, err := r.GetUserByID(ctx, user.ID)
+	assert.Nil(t, err)
+	ok, err := h.Compare(got.PasswordHash, in.Password)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	_, err = s.Redeem(ctx, tokens.RefreshToken)
+	assert.ErrorIs(t, err, errs.ErrInvalidRefreshToken)
+
+	err = reset.Execute(ctx, in)
+	assert.ErrorIs(t, err, errs.ErrInvalidPasswordResetToken)
+}
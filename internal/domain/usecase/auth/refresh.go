@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auditlog"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+type RefreshUseCase struct {
+	v validator.Validator
+	s *Sessions
+	r repo.UserRepository
+	a *auditlog.Auditor
+}
+
+func NewRefreshUseCase(
+	v validator.Validator,
+	s *Sessions,
+	r repo.UserRepository,
+	a *auditlog.Auditor,
+) *RefreshUseCase {
+	return &RefreshUseCase{
+		v: v,
+		s: s,
+		r: r,
+		a: a,
+	}
+}
+
+type RefreshUseCaseInput struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type RefreshUseCaseOutput struct {
+	Tokens
+}
+
+// Execute exchanges a refresh token for new tokens. The refresh token is
+// rotated, so it can't be used again.
+func (r *RefreshUseCase) Execute(
+	ctx context.Context,
+	in RefreshUseCaseInput,
+) (*RefreshUseCaseOutput, error) {
+	if err := r.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	// Invalid and replayed refresh tokens are recorded without an actor,
+	// as their claims can't be trusted.
+	claims, err := r.s.Redeem(ctx, in.RefreshToken)
+	if err != nil {
+		r.a.Record(ctx, entity.AuditEvent{
+			Action: entity.AuditActionRefresh,
+		}, err)
+		return nil, errs.New(err)
+	}
+
+	// Read the user again, so that the new tokens carry its current plan.
+	user, err := r.r.GetUserByID(ctx, claims.Subject)
+	if errors.Is(err, errs.ErrUserNotFound) {
+		return nil, errs.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	tokens, err := r.s.Issue(ctx, *user, claims.Family)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	r.a.Record(ctx, entity.AuditEvent{
+		Actor:  auditlog.UserActor(user.ID),
+		Action: entity.AuditActionRefresh,
+		Target: auditlog.UserActor(user.ID),
+	}, nil)
+
+	return &RefreshUseCaseOutput{Tokens: *tokens}, nil
+}
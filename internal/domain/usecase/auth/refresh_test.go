@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/inmemoryrepo"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/mockrepo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRefreshUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	user := &entity.User{
+		ID:    "1",
+		Email: "johndoe@email.com",
+		Plan:  "pro",
+	}
+
+	v := validator.New()
+	e := config.LoadConfig(v)
+	s := newSessions(e)
+
+	r := mockrepo.NewMockUserRepository(t)
+	r.EXPECT().GetUserByID(mock.Anything, "1").Return(user, nil).Maybe()
+	r.EXPECT().
+		GetUserByID(mock.Anything, "2").
+		Return(nil, errs.ErrUserNotFound).
+		Maybe()
+
+	f := NewRefreshUseCase(
+		v,
+		s,
+		r,
+		newAuditor(inmemoryrepo.NewInMemoryRepository()),
+	)
+
+	refresh := func(token string) (*RefreshUseCaseOutput, error) {
+		return f.Execute(ctx, RefreshUseCaseInput{RefreshToken: token})
+	}
+
+	login, err := s.Issue(ctx, *user, "")
+	assert.Nil(t, err)
+
+	t.Run("rotates the refresh token", func(t *testing.T) {
+		got, err := refresh(login.RefreshToken)
+		assert.Nil(t, err)
+		assert.NotEqual(t, login.RefreshToken, got.RefreshToken)
+
+		claims, err := jwtutil.NewJWT(e).
+			Parse(got.AccessToken, jwtutil.TokenTypeAccess)
+		assert.Nil(t, err)
+		assert.Equal(t, "1", claims.Subject)
+		assert.Equal(t, "pro", claims.Plan)
+		assert.Equal(t, entity.Scopes, claims.Scopes)
+
+		_, err = refresh(got.RefreshToken)
+		assert.Nil(t, err)
+	})
+
+	t.Run("revokes the family when a token is replayed", func(t *testing.T) {
+		first, err := s.Issue(ctx, *user, "")
+		assert.Nil(t, err)
+
+		second, err := refresh(first.RefreshToken)
+		assert.Nil(t, err)
+
+		_, err = refresh(first.RefreshToken)
+		assert.Equal(t, errs.ErrRefreshTokenReused, err)
+
+		_, err = refresh(second.RefreshToken)
+		assert.Equal(t, errs.ErrInvalidRefreshToken, err)
+
+		for _, token := range []string{first.AccessToken, second.AccessToken} {
+			claims, err := jwtutil.NewJWT(e).
+				Parse(token, jwtutil.TokenTypeAccess)
+			assert.Nil(t, err)
+
+			revoked, err := s.d.IsRevoked(ctx, claims)
+			assert.Nil(t, err)
+			assert.True(t, revoked)
+		}
+	})
+
+	t.Run("redeems a token once when used concurrently", func(t *testing.T) {
+		tokens, err := s.Issue(ctx, *user, "")
+		assert.Nil(t, err)
+
+		var refreshed atomic.Int64
+		wg := sync.WaitGroup{}
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := refresh(tokens.RefreshToken); err == nil {
+					refreshed.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int64(1), refreshed.Load())
+	})
+
+	t.Run("rejects an access token", func(t *testing.T) {
+		_, err := refresh(login.AccessToken)
+		assert.Equal(t, errs.ErrInvalidRefreshToken, err)
+	})
+
+	t.Run("rejects a token of the other type", func(t *testing.T) {
+		same := *e
+		same.JWTSigningKeys = ""
+		same.JWTRefreshTokenSecretKey = same.JWTAccessTokenSecretKey
+		j := jwtutil.NewJWT(&same)
+
+		claims := jwtutil.UserClaims{
+			Subject:   "1",
+			IssuedAt:  time.Now(),
+			ExpiresAt: time.Now().Add(time.Hour),
+			Family:    "family",
+		}
+
+		access, err := j.NewToken(claims, jwtutil.TokenTypeAccess)
+		assert.Nil(t, err)
+		_, err = j.Parse(access, jwtutil.TokenTypeRefresh)
+		assert.NotNil(t, err)
+
+		refresh, err := j.NewToken(claims, jwtutil.TokenTypeRefresh)
+		assert.Nil(t, err)
+		_, err = j.Parse(refresh, jwtutil.TokenTypeAccess)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("rejects an expired token", func(t *testing.T) {
+		token, err := jwtutil.NewJWT(e).NewToken(jwtutil.UserClaims{
+			Subject:   "1",
+			IssuedAt:  time.Now().Add(-time.Hour),
+			ExpiresAt: time.Now().Add(-time.Minute),
+			ID:        "id",
+			Family:    "family",
+		}, jwtutil.TokenTypeRefresh)
+		assert.Nil(t, err)
+
+		_, err = refresh(token)
+		assert.Equal(t, errs.ErrInvalidRefreshToken, err)
+	})
+
+	t.Run("rejects the tokens of a deleted user", func(t *testing.T) {
+		tokens, err := s.Issue(ctx, entity.User{ID: "2"}, "")
+		assert.Nil(t, err)
+
+		_, err = refresh(tokens.RefreshToken)
+		assert.Equal(t, errs.ErrInvalidRefreshToken, err)
+	})
+
+	t.Run("requires a refresh token", func(t *testing.T) {
+		_, err := refresh("")
+		assert.Equal(t, errs.ErrCodeValidation, codeOf(err))
+	})
+}
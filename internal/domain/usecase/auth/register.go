@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auditlog"
+	"github.com/danielmesquitta/flight-api/internal/pkg/hasher"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	"github.com/google/uuid"
+)
+
+type RegisterUseCase struct {
+	v  validator.Validator
+	h  hasher.Hasher
+	r  repo.UserRepository
+	ev *EmailVerifier
+	a  *auditlog.Auditor
+}
+
+func NewRegisterUseCase(
+	v validator.Validator,
+	h hasher.Hasher,
+	r repo.UserRepository,
+	ev *EmailVerifier,
+	a *auditlog.Auditor,
+) *RegisterUseCase {
+	return &RegisterUseCase{
+		v:  v,
+		h:  h,
+		r:  r,
+		ev: ev,
+		a:  a,
+	}
+}
+
+// RegisterUseCaseInput limits passwords to the 72 bytes bcrypt accepts.
+type RegisterUseCaseInput struct {
+	Email    string `json:"email"    validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+type RegisterUseCaseOutput struct {
+	User entity.User `json:"user"`
+}
+
+func (r *RegisterUseCase) Execute(
+	ctx context.Context,
+	in RegisterUseCaseInput,
+) (*RegisterUseCaseOutput, error) {
+	if err := r.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	passwordHash, err := r.h.Hash(in.Password)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	now := time.Now()
+	user := entity.User{
+		ID:           uuid.NewString(),
+		Email:        normalizeEmail(in.Email),
+		PasswordHash: passwordHash,
+		Roles:        []entity.Role{},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	err = r.r.CreateUser(ctx, user)
+	r.a.Record(ctx, entity.AuditEvent{
+		Actor:  auditlog.EmailActor(user.Email),
+		Action: entity.AuditActionRegister,
+		Target: auditlog.UserActor(user.ID),
+	}, err)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	// The user is registered anyway, and may ask for another link.
+	if err := r.ev.Send(ctx, user); err != nil {
+		slog.ErrorContext(
+			ctx,
+			"failed to send e-mail verification",
+			"error", err,
+		)
+	}
+
+	return &RegisterUseCaseOutput{User: user}, nil
+}
+
+// normalizeEmail makes e-mails that only differ in case or surrounding
+// spaces belong to the same user.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/hasher"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/inmemorycache"
+	"github.com/danielmesquitta/flight-api/internal/provider/mailer/filemailer"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/inmemoryrepo"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/mockrepo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRegisterUseCase_Execute(t *testing.T) {
+	type Test struct {
+		name    string
+		r       *mockrepo.MockUserRepository
+		args    RegisterUseCaseInput
+		wantErr error
+	}
+	tests := []Test{
+		func() Test {
+			r := mockrepo.NewMockUserRepository(t)
+			r.EXPECT().
+				CreateUser(mock.Anything, mock.MatchedBy(
+					func(user entity.User) bool {
+						return user.Email == "johndoe@email.com" &&
+							user.ID != "" &&
+							user.PasswordHash != "P@ssw0rd"
+					},
+				)).
+				Return(nil)
+
+			return Test{
+				name: "registers",
+				r:    r,
+				args: RegisterUseCaseInput{
+					Email:    "JohnDoe@email.com",
+					Password: "P@ssw0rd",
+				},
+			}
+		}(),
+		func() Test {
+			r := mockrepo.NewMockUserRepository(t)
+			r.EXPECT().
+				CreateUser(mock.Anything, mock.Anything).
+				Return(errs.ErrUserAlreadyExists)
+
+			return Test{
+				name: "fails with taken email",
+				r:    r,
+				args: RegisterUseCaseInput{
+					Email:    "johndoe@email.com",
+					Password: "P@ssw0rd",
+				},
+				wantErr: errs.ErrUserAlreadyExists,
+			}
+		}(),
+		{
+			name: "fails with short password",
+			r:    mockrepo.NewMockUserRepository(t),
+			args: RegisterUseCaseInput{
+				Email:    "johndoe@email.com",
+				Password: "short",
+			},
+			wantErr: errs.New("", errs.ErrCodeValidation),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, mailPath := newMailerEnv(t)
+			r := NewRegisterUseCase(
+				validator.New(),
+				hasher.New(),
+				tt.r,
+				NewEmailVerifier(
+					e,
+					inmemorycache.NewInMemoryCache(e),
+					filemailer.NewFileMailer(e),
+				),
+				newAuditor(inmemoryrepo.NewInMemoryRepository()),
+			)
+
+			got, err := r.Execute(context.Background(), tt.args)
+
+			if tt.wantErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, codeOf(tt.wantErr), codeOf(err))
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.NotNil(t, got)
+			assert.Empty(t, got.User.Plan)
+
+			messages, err := filemailer.Read(mailPath)
+			assert.Nil(t, err)
+			if assert.Len(t, messages, 1) {
+				assert.Equal(t, got.User.Email, messages[0].To)
+				assert.Contains(t, messages[0].Body, "/verify-email?token=")
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+type RevokeSessionsUseCase struct {
+	v validator.Validator
+	s *Sessions
+	r repo.UserRepository
+}
+
+func NewRevokeSessionsUseCase(
+	v validator.Validator,
+	s *Sessions,
+	r repo.UserRepository,
+) *RevokeSessionsUseCase {
+	return &RevokeSessionsUseCase{
+		v: v,
+		s: s,
+		r: r,
+	}
+}
+
+type RevokeSessionsUseCaseInput struct {
+	UserID string `json:"user_id" validate:"required"`
+}
+
+type RevokeSessionsUseCaseOutput struct {
+	// Revoked is how many logins were ended.
+	Revoked int `json:"revoked"`
+}
+
+// Execute revokes every token issued to the user so far.
+func (r *RevokeSessionsUseCase) Execute(
+	ctx context.Context,
+	in RevokeSessionsUseCaseInput,
+) (*RevokeSessionsUseCaseOutput, error) {
+	if err := r.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	if _, err := r.r.GetUserByID(ctx, in.UserID); err != nil {
+		return nil, errs.New(err)
+	}
+
+	revoked, err := r.s.RevokeUser(ctx, in.UserID)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &RevokeSessionsUseCaseOutput{Revoked: revoked}, nil
+}
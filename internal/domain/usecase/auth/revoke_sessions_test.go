@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/danielmesquitta/flight-api/internal/config"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/mockrepo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRevokeSessionsUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	user := &entity.User{ID: "1", Email: "johndoe@email.com"}
+	otherUser := entity.User{ID: "2", Email: "janedoe@email.com"}
+
+	v := validator.New()
+	e := config.LoadConfig(v)
+	s := newSessions(e)
+	j := jwtutil.NewJWT(e)
+
+	r := mockrepo.NewMockUserRepository(t)
+	r.EXPECT().GetUserByID(mock.Anything, "1").Return(user, nil)
+	r.EXPECT().
+		GetUserByID(mock.Anything, "3").
+		Return(nil, errs.ErrUserNotFound)
+
+	rs := NewRevokeSessionsUseCase(v, s, r)
+
+	tokens := []*Tokens{}
+	for range 2 {
+		issued, err := s.Issue(ctx, *user, "")
+		assert.Nil(t, err)
+		tokens = append(tokens, issued)
+	}
+	otherTokens, err := s.Issue(ctx, otherUser, "")
+	assert.Nil(t, err)
+
+	got, err := rs.Execute(ctx, RevokeSessionsUseCaseInput{UserID: "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, got.Revoked)
+
+	for _, token := range tokens {
+		claims, err := j.Parse(token.AccessToken, jwtutil.TokenTypeAccess)
+		assert.Nil(t, err)
+
+		revoked, err := s.d.IsRevoked(ctx, claims)
+		assert.Nil(t, err)
+		assert.True(t, revoked)
+
+		_, err = s.Redeem(ctx, token.RefreshToken)
+		assert.Equal(t, errs.ErrInvalidRefreshToken, err)
+	}
+
+	_, err = s.Redeem(ctx, otherTokens.RefreshToken)
+	assert.Nil(t, err, "other users keep their sessions")
+
+	_, err = rs.Execute(ctx, RevokeSessionsUseCaseInput{UserID: "3"})
+	assert.Equal(t, errs.ErrUserNotFound, err)
+}
@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+)
+
+// Sessions issues and revokes access and refresh tokens. Each login
+// starts a family of refresh tokens, of which only the latest one is
+// valid, tracked in the cache.
+type Sessions struct {
+	e *env.Env
+	j *jwtutil.JWT
+	d *jwtutil.Denylist
+	c cache.Cache
+}
+
+func NewSessions(
+	e *env.Env,
+	j *jwtutil.JWT,
+	d *jwtutil.Denylist,
+	c cache.Cache,
+) *Sessions {
+	return &Sessions{
+		e: e,
+		j: j,
+		d: d,
+		c: c,
+	}
+}
+
+type Tokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type refreshFamily struct {
+	TokenID string `json:"token_id"`
+}
+
+func refreshFamilyKey(userID, family string) string {
+	return "auth:refresh:" + userID + ":" + family
+}
+
+func refreshFamiliesPattern(userID string) string {
+	return "auth:refresh:" + cache.EscapePattern(userID) + ":*"
+}
+
+// Issue returns new tokens for the user, making the refresh token the
+// only valid one of its family. An empty family starts a new one.
+func (s *Sessions) Issue(
+	ctx context.Context,
+	user entity.User,
+	family string,
+) (*Tokens, error) {
+	if family == "" {
+		family = uuid.NewString()
+	}
+
+	now := time.Now()
+	accessToken, err := s.j.NewToken(jwtutil.UserClaims{
+		Subject:   user.ID,
+		Issuer:    user.Email,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(s.e.JWTAccessTokenTTL),
+		Plan:      user.Plan,
+		TenantID:  user.OrganizationID,
+		Family:    family,
+		Roles:     user.Roles,
+		Scopes:    entity.Scopes,
+	}, jwtutil.TokenTypeAccess)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	refreshClaims := jwtutil.UserClaims{
+		Subject:   user.ID,
+		Issuer:    user.Email,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(s.e.JWTRefreshTokenTTL),
+		ID:        uuid.NewString(),
+		Family:    family,
+	}
+	refreshToken, err := s.j.NewToken(
+		refreshClaims,
+		jwtutil.TokenTypeRefresh,
+	)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	err = s.c.Set(
+		ctx,
+		refreshFamilyKey(user.ID, family),
+		refreshFamily{TokenID: refreshClaims.ID},
+		s.e.JWTRefreshTokenTTL,
+	)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// Redeem verifies that the refresh token is the latest of its family,
+// and returns its claims. Replaying an older token means it leaked, so
+// the whole family is revoked, along with its access tokens. The family
+// is taken from the cache, so that a token is only redeemed once, even
+// by concurrent requests, and the caller must issue the next token of
+// the family with Issue.
+func (s *Sessions) Redeem(
+	ctx context.Context,
+	refreshToken string,
+) (*jwtutil.UserClaims, error) {
+	claims, err := s.j.Parse(refreshToken, jwtutil.TokenTypeRefresh)
+	if err != nil || claims.Subject == "" || claims.Family == "" {
+		return nil, errs.ErrInvalidRefreshToken
+	}
+
+	key := refreshFamilyKey(claims.Subject, claims.Family)
+
+	var family refreshFamily
+	ok, err := s.c.Take(ctx, key, &family)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	if !ok {
+		return nil, errs.ErrInvalidRefreshToken
+	}
+
+	// The family was already removed when it was taken.
+	if family.TokenID != claims.ID {
+		err := s.d.RevokeFamily(ctx, claims.Family, s.e.JWTAccessTokenTTL)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+		return nil, errs.ErrRefreshTokenReused
+	}
+
+	return claims, nil
+}
+
+// Revoke ends the session of an access token: the token is denied until
+// it expires, and the refresh tokens of its family can't be used anymore.
+func (s *Sessions) Revoke(
+	ctx context.Context,
+	claims *jwtutil.UserClaims,
+) error {
+	if err := s.d.Revoke(ctx, claims); err != nil {
+		return errs.New(err)
+	}
+
+	if claims.Subject == "" || claims.Family == "" {
+		return nil
+	}
+
+	key := refreshFamilyKey(claims.Subject, claims.Family)
+	if err := s.c.Delete(ctx, key); err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+// RevokeUser ends every session of the user, and returns how many refresh
+// token families were revoked.
+func (s *Sessions) RevokeUser(
+	ctx context.Context,
+	userID string,
+) (int, error) {
+	keys := []string{}
+	for info, err := range s.c.Keys(ctx, refreshFamiliesPattern(userID)) {
+		if err != nil {
+			return 0, errs.New(err)
+		}
+		keys = append(keys, info.Key)
+	}
+
+	if len(keys) > 0 {
+		if err := s.c.Delete(ctx, keys...); err != nil {
+			return 0, errs.New(err)
+		}
+	}
+
+	err := s.d.RevokeSubject(ctx, userID, s.e.JWTAccessTokenTTL)
+	if err != nil {
+		return 0, errs.New(err)
+	}
+
+	return len(keys), nil
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+type UpdateUserRolesUseCase struct {
+	v validator.Validator
+	r repo.UserRepository
+}
+
+func NewUpdateUserRolesUseCase(
+	v validator.Validator,
+	r repo.UserRepository,
+) *UpdateUserRolesUseCase {
+	return &UpdateUserRolesUseCase{
+		v: v,
+		r: r,
+	}
+}
+
+type UpdateUserRolesUseCaseInput struct {
+	UserID string        `json:"-"     validate:"required"`
+	Roles  []entity.Role `json:"roles" validate:"required,dive,oneof=admin"`
+}
+
+type UpdateUserRolesUseCaseOutput struct {
+	User entity.User `json:"user"`
+}
+
+// Execute replaces the roles of the user. Access tokens keep the roles
+// they were issued with until they expire, and refreshed ones get the
+// new roles.
+func (u *UpdateUserRolesUseCase) Execute(
+	ctx context.Context,
+	in UpdateUserRolesUseCaseInput,
+) (*UpdateUserRolesUseCaseOutput, error) {
+	if err := u.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	roles := slices.Clone(in.Roles)
+	slices.Sort(roles)
+	roles = slices.Compact(roles)
+
+	err := u.r.UpdateUserRoles(ctx, in.UserID, roles, time.Now())
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	user, err := u.r.GetUserByID(ctx, in.UserID)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &UpdateUserRolesUseCaseOutput{User: *user}, nil
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/mockrepo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateUserRolesUseCase_Execute(t *testing.T) {
+	admin := []entity.Role{entity.RoleAdmin}
+
+	type Test struct {
+		name    string
+		r       *mockrepo.MockUserRepository
+		args    UpdateUserRolesUseCaseInput
+		want    []entity.Role
+		wantErr error
+	}
+	tests := []Test{
+		func() Test {
+			r := mockrepo.NewMockUserRepository(t)
+			r.EXPECT().
+				UpdateUserRoles(mock.Anything, "1", admin, mock.Anything).
+				Return(nil)
+			r.EXPECT().
+				GetUserByID(mock.Anything, "1").
+				Return(&entity.User{ID: "1", Roles: admin}, nil)
+
+			return Test{
+				name: "grants roles once",
+				r:    r,
+				args: UpdateUserRolesUseCaseInput{
+					UserID: "1",
+					Roles:  []entity.Role{entity.RoleAdmin, entity.RoleAdmin},
+				},
+				want: admin,
+			}
+		}(),
+		func() Test {
+			r := mockrepo.NewMockUserRepository(t)
+			r.EXPECT().
+				UpdateUserRoles(
+					mock.Anything,
+					"1",
+					[]entity.Role{},
+					mock.Anything,
+				).
+				Return(nil)
+			r.EXPECT().
+				GetUserByID(mock.Anything, "1").
+				Return(&entity.User{ID: "1", Roles: []entity.Role{}}, nil)
+
+			return Test{
+				name: "removes every role",
+				r:    r,
+				args: UpdateUserRolesUseCaseInput{
+					UserID: "1",
+					Roles:  []entity.Role{},
+				},
+				want: []entity.Role{},
+			}
+		}(),
+		func() Test {
+			r := mockrepo.NewMockUserRepository(t)
+			r.EXPECT().
+				UpdateUserRoles(mock.Anything, "2", admin, mock.Anything).
+				Return(errs.ErrUserNotFound)
+
+			return Test{
+				name: "fails with unknown user",
+				r:    r,
+				args: UpdateUserRolesUseCaseInput{
+					UserID: "2",
+					Roles:  admin,
+				},
+				wantErr: errs.ErrUserNotFound,
+			}
+		}(),
+		{
+			name: "fails with unknown role",
+			r:    mockrepo.NewMockUserRepository(t),
+			args: UpdateUserRolesUseCaseInput{
+				UserID: "1",
+				Roles:  []entity.Role{"owner"},
+			},
+			wantErr: errs.New("", errs.ErrCodeValidation),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewUpdateUserRolesUseCase(validator.New(), tt.r)
+
+			got, err := u.Execute(context.Background(), tt.args)
+
+			if tt.wantErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, codeOf(tt.wantErr), codeOf(err))
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got.User.Roles)
+		})
+	}
+}
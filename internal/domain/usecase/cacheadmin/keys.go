@@ -0,0 +1,210 @@
+package cacheadmin
+
+import (
+	"cmp"
+	"context"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/flight"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+)
+
+const (
+	defaultCacheKeysLimit = 100
+
+	// purgeBatchSize is how many keys are deleted at once while purging.
+	purgeBatchSize = 100
+)
+
+// CacheKeysFilter selects cache keys by a raw pattern or, when no
+// pattern is given, the cached searches of a tenant, route and date.
+type CacheKeysFilter struct {
+	Pattern        string    `json:"pattern"`
+	OrganizationID string    `json:"organization_id"`
+	Origin         string    `json:"origin"          validate:"omitempty,len=3"`
+	Destination    string    `json:"destination"     validate:"omitempty,len=3"`
+	Date           time.Time `json:"date"`
+}
+
+func (f CacheKeysFilter) isEmpty() bool {
+	return f.Pattern == "" &&
+		f.OrganizationID == "" &&
+		f.Origin == "" &&
+		f.Destination == "" &&
+		f.Date.IsZero()
+}
+
+func (f CacheKeysFilter) pattern() string {
+	if f.Pattern != "" {
+		return f.Pattern
+	}
+	return flight.SearchCacheKeyPattern(
+		f.OrganizationID,
+		f.Origin,
+		f.Destination,
+		f.Date,
+	)
+}
+
+type CacheKey struct {
+	Key string `json:"key"`
+	// TTL in seconds, or -1 if the key never expires.
+	TTL  int64 `json:"ttl"`
+	Size int64 `json:"size"`
+}
+
+type ListCacheKeysUseCase struct {
+	v validator.Validator
+	c cache.Cache
+}
+
+func NewListCacheKeysUseCase(
+	v validator.Validator,
+	c cache.Cache,
+) *ListCacheKeysUseCase {
+	return &ListCacheKeysUseCase{
+		v: v,
+		c: c,
+	}
+}
+
+type ListCacheKeysUseCaseInput struct {
+	CacheKeysFilter
+	Limit int `json:"limit" validate:"omitempty,min=1,max=1000"`
+}
+
+type ListCacheKeysUseCaseOutput struct {
+	Data []CacheKey `json:"data"`
+	// Truncated is set when more keys than the limit matched.
+	Truncated bool `json:"truncated"`
+}
+
+func (l *ListCacheKeysUseCase) Execute(
+	ctx context.Context,
+	in ListCacheKeysUseCaseInput,
+) (*ListCacheKeysUseCaseOutput, error) {
+	if err := l.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	in.Limit = cmp.Or(in.Limit, defaultCacheKeysLimit)
+
+	out := &ListCacheKeysUseCaseOutput{
+		Data: []CacheKey{},
+	}
+
+	for info, err := range l.c.Keys(ctx, in.pattern()) {
+		if err != nil {
+			return nil, errs.New(err)
+		}
+
+		if len(out.Data) == in.Limit {
+			out.Truncated = true
+			break
+		}
+
+		key := CacheKey{
+			Key:  info.Key,
+			TTL:  -1,
+			Size: info.Size,
+		}
+		if info.TTL > 0 {
+			key.TTL = int64(info.TTL.Seconds())
+		}
+
+		out.Data = append(out.Data, key)
+	}
+
+	return out, nil
+}
+
+type PurgeCacheKeysUseCase struct {
+	v validator.Validator
+	c cache.Cache
+}
+
+func NewPurgeCacheKeysUseCase(
+	v validator.Validator,
+	c cache.Cache,
+) *PurgeCacheKeysUseCase {
+	return &PurgeCacheKeysUseCase{
+		v: v,
+		c: c,
+	}
+}
+
+type PurgeCacheKeysUseCaseInput struct {
+	CacheKeysFilter
+}
+
+type PurgeCacheKeysUseCaseOutput struct {
+	Deleted int `json:"deleted"`
+}
+
+func (p *PurgeCacheKeysUseCase) Execute(
+	ctx context.Context,
+	in PurgeCacheKeysUseCaseInput,
+) (*PurgeCacheKeysUseCaseOutput, error) {
+	if err := p.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	// Purging every cached search must be asked for explicitly, with the
+	// pattern.
+	if in.isEmpty() {
+		return nil, errs.ErrCacheKeysFilterRequired
+	}
+
+	deleted, err := purgeKeys(ctx, p.c, in.pattern())
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &PurgeCacheKeysUseCaseOutput{
+		Deleted: deleted,
+	}, nil
+}
+
+// purgeKeys deletes the keys matching pattern in batches, and returns
+// how many were deleted.
+func purgeKeys(
+	ctx context.Context,
+	c cache.Cache,
+	pattern string,
+) (int, error) {
+	deleted := 0
+	batch := make([]string, 0, purgeBatchSize)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := c.Delete(ctx, batch...); err != nil {
+			return err
+		}
+		deleted += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for info, err := range c.Keys(ctx, pattern) {
+		if err != nil {
+			return deleted, err
+		}
+
+		batch = append(batch, info.Key)
+		if len(batch) == purgeBatchSize {
+			if err := flush(); err != nil {
+				return deleted, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return deleted, err
+	}
+
+	return deleted, nil
+}
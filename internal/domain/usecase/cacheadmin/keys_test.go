@@ -0,0 +1,141 @@
+package cacheadmin
+
+import (
+	"context"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/mockcache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func keys(infos ...cache.KeyInfo) iter.Seq2[cache.KeyInfo, error] {
+	return func(yield func(cache.KeyInfo, error) bool) {
+		for _, info := range infos {
+			if !yield(info, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestListCacheKeysUseCase_Execute(t *testing.T) {
+	c := mockcache.NewMockCache(t)
+	c.EXPECT().
+		Keys(context.Background(), "flight:search:v5:*:GRU:JFK:2025-01-01:*").
+		Return(keys(
+			cache.KeyInfo{Key: "a", TTL: time.Minute, Size: 10},
+			cache.KeyInfo{Key: "b", Size: 20},
+			cache.KeyInfo{Key: "c", Size: 30},
+		))
+
+	l := NewListCacheKeysUseCase(validator.New(), c)
+
+	got, err := l.Execute(context.Background(), ListCacheKeysUseCaseInput{
+		CacheKeysFilter: CacheKeysFilter{
+			Origin:      "gru",
+			Destination: "jfk",
+			Date:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Limit: 2,
+	})
+
+	assert.Nil(t, err)
+	assert.True(t, got.Truncated)
+	assert.Equal(t, []CacheKey{
+		{Key: "a", TTL: 60, Size: 10},
+		{Key: "b", TTL: -1, Size: 20},
+	}, got.Data)
+}
+
+func TestPurgeCacheKeysUseCase_Execute(t *testing.T) {
+	type Test struct {
+		name    string
+		c       func() *mockcache.MockCache
+		args    PurgeCacheKeysUseCaseInput
+		want    *PurgeCacheKeysUseCaseOutput
+		wantErr bool
+	}
+
+	tests := []Test{
+		{
+			name: "purges keys by pattern",
+			c: func() *mockcache.MockCache {
+				infos := make([]cache.KeyInfo, purgeBatchSize+1)
+				for i := range infos {
+					infos[i] = cache.KeyInfo{Key: "flightapi:flights:key"}
+				}
+
+				c := mockcache.NewMockCache(t)
+				c.EXPECT().
+					Keys(context.Background(), "flightapi:flights:*").
+					Return(keys(infos...))
+				c.EXPECT().
+					Delete(context.Background(), mock.Anything).
+					Return(nil).
+					Twice()
+				return c
+			},
+			args: PurgeCacheKeysUseCaseInput{
+				CacheKeysFilter: CacheKeysFilter{
+					Pattern: "flightapi:flights:*",
+				},
+			},
+			want: &PurgeCacheKeysUseCaseOutput{
+				Deleted: purgeBatchSize + 1,
+			},
+		},
+		{
+			name: "requires a filter",
+			c: func() *mockcache.MockCache {
+				return mockcache.NewMockCache(t)
+			},
+			args:    PurgeCacheKeysUseCaseInput{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPurgeCacheKeysUseCase(validator.New(), tt.c())
+
+			got, err := p.Execute(context.Background(), tt.args)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFlushRateLimitUseCase_Execute(t *testing.T) {
+	c := mockcache.NewMockCache(t)
+	c.EXPECT().
+		Keys(context.Background(), "ratelimit:ip:10.0.0.1:*").
+		Return(keys(
+			cache.KeyInfo{Key: "ratelimit:ip:10.0.0.1:search:1760000000"},
+			cache.KeyInfo{Key: "ratelimit:ip:10.0.0.1:search:1760000060"},
+		))
+	c.EXPECT().
+		Delete(context.Background(), []string{
+			"ratelimit:ip:10.0.0.1:search:1760000000",
+			"ratelimit:ip:10.0.0.1:search:1760000060",
+		}).
+		Return(nil)
+
+	f := NewFlushRateLimitUseCase(validator.New(), c)
+
+	got, err := f.Execute(context.Background(), FlushRateLimitUseCaseInput{
+		Client: "ip:10.0.0.1",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, got.Deleted)
+}
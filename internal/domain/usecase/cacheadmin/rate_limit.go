@@ -0,0 +1,51 @@
+package cacheadmin
+
+import (
+	"context"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/ratelimit"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+)
+
+type FlushRateLimitUseCase struct {
+	v validator.Validator
+	c cache.Cache
+}
+
+func NewFlushRateLimitUseCase(
+	v validator.Validator,
+	c cache.Cache,
+) *FlushRateLimitUseCase {
+	return &FlushRateLimitUseCase{
+		v: v,
+		c: c,
+	}
+}
+
+type FlushRateLimitUseCaseInput struct {
+	Client string `json:"client" validate:"required"`
+}
+
+type FlushRateLimitUseCaseOutput struct {
+	Deleted int `json:"deleted"`
+}
+
+// Execute deletes the rate limit counters of a client, so that its
+// requests are allowed again right away.
+func (f *FlushRateLimitUseCase) Execute(
+	ctx context.Context,
+	in FlushRateLimitUseCaseInput,
+) (*FlushRateLimitUseCaseOutput, error) {
+	if err := f.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	deleted, err := purgeKeys(ctx, f.c, ratelimit.ClientKeyPattern(in.Client))
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &FlushRateLimitUseCaseOutput{Deleted: deleted}, nil
+}
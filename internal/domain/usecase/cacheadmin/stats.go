@@ -0,0 +1,37 @@
+package cacheadmin
+
+import (
+	"context"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+)
+
+type GetCacheStatsUseCase struct {
+	c cache.Cache
+}
+
+func NewGetCacheStatsUseCase(
+	c cache.Cache,
+) *GetCacheStatsUseCase {
+	return &GetCacheStatsUseCase{
+		c: c,
+	}
+}
+
+type GetCacheStatsUseCaseOutput struct {
+	cache.Stats
+}
+
+func (g *GetCacheStatsUseCase) Execute(
+	ctx context.Context,
+) (*GetCacheStatsUseCaseOutput, error) {
+	reporter, ok := g.c.(cache.StatsReporter)
+	if !ok {
+		return nil, errs.ErrCacheStatsUnavailable
+	}
+
+	return &GetCacheStatsUseCaseOutput{
+		Stats: reporter.Stats(),
+	}, nil
+}
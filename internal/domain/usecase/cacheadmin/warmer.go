@@ -0,0 +1,37 @@
+package cacheadmin
+
+import (
+	"context"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/flight"
+)
+
+type GetCacheWarmerStatusUseCase struct {
+	w *flight.CacheWarmer
+}
+
+func NewGetCacheWarmerStatusUseCase(
+	w *flight.CacheWarmer,
+) *GetCacheWarmerStatusUseCase {
+	return &GetCacheWarmerStatusUseCase{
+		w: w,
+	}
+}
+
+type GetCacheWarmerStatusUseCaseOutput struct {
+	*flight.CacheWarmerStatus
+}
+
+func (g *GetCacheWarmerStatusUseCase) Execute(
+	ctx context.Context,
+) (*GetCacheWarmerStatusUseCaseOutput, error) {
+	status, err := g.w.Status(ctx)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &GetCacheWarmerStatusUseCaseOutput{
+		CacheWarmerStatus: status,
+	}, nil
+}
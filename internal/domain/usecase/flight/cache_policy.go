@@ -0,0 +1,312 @@
+package flight
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+)
+
+// routePricesRetention is how long the observed prices of a route are
+// kept after its last search.
+const routePricesRetention = 7 * 24 * time.Hour
+
+// volatilitySmoothing is the weight of the latest price change in the
+// volatility of a route, the previous changes weighting the rest.
+const volatilitySmoothing = 0.3
+
+// CacheTTL holds how long a cached search is fresh (Soft) and how long
+// it may still be served stale while it is refreshed (Hard).
+type CacheTTL struct {
+	Soft time.Duration
+	Hard time.Duration
+}
+
+// departureTTL holds the cache TTLs of searches departing at least Days
+// days from now.
+type departureTTL struct {
+	Days int
+	TTL  CacheTTL
+}
+
+// routePrices holds the last cheapest price of each searched date of a
+// route, and the average relative change between consecutive ones.
+type routePrices struct {
+	Prices     map[string]int64 `json:"prices"`
+	Volatility float64          `json:"volatility"`
+}
+
+// CachePolicy decides the cache TTLs of a search. Searches departing
+// further away are cached for longer, and routes whose prices change
+// often for shorter. Routes may have fixed TTLs instead, and no search
+// is cached past the expiration of its offers.
+type CachePolicy struct {
+	c                cache.Cache
+	defaultTTL       CacheTTL
+	departures       []departureTTL
+	routes           map[string]CacheTTL
+	volatilityWeight float64
+}
+
+func NewCachePolicy(
+	e *env.Env,
+	c cache.Cache,
+) *CachePolicy {
+	routes, err := parseCacheRoutes(e.SearchCacheRoutes)
+	if err != nil {
+		panic(err)
+	}
+
+	departures, err := parseCacheDepartures(e.SearchCacheDepartureTTLs)
+	if err != nil {
+		panic(err)
+	}
+
+	return &CachePolicy{
+		c: c,
+		defaultTTL: CacheTTL{
+			Soft: e.SearchCacheSoftTTL,
+			Hard: e.SearchCacheHardTTL,
+		},
+		departures:       departures,
+		routes:           routes,
+		volatilityWeight: e.SearchCacheVolatilityWeight,
+	}
+}
+
+// TTL returns the cache TTLs of a search departing on date, which found
+// flights. The cheapest of the flights is recorded to estimate how
+// volatile the prices of the route are.
+func (p *CachePolicy) TTL(
+	ctx context.Context,
+	origin, destination string,
+	date time.Time,
+	flights []entity.Flight,
+) CacheTTL {
+	ttl, ok := p.routes[routeKey(origin, destination)]
+	if !ok {
+		ttl = p.departureTTL(date)
+
+		volatility, err := p.observe(ctx, origin, destination, date, flights)
+		if err != nil {
+			slog.ErrorContext(
+				ctx,
+				"failed to observe route prices for cache policy",
+				"error", err,
+			)
+		}
+
+		// A route whose cheapest price changes by 10% between searches is
+		// cached for half as long with the default weight of 10.
+		ttl = ttl.scale(1 / (1 + volatility*p.volatilityWeight))
+	}
+
+	if expiresAt := offersExpiresAt(flights); !expiresAt.IsZero() {
+		ttl.Hard = max(min(ttl.Hard, time.Until(expiresAt)), 0)
+		ttl.Soft = min(ttl.Soft, ttl.Hard)
+	}
+
+	return ttl
+}
+
+// departureTTL returns the cache TTLs of the furthest departure tier
+// the date reaches, or the default ones.
+func (p *CachePolicy) departureTTL(date time.Time) CacheTTL {
+	days := int(time.Until(date).Hours() / 24)
+
+	ttl := p.defaultTTL
+	for _, departure := range p.departures {
+		if days < departure.Days {
+			break
+		}
+		ttl = departure.TTL
+	}
+
+	return ttl
+}
+
+// observe records the cheapest price of a route and date, and returns
+// the updated volatility of the route.
+func (p *CachePolicy) observe(
+	ctx context.Context,
+	origin, destination string,
+	date time.Time,
+	flights []entity.Flight,
+) (float64, error) {
+	key := routePricesKey(origin, destination)
+
+	prices := routePrices{}
+	if _, err := p.c.Scan(ctx, key, &prices); err != nil {
+		return 0, errs.New(err)
+	}
+
+	if len(flights) == 0 {
+		return prices.Volatility, nil
+	}
+
+	cheapest := slices.MinFunc(flights, func(a, b entity.Flight) int {
+		return cmp.Compare(a.Price, b.Price)
+	}).Price
+
+	day := date.Format(time.DateOnly)
+	if last, ok := prices.Prices[day]; ok && last > 0 {
+		change := float64(cheapest-last) / float64(last)
+		if change < 0 {
+			change = -change
+		}
+		prices.Volatility = volatilitySmoothing*change +
+			(1-volatilitySmoothing)*prices.Volatility
+	}
+
+	today := time.Now().Format(time.DateOnly)
+	for d := range prices.Prices {
+		if d < today {
+			delete(prices.Prices, d)
+		}
+	}
+	if prices.Prices == nil {
+		prices.Prices = map[string]int64{}
+	}
+	prices.Prices[day] = cheapest
+
+	if err := p.c.Set(ctx, key, prices, routePricesRetention); err != nil {
+		return 0, errs.New(err)
+	}
+
+	return prices.Volatility, nil
+}
+
+func (t CacheTTL) scale(factor float64) CacheTTL {
+	return CacheTTL{
+		Soft: time.Duration(float64(t.Soft) * factor),
+		Hard: time.Duration(float64(t.Hard) * factor),
+	}
+}
+
+// offersExpiresAt returns when the first of the offers expires, or zero
+// if none of them do.
+func offersExpiresAt(flights []entity.Flight) time.Time {
+	var expiresAt time.Time
+	for _, flight := range flights {
+		if flight.ExpiresAt.IsZero() {
+			continue
+		}
+		if expiresAt.IsZero() || flight.ExpiresAt.Before(expiresAt) {
+			expiresAt = flight.ExpiresAt
+		}
+	}
+	return expiresAt
+}
+
+func routePricesKey(origin, destination string) string {
+	return "flight:search:prices:" + routeKey(origin, destination)
+}
+
+// parseCacheRoutes parses route overrides in the format
+// ORIGIN-DESTINATION:SOFT:HARD, comma separated.
+func parseCacheRoutes(raw string) (map[string]CacheTTL, error) {
+	routes := map[string]CacheTTL{}
+
+	for entry := range strings.SplitSeq(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return nil, errs.New(
+				fmt.Sprintf("invalid search cache route %q", entry),
+			)
+		}
+
+		route := strings.Split(parts[0], "-")
+		if len(route) != 2 {
+			return nil, errs.New(
+				fmt.Sprintf("invalid search cache route %q", entry),
+			)
+		}
+
+		ttl, err := parseCacheTTL(entry, parts[1], parts[2])
+		if err != nil {
+			return nil, err
+		}
+
+		routes[routeKey(route[0], route[1])] = ttl
+	}
+
+	return routes, nil
+}
+
+// parseCacheDepartures parses departure tiers in the format
+// DAYS:SOFT:HARD, comma separated, sorted by days.
+func parseCacheDepartures(raw string) ([]departureTTL, error) {
+	departures := []departureTTL{}
+
+	for entry := range strings.SplitSeq(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return nil, errs.New(
+				fmt.Sprintf("invalid search cache departure %q", entry),
+			)
+		}
+
+		days, err := strconv.Atoi(parts[0])
+		if err != nil || days < 0 {
+			return nil, errs.New(
+				fmt.Sprintf("invalid search cache departure %q", entry),
+			)
+		}
+
+		ttl, err := parseCacheTTL(entry, parts[1], parts[2])
+		if err != nil {
+			return nil, err
+		}
+
+		departures = append(departures, departureTTL{Days: days, TTL: ttl})
+	}
+
+	slices.SortFunc(departures, func(a, b departureTTL) int {
+		return cmp.Compare(a.Days, b.Days)
+	})
+
+	return departures, nil
+}
+
+func parseCacheTTL(entry, rawSoft, rawHard string) (CacheTTL, error) {
+	soft, err := time.ParseDuration(rawSoft)
+	if err != nil {
+		return CacheTTL{}, errs.New(err)
+	}
+
+	hard, err := time.ParseDuration(rawHard)
+	if err != nil {
+		return CacheTTL{}, errs.New(err)
+	}
+
+	if hard < soft {
+		return CacheTTL{}, errs.New(
+			fmt.Sprintf("hard ttl lower than soft ttl in %q", entry),
+		)
+	}
+
+	return CacheTTL{Soft: soft, Hard: hard}, nil
+}
+
+func routeKey(origin, destination string) string {
+	return strings.ToUpper(origin) + "-" + strings.ToUpper(destination)
+}
@@ -0,0 +1,209 @@
+package flight
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/inmemorycache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachePolicy_TTL(t *testing.T) {
+	now := time.Now()
+
+	type Test struct {
+		name        string
+		origin      string
+		date        time.Time
+		flights     []entity.Flight
+		prevFlights []entity.Flight
+		want        CacheTTL
+	}
+
+	tests := []Test{
+		{
+			name:    "uses the default ttl for close departures",
+			origin:  "LAX",
+			date:    now.AddDate(0, 0, 1),
+			flights: []entity.Flight{{Price: 100}},
+			want:    CacheTTL{Soft: 30 * time.Second, Hard: 5 * time.Minute},
+		},
+		{
+			name:    "uses the ttl of the departure tier",
+			origin:  "LAX",
+			date:    now.AddDate(0, 0, 40),
+			flights: []entity.Flight{{Price: 100}},
+			want:    CacheTTL{Soft: 10 * time.Minute, Hard: time.Hour},
+		},
+		{
+			name:    "uses the ttl of the route",
+			origin:  "GRU",
+			date:    now.AddDate(0, 0, 40),
+			flights: []entity.Flight{{Price: 100}},
+			want:    CacheTTL{Soft: time.Minute, Hard: 10 * time.Minute},
+		},
+		{
+			name:        "shrinks the ttl of volatile routes",
+			origin:      "LAX",
+			date:        now.AddDate(0, 0, 40),
+			prevFlights: []entity.Flight{{Price: 100}},
+			flights:     []entity.Flight{{Price: 200}, {Price: 300}},
+			// The cheapest price doubled, a volatility of 0.3 that cuts the
+			// ttl of the departure tier to a quarter.
+			want: CacheTTL{Soft: 150 * time.Second, Hard: 15 * time.Minute},
+		},
+		{
+			name:   "caps the ttl at the offers expiration",
+			origin: "GRU",
+			date:   now.AddDate(0, 0, 40),
+			flights: []entity.Flight{
+				{Price: 100},
+				{Price: 200, ExpiresAt: now.Add(30 * time.Second)},
+			},
+			want: CacheTTL{Soft: 30 * time.Second, Hard: 30 * time.Second},
+		},
+		{
+			name:   "does not cache expired offers",
+			origin: "LAX",
+			date:   now.AddDate(0, 0, 1),
+			flights: []entity.Flight{
+				{Price: 100, ExpiresAt: now.Add(-time.Second)},
+			},
+			want: CacheTTL{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &env.Env{
+				InMemoryCacheMaxEntries:     100,
+				SearchCacheSoftTTL:          30 * time.Second,
+				SearchCacheHardTTL:          5 * time.Minute,
+				SearchCacheDepartureTTLs:    "30:10m:1h,7:2m:15m",
+				SearchCacheVolatilityWeight: 10,
+				SearchCacheRoutes:           "GRU-JFK:1m:10m",
+			}
+			p := NewCachePolicy(e, inmemorycache.NewInMemoryCache(e))
+			ctx := context.Background()
+
+			if tt.prevFlights != nil {
+				p.TTL(ctx, tt.origin, "JFK", tt.date, tt.prevFlights)
+			}
+
+			got := p.TTL(ctx, tt.origin, "JFK", tt.date, tt.flights)
+
+			assert.InDelta(t, tt.want.Soft, got.Soft, float64(time.Second))
+			assert.InDelta(t, tt.want.Hard, got.Hard, float64(time.Second))
+		})
+	}
+}
+
+func TestParseCacheDepartures(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    []departureTTL
+		wantErr bool
+	}{
+		{
+			name: "parses and sorts tiers",
+			raw:  "30:10m:1h, 7:2m:15m",
+			want: []departureTTL{
+				{
+					Days: 7,
+					TTL: CacheTTL{
+						Soft: 2 * time.Minute,
+						Hard: 15 * time.Minute,
+					},
+				},
+				{
+					Days: 30,
+					TTL: CacheTTL{
+						Soft: 10 * time.Minute,
+						Hard: time.Hour,
+					},
+				},
+			},
+		},
+		{
+			name: "parses empty config",
+			raw:  "",
+			want: []departureTTL{},
+		},
+		{
+			name:    "fails with invalid days",
+			raw:     "week:2m:15m",
+			wantErr: true,
+		},
+		{
+			name:    "fails with hard ttl lower than soft ttl",
+			raw:     "7:15m:2m",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCacheDepartures(tt.raw)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseCacheRoutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    map[string]CacheTTL
+		wantErr bool
+	}{
+		{
+			name: "parses routes",
+			raw:  "GRU-JFK:1m:10m, lax-jfk:10s:1m",
+			want: map[string]CacheTTL{
+				"GRU-JFK": {Soft: time.Minute, Hard: 10 * time.Minute},
+				"LAX-JFK": {Soft: 10 * time.Second, Hard: time.Minute},
+			},
+		},
+		{
+			name: "parses empty config",
+			raw:  "",
+			want: map[string]CacheTTL{},
+		},
+		{
+			name:    "fails without hard ttl",
+			raw:     "GRU-JFK:1m",
+			wantErr: true,
+		},
+		{
+			name:    "fails with hard ttl lower than soft ttl",
+			raw:     "GRU-JFK:10m:1m",
+			wantErr: true,
+		},
+		{
+			name:    "fails with invalid route",
+			raw:     "GRUJFK:1m:10m",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCacheRoutes(tt.raw)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
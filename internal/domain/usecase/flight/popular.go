@@ -0,0 +1,143 @@
+package flight
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+	"github.com/danielmesquitta/flight-api/internal/provider/flightapi"
+)
+
+// popularSearchesRetention is how long each daily ranking is kept, so
+// that yesterday's searches still count during the first hours of today.
+const popularSearchesRetention = 48 * time.Hour
+
+// PopularSearch is a route and date searched by a tenant, and how many
+// times it was searched.
+type PopularSearch struct {
+	TenantID    string    `json:"organization_id,omitempty"`
+	Origin      string    `json:"origin"`
+	Destination string    `json:"destination"`
+	Date        time.Time `json:"date"`
+	Searches    int64     `json:"searches"`
+}
+
+// PopularSearches counts searches per tenant, route and date in daily
+// rankings.
+// Tracking is disabled if the cache can't rank members.
+type PopularSearches struct {
+	c cache.Cache
+}
+
+func NewPopularSearches(
+	c cache.Cache,
+) *PopularSearches {
+	return &PopularSearches{
+		c: c,
+	}
+}
+
+// Track counts a search of the route on the given date by the tenant.
+func (p *PopularSearches) Track(
+	ctx context.Context,
+	tenantID, origin, destination string,
+	date time.Time,
+) error {
+	r, ok := p.c.(cache.Ranker)
+	if !ok {
+		return nil
+	}
+
+	member := fmt.Sprintf(
+		"%s:%s:%s:%s",
+		flightapi.TenantKey(tenantID),
+		strings.ToUpper(origin),
+		strings.ToUpper(destination),
+		date.Format(time.DateOnly),
+	)
+
+	err := r.IncrementScore(
+		ctx,
+		p.key(time.Now()),
+		member,
+		1,
+		popularSearchesRetention,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+// Top returns the n most searched routes and dates of today and
+// yesterday, skipping dates already in the past.
+func (p *PopularSearches) Top(
+	ctx context.Context,
+	n int,
+) ([]PopularSearch, error) {
+	r, ok := p.c.(cache.Ranker)
+	if !ok {
+		return nil, nil
+	}
+
+	now := time.Now()
+	counts := map[string]float64{}
+	for _, day := range []time.Time{now, now.AddDate(0, 0, -1)} {
+		scores, err := r.TopScores(ctx, p.key(day), n)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+		for _, score := range scores {
+			counts[score.Member] += score.Score
+		}
+	}
+
+	today := now.Format(time.DateOnly)
+	searches := make([]PopularSearch, 0, len(counts))
+	for member, count := range counts {
+		parts := strings.Split(member, ":")
+		if len(parts) != 4 || parts[3] < today {
+			continue
+		}
+
+		date, err := time.Parse(time.DateOnly, parts[3])
+		if err != nil {
+			continue
+		}
+
+		tenantID := parts[0]
+		if tenantID == flightapi.DefaultTenant {
+			tenantID = ""
+		}
+
+		searches = append(searches, PopularSearch{
+			TenantID:    tenantID,
+			Origin:      parts[1],
+			Destination: parts[2],
+			Date:        date,
+			Searches:    int64(count),
+		})
+	}
+
+	slices.SortFunc(searches, func(a, b PopularSearch) int {
+		return cmp.Or(
+			cmp.Compare(b.Searches, a.Searches),
+			a.Date.Compare(b.Date),
+			cmp.Compare(a.Origin, b.Origin),
+			cmp.Compare(a.Destination, b.Destination),
+			cmp.Compare(a.TenantID, b.TenantID),
+		)
+	})
+
+	return searches[:min(n, len(searches))], nil
+}
+
+func (p *PopularSearches) key(day time.Time) string {
+	return "flight:search:popular:" + day.Format(time.DateOnly)
+}
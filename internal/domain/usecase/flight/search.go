@@ -3,49 +3,120 @@ package flight
 import (
 	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/danielmesquitta/flight-api/internal/config/env"
 	"github.com/danielmesquitta/flight-api/internal/domain/entity"
 	"github.com/danielmesquitta/flight-api/internal/domain/errs"
 	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
 	"github.com/danielmesquitta/flight-api/internal/provider/cache"
 	"github.com/danielmesquitta/flight-api/internal/provider/flightapi"
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/singleflight"
 )
 
+// searchTimeout bounds a provider search shared by concurrent requests,
+// or run in background to refresh a stale search.
+const searchTimeout = 60 * time.Second
+
+// lockPollInterval is how often an instance waiting for another one to
+// search checks the cache for its result.
+const lockPollInterval = 100 * time.Millisecond
+
 type SearchFlightsUseCase struct {
 	v validator.Validator
 	c cache.Cache
-	f []flightapi.FlightAPI
+	f flightapi.Resolver
+	p *CachePolicy
+	e *env.Env
+	t *PopularSearches
+
+	refreshing sync.Map
+	searches   singleflight.Group
 }
 
 func NewSearchFlightsUseCase(
 	v validator.Validator,
 	c cache.Cache,
-	f []flightapi.FlightAPI,
+	f flightapi.Resolver,
+	p *CachePolicy,
+	e *env.Env,
+	t *PopularSearches,
 ) *SearchFlightsUseCase {
 	return &SearchFlightsUseCase{
 		v: v,
 		c: c,
 		f: f,
+		p: p,
+		e: e,
+		t: t,
 	}
 }
 
 type SearchFlightsUseCaseInput struct {
-	Origin      string    `json:"origin"      validate:"required,len=3"`
-	Destination string    `json:"destination" validate:"required,len=3"`
-	Date        time.Time `json:"date"        validate:"required"`
-	SortBy      string    `json:"sort_by"     validate:"omitempty,oneof=price duration departure"`
-	SortOrder   string    `json:"sort_order"  validate:"omitempty,oneof=asc desc"`
+	Origin      string    `json:"origin"       validate:"required,len=3"`
+	Destination string    `json:"destination"  validate:"required,len=3"`
+	Date        time.Time `json:"date"         validate:"required"`
+	SortBy      string    `json:"sort_by"      validate:"omitempty,oneof=price duration departure"`
+	SortOrder   string    `json:"sort_order"   validate:"omitempty,oneof=asc desc"`
+	MaxPrice    int64     `json:"max_price"    validate:"omitempty,min=1"`
+	MaxDuration int64     `json:"max_duration" validate:"omitempty,min=1"`
+	Page        int       `json:"page"         validate:"omitempty,min=1"`
+	PageSize    int       `json:"page_size"    validate:"omitempty,min=1,max=100"`
+	// TenantID is the organization searching, empty for the default
+	// tenant.
+	TenantID string `json:"-"`
+	// Untracked searches, run on behalf of the user rather than by them,
+	// don't count towards the popular searches warmed in background.
+	Untracked bool `json:"-"`
 }
 
 type SearchFlightsUseCaseOutput struct {
-	Data []entity.Flight `json:"data,omitzero"`
+	Data []entity.Flight   `json:"data,omitzero"`
+	Meta SearchFlightsMeta `json:"meta"`
+}
+
+type SearchFlightsMeta struct {
+	CachedAt time.Time `json:"cached_at,omitzero"`
+	Stale    bool      `json:"stale"`
+	Total    int       `json:"total"`
+	Page     int       `json:"page"`
+	PageSize int       `json:"page_size"`
 }
 
+type searchFlightsCacheEntry struct {
+	Data     []entity.Flight `json:"data"`
+	CachedAt time.Time       `json:"cached_at"`
+	StaleAt  time.Time       `json:"stale_at"`
+}
+
+// searchFlightsCacheKeyVersion must be bumped whenever the cached entry
+// or the key inputs change, so that old entries are no longer read.
+const searchFlightsCacheKeyVersion = 5
+
+// searchFlightsCacheKey holds only the inputs that define which flights
+// the providers return. Sorting, filtering and paging are applied per
+// request over the cached result.
+type searchFlightsCacheKey struct {
+	Tenant        string `json:"tenant"`
+	ConfigVersion int64  `json:"config_version"`
+	Origin        string `json:"origin"`
+	Destination   string `json:"destination"`
+	Date          string `json:"date"`
+}
+
+const defaultSearchFlightsPageSize = 50
+
 func (s *SearchFlightsUseCase) Execute(
 	ctx context.Context,
 	in SearchFlightsUseCaseInput,
@@ -56,18 +127,32 @@ func (s *SearchFlightsUseCase) Execute(
 
 	in.SortBy = cmp.Or(in.SortBy, "price")
 	in.SortOrder = cmp.Or(in.SortOrder, "asc")
+	in.Page = cmp.Or(in.Page, 1)
+	in.PageSize = cmp.Or(in.PageSize, defaultSearchFlightsPageSize)
 
-	cacheKey := fmt.Sprintf(
-		"%s_%s_%s_%s_%s",
-		in.Origin,
-		in.Destination,
-		in.Date.Format(time.DateOnly),
-		in.SortBy,
-		in.SortOrder,
-	)
+	if !in.Untracked {
+		err := s.t.Track(ctx, in.TenantID, in.Origin, in.Destination, in.Date)
+		if err != nil {
+			slog.ErrorContext(
+				ctx,
+				"failed to track search flight use case",
+				"error", err,
+			)
+		}
+	}
+
+	cacheKey, err := s.cacheKey(ctx, in)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	meta := SearchFlightsMeta{
+		Page:     in.Page,
+		PageSize: in.PageSize,
+	}
 
-	out := &SearchFlightsUseCaseOutput{}
-	ok, err := s.c.Scan(ctx, cacheKey, out)
+	entry := &searchFlightsCacheEntry{}
+	ok, err := s.c.Scan(ctx, cacheKey, entry)
 	if err != nil {
 		slog.ErrorContext(
 			ctx,
@@ -76,12 +161,273 @@ func (s *SearchFlightsUseCase) Execute(
 		)
 	}
 	if ok {
-		return out, nil
+		meta.Stale = time.Now().After(entry.StaleAt)
+		if meta.Stale {
+			s.refresh(ctx, cacheKey, in, entry.CachedAt)
+		}
+	} else {
+		entry, err = s.fetch(ctx, cacheKey, in, time.Time{})
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	allFlights := []entity.Flight{}
+	meta.CachedAt = entry.CachedAt
+
+	flights := s.filterFlights(entry.Data, in)
+	s.sortFlights(flights, in.SortBy, in.SortOrder)
+	meta.Total = len(flights)
+
+	return &SearchFlightsUseCaseOutput{
+		Data: s.paginateFlights(flights, in.Page, in.PageSize),
+		Meta: meta,
+	}, nil
+}
+
+// Warm searches the providers and caches the result, unless the cached
+// search is still fresh at freshUntil. It reports whether the providers
+// were searched.
+func (s *SearchFlightsUseCase) Warm(
+	ctx context.Context,
+	in SearchFlightsUseCaseInput,
+	freshUntil time.Time,
+) (bool, error) {
+	if err := s.v.Validate(in); err != nil {
+		return false, errs.New(err)
+	}
+
+	cacheKey, err := s.cacheKey(ctx, in)
+	if err != nil {
+		return false, errs.New(err)
+	}
+
+	entry := &searchFlightsCacheEntry{}
+	ok, err := s.c.Scan(ctx, cacheKey, entry)
+	if err != nil {
+		return false, errs.New(err)
+	}
+
+	if ok && entry.StaleAt.After(freshUntil) {
+		return false, nil
+	}
+
+	if _, err := s.fetch(ctx, cacheKey, in, entry.CachedAt); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// cacheKey returns a canonical, versioned hash of the inputs that
+// define a search. The tenant, route and date are kept readable in the
+// key, so that cached searches can be listed and purged by them. The
+// configuration version of the tenant is only hashed, so that searches
+// cached with a previous configuration are still listed and purged.
+func (s *SearchFlightsUseCase) cacheKey(
+	ctx context.Context,
+	in SearchFlightsUseCaseInput,
+) (string, error) {
+	configVersion, err := s.f.ConfigVersion(ctx, in.TenantID)
+	if err != nil {
+		return "", errs.New(err)
+	}
+
+	key := searchFlightsCacheKey{
+		Tenant:        flightapi.TenantKey(in.TenantID),
+		ConfigVersion: configVersion,
+		Origin:        strings.ToUpper(in.Origin),
+		Destination:   strings.ToUpper(in.Destination),
+		Date:          in.Date.Format(time.DateOnly),
+	}
+
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", errs.New(err)
+	}
+
+	hash := sha256.Sum256(data)
+
+	return fmt.Sprintf(
+		"flight:search:v%d:%s:%s:%s:%s:%s",
+		searchFlightsCacheKeyVersion,
+		key.Tenant,
+		key.Origin,
+		key.Destination,
+		key.Date,
+		hex.EncodeToString(hash[:]),
+	), nil
+}
+
+// SearchCacheKeyPattern returns a cache key pattern matching the cached
+// searches of a tenant, route and date. Empty inputs match any value.
+func SearchCacheKeyPattern(
+	tenant, origin, destination string,
+	date time.Time,
+) string {
+	day := "*"
+	if !date.IsZero() {
+		day = date.Format(time.DateOnly)
+	}
+
+	return fmt.Sprintf(
+		"flight:search:v%d:%s:%s:%s:%s:*",
+		searchFlightsCacheKeyVersion,
+		cmp.Or(cache.EscapePattern(tenant), "*"),
+		cmp.Or(cache.EscapePattern(strings.ToUpper(origin)), "*"),
+		cmp.Or(cache.EscapePattern(strings.ToUpper(destination)), "*"),
+		day,
+	)
+}
+
+// refresh searches again in background, unless a refresh of the same
+// search is already running in this instance.
+func (s *SearchFlightsUseCase) refresh(
+	ctx context.Context,
+	cacheKey string,
+	in SearchFlightsUseCaseInput,
+	cachedAt time.Time,
+) {
+	if _, loaded := s.refreshing.LoadOrStore(cacheKey, struct{}{}); loaded {
+		return
+	}
+
+	go func() {
+		defer s.refreshing.Delete(cacheKey)
+
+		ctx := context.WithoutCancel(ctx)
+		if _, err := s.fetch(ctx, cacheKey, in, cachedAt); err != nil {
+			slog.ErrorContext(
+				ctx,
+				"failed to refresh stale search flight cache",
+				"error", err,
+			)
+		}
+	}()
+}
+
+// fetch searches the providers for entries cached after cachedAfter.
+// Concurrent fetches of the same search in this instance share a single
+// search, which is not canceled when the request that started it is.
+func (s *SearchFlightsUseCase) fetch(
+	ctx context.Context,
+	cacheKey string,
+	in SearchFlightsUseCaseInput,
+	cachedAfter time.Time,
+) (*searchFlightsCacheEntry, error) {
+	ch := s.searches.DoChan(cacheKey, func() (any, error) {
+		ctx, cancel := context.WithTimeout(
+			context.WithoutCancel(ctx),
+			searchTimeout,
+		)
+		defer cancel()
+
+		return s.lockedSearch(ctx, cacheKey, in, cachedAfter)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, errs.New(ctx.Err())
+
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*searchFlightsCacheEntry), nil
+	}
+}
+
+// lockedSearch searches the providers while holding a lock shared by
+// every instance, when SEARCH_LOCK_ENABLED is set and the cache supports
+// it. Instances that don't get the lock wait for an entry cached after
+// cachedAfter, and search by themselves if it doesn't show up in time.
+func (s *SearchFlightsUseCase) lockedSearch(
+	ctx context.Context,
+	cacheKey string,
+	in SearchFlightsUseCaseInput,
+	cachedAfter time.Time,
+) (*searchFlightsCacheEntry, error) {
+	locker, ok := s.c.(cache.Locker)
+	if !s.e.SearchLockEnabled || !ok {
+		return s.search(ctx, cacheKey, in)
+	}
+
+	lockKey := cacheKey + ":lock"
+	deadline := time.Now().Add(s.e.SearchLockWait)
+	for {
+		token, locked, err := locker.Lock(ctx, lockKey, s.e.SearchLockTTL)
+		if err != nil {
+			slog.ErrorContext(
+				ctx,
+				"failed to lock search flight use case",
+				"error", err,
+			)
+			return s.search(ctx, cacheKey, in)
+		}
+
+		if locked {
+			defer func() {
+				if err := locker.Unlock(ctx, lockKey, token); err != nil {
+					slog.ErrorContext(
+						ctx,
+						"failed to unlock search flight use case",
+						"error", err,
+					)
+				}
+			}()
+		}
+
+		// The lock holder may have cached the result since the cache
+		// was last read.
+		entry := &searchFlightsCacheEntry{}
+		ok, err := s.c.Scan(ctx, cacheKey, entry)
+		if err != nil {
+			slog.ErrorContext(
+				ctx,
+				"failed to scan cache for search flight use case",
+				"error", err,
+			)
+		}
+		if ok && entry.CachedAt.After(cachedAfter) {
+			return entry, nil
+		}
+
+		if locked || time.Now().After(deadline) {
+			return s.search(ctx, cacheKey, in)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errs.New(ctx.Err())
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
+
+// search fetches flights from every provider and caches them, unless
+// one of the providers failed or the offers already expired. Results
+// missing a provider whose budget is exhausted are cached only until
+// they are stale. A zero CachedAt means the result was not cached.
+func (s *SearchFlightsUseCase) search(
+	ctx context.Context,
+	cacheKey string,
+	in SearchFlightsUseCaseInput,
+) (*searchFlightsCacheEntry, error) {
+	apis, err := s.f.FlightAPIs(ctx, in.TenantID)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	if len(apis) == 0 {
+		return nil, errs.ErrNoFlightProviderEnabled
+	}
+
+	// Each provider caches its own results, so a failing provider only
+	// prevents the aggregated result from being cached, and the next
+	// search refetches just that provider.
+	results := make([][]entity.Flight, len(apis))
+	failed := make([]bool, len(apis))
+	exhausted := make([]bool, len(apis))
 	g := errgroup.Group{}
-	for _, api := range s.f {
+	for i, api := range apis {
 		g.Go(func() error {
 			flights, err := api.SearchFlights(
 				ctx,
@@ -89,10 +435,15 @@ func (s *SearchFlightsUseCase) Execute(
 				in.Destination,
 				in.Date,
 			)
+			if errors.Is(err, errs.ErrFlightAPIBudgetExhausted) {
+				exhausted[i] = true
+				return nil
+			}
 			if err != nil {
+				failed[i] = true
 				return err
 			}
-			allFlights = append(allFlights, flights...)
+			results[i] = flights
 			return nil
 		})
 	}
@@ -105,19 +456,33 @@ func (s *SearchFlightsUseCase) Execute(
 		)
 	}
 
+	allFlights := slices.Concat(results...)
+
 	if len(allFlights) == 0 {
 		return nil, errs.ErrSearchFlightsNotFound
 	}
 
 	s.setFastestAndCheapest(allFlights)
 
-	s.sortFlights(allFlights, in.SortBy, in.SortOrder)
-
-	out = &SearchFlightsUseCaseOutput{
+	entry := &searchFlightsCacheEntry{
 		Data: allFlights,
 	}
 
-	if err := s.c.Set(ctx, cacheKey, out, time.Second*30); err != nil {
+	if slices.Contains(failed, true) {
+		return entry, nil
+	}
+
+	ttl := s.p.TTL(ctx, in.Origin, in.Destination, in.Date, allFlights)
+	if slices.Contains(exhausted, true) {
+		ttl.Hard = ttl.Soft
+	}
+	if ttl.Hard <= 0 {
+		return entry, nil
+	}
+
+	entry.CachedAt = time.Now()
+	entry.StaleAt = entry.CachedAt.Add(ttl.Soft)
+	if err := s.c.Set(ctx, cacheKey, entry, ttl.Hard); err != nil {
 		slog.ErrorContext(
 			ctx,
 			"failed to set cache for search flight use case",
@@ -125,7 +490,7 @@ func (s *SearchFlightsUseCase) Execute(
 		)
 	}
 
-	return out, nil
+	return entry, nil
 }
 
 func (s *SearchFlightsUseCase) setFastestAndCheapest(
@@ -152,6 +517,37 @@ func (s *SearchFlightsUseCase) setFastestAndCheapest(
 	}
 }
 
+// filterFlights returns a copy of the flights matching the input
+// filters, so that the cached result is never modified.
+func (s *SearchFlightsUseCase) filterFlights(
+	flights []entity.Flight,
+	in SearchFlightsUseCaseInput,
+) []entity.Flight {
+	maxDuration := time.Duration(in.MaxDuration) * time.Minute
+
+	var filtered []entity.Flight
+	for _, flight := range flights {
+		if in.MaxPrice > 0 && flight.Price > in.MaxPrice {
+			continue
+		}
+		if maxDuration > 0 && flight.Duration > int64(maxDuration) {
+			continue
+		}
+		filtered = append(filtered, flight)
+	}
+
+	return filtered
+}
+
+func (s *SearchFlightsUseCase) paginateFlights(
+	flights []entity.Flight,
+	page, pageSize int,
+) []entity.Flight {
+	start := min((page-1)*pageSize, len(flights))
+	end := min(start+pageSize, len(flights))
+	return flights[start:end]
+}
+
 func (s *SearchFlightsUseCase) sortFlights(
 	flights []entity.Flight,
 	sortBy, sortOrder string,
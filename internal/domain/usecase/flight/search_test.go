@@ -2,11 +2,17 @@ package flight
 
 import (
 	"context"
+	"errors"
+	"path"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/danielmesquitta/flight-api/internal/config/env"
 	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
 	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/inmemorycache"
 	"github.com/danielmesquitta/flight-api/internal/provider/cache/mockcache"
 	"github.com/danielmesquitta/flight-api/internal/provider/flightapi"
 	"github.com/danielmesquitta/flight-api/internal/provider/flightapi/mockflightapi"
@@ -18,7 +24,7 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 	type fields struct {
 		v validator.Validator
 		c *mockcache.MockCache
-		f []flightapi.FlightAPI
+		f flightapi.StaticResolver
 	}
 	type Test struct {
 		name    string
@@ -34,7 +40,7 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 				Scan(context.Background(), mock.Anything, mock.Anything).
 				Return(false, nil)
 			c.EXPECT().
-				Set(context.Background(), mock.Anything, mock.Anything, mock.Anything).
+				Set(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
 			flights := []entity.Flight{
@@ -54,7 +60,7 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 
 			f := mockflightapi.NewMockFlightAPI(t)
 			f.EXPECT().
-				SearchFlights(context.Background(), "LAX", "JFK", mock.Anything).
+				SearchFlights(mock.Anything, "LAX", "JFK", mock.Anything).
 				Return(flights, nil)
 
 			wantFlights := make([]entity.Flight, len(flights))
@@ -68,7 +74,7 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 				fields: fields{
 					v: validator.New(),
 					c: c,
-					f: []flightapi.FlightAPI{
+					f: flightapi.StaticResolver{
 						f,
 					},
 				},
@@ -91,7 +97,7 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 				Scan(context.Background(), mock.Anything, mock.Anything).
 				Return(false, nil)
 			c.EXPECT().
-				Set(context.Background(), mock.Anything, mock.Anything, mock.Anything).
+				Set(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
 			flight1 := entity.Flight{
@@ -127,7 +133,7 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 
 			f := mockflightapi.NewMockFlightAPI(t)
 			f.EXPECT().
-				SearchFlights(context.Background(), "LAX", "JFK", mock.Anything).
+				SearchFlights(mock.Anything, "LAX", "JFK", mock.Anything).
 				Return(flights, nil)
 
 			flight2.IsCheapest = true
@@ -143,7 +149,7 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 				fields: fields{
 					v: validator.New(),
 					c: c,
-					f: []flightapi.FlightAPI{
+					f: flightapi.StaticResolver{
 						f,
 					},
 				},
@@ -166,7 +172,7 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 				Scan(context.Background(), mock.Anything, mock.Anything).
 				Return(false, nil)
 			c.EXPECT().
-				Set(context.Background(), mock.Anything, mock.Anything, mock.Anything).
+				Set(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
 			flight1 := entity.Flight{
@@ -202,7 +208,7 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 
 			f := mockflightapi.NewMockFlightAPI(t)
 			f.EXPECT().
-				SearchFlights(context.Background(), "LAX", "JFK", mock.Anything).
+				SearchFlights(mock.Anything, "LAX", "JFK", mock.Anything).
 				Return(flights, nil)
 
 			flight1.IsFastest = true
@@ -218,7 +224,7 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 				fields: fields{
 					v: validator.New(),
 					c: c,
-					f: []flightapi.FlightAPI{
+					f: flightapi.StaticResolver{
 						f,
 					},
 				},
@@ -241,7 +247,7 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 				Scan(context.Background(), mock.Anything, mock.Anything).
 				Return(false, nil)
 			c.EXPECT().
-				Set(context.Background(), mock.Anything, mock.Anything, mock.Anything).
+				Set(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 				Return(nil)
 
 			flight1 := entity.Flight{
@@ -277,7 +283,7 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 
 			f := mockflightapi.NewMockFlightAPI(t)
 			f.EXPECT().
-				SearchFlights(context.Background(), "LAX", "JFK", mock.Anything).
+				SearchFlights(mock.Anything, "LAX", "JFK", mock.Anything).
 				Return(flights, nil)
 
 			flight1.IsFastest = true
@@ -293,7 +299,7 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 				fields: fields{
 					v: validator.New(),
 					c: c,
-					f: []flightapi.FlightAPI{
+					f: flightapi.StaticResolver{
 						f,
 					},
 				},
@@ -314,7 +320,14 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 			c := mockcache.NewMockCache(t)
 			c.EXPECT().
 				Scan(context.Background(), mock.Anything, mock.Anything).
-				Return(true, nil)
+				RunAndReturn(
+					func(_ context.Context, _ string, value any) (bool, error) {
+						entry := value.(*searchFlightsCacheEntry)
+						entry.CachedAt = time.Now()
+						entry.StaleAt = entry.CachedAt.Add(time.Minute)
+						return true, nil
+					},
+				)
 			f := mockflightapi.NewMockFlightAPI(t)
 
 			return Test{
@@ -322,7 +335,7 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 				fields: fields{
 					v: validator.New(),
 					c: c,
-					f: []flightapi.FlightAPI{
+					f: flightapi.StaticResolver{
 						f,
 					},
 				},
@@ -337,6 +350,66 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 				wantErr: false,
 			}
 		}(),
+		func() Test {
+			c := mockcache.NewMockCache(t)
+			c.EXPECT().
+				Scan(context.Background(), mock.Anything, mock.Anything).
+				Return(false, nil)
+
+			flights := []entity.Flight{
+				{
+					ID:           "123",
+					Origin:       "LAX",
+					Destination:  "JFK",
+					Price:        100,
+					Duration:     int64(time.Hour) * 2,
+					FlightNumber: "TX 123",
+					DepartureAt:  time.Now(),
+					ArrivalAt:    time.Now().Add(time.Hour * 2),
+					IsCheapest:   false,
+					IsFastest:    false,
+				},
+			}
+
+			f1 := mockflightapi.NewMockFlightAPI(t)
+			f1.EXPECT().
+				SearchFlights(mock.Anything, "LAX", "JFK", mock.Anything).
+				Return(flights, nil)
+
+			f2 := mockflightapi.NewMockFlightAPI(t)
+			f2.EXPECT().
+				SearchFlights(mock.Anything, "LAX", "JFK", mock.Anything).
+				Return(nil, errors.New("provider unavailable"))
+
+			wantFlights := make([]entity.Flight, len(flights))
+			copy(wantFlights, flights)
+
+			wantFlights[0].IsCheapest = true
+			wantFlights[0].IsFastest = true
+
+			return Test{
+				name: "does not cache partial results",
+				fields: fields{
+					v: validator.New(),
+					c: c,
+					f: flightapi.StaticResolver{
+						f1,
+						f2,
+					},
+				},
+				args: SearchFlightsUseCaseInput{
+					Origin:      "LAX",
+					Destination: "JFK",
+					Date:        time.Now(),
+					SortBy:      "price",
+					SortOrder:   "asc",
+				},
+				want: &SearchFlightsUseCaseOutput{
+					Data: wantFlights,
+				},
+				wantErr: false,
+			}
+		}(),
 		func() Test {
 			c := mockcache.NewMockCache(t)
 			c.EXPECT().
@@ -347,7 +420,7 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 
 			f := mockflightapi.NewMockFlightAPI(t)
 			f.EXPECT().
-				SearchFlights(context.Background(), "LAX", "JFK", mock.Anything).
+				SearchFlights(mock.Anything, "LAX", "JFK", mock.Anything).
 				Return(flights, nil)
 
 			return Test{
@@ -355,7 +428,7 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 				fields: fields{
 					v: validator.New(),
 					c: c,
-					f: []flightapi.FlightAPI{
+					f: flightapi.StaticResolver{
 						f,
 					},
 				},
@@ -377,6 +450,9 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 				v: tt.fields.v,
 				c: tt.fields.c,
 				f: tt.fields.f,
+				p: newCachePolicy(),
+				e: &env.Env{},
+				t: NewPopularSearches(tt.fields.c),
 			}
 
 			got, err := s.Execute(context.Background(), tt.args)
@@ -393,3 +469,413 @@ func TestSearchFlightsUseCase_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchFlightsUseCase_Execute_Stale(t *testing.T) {
+	staleFlights := []entity.Flight{
+		{
+			ID:           "123",
+			Origin:       "LAX",
+			Destination:  "JFK",
+			Price:        100,
+			Duration:     int64(time.Hour) * 2,
+			FlightNumber: "TX 123",
+			DepartureAt:  time.Now(),
+			ArrivalAt:    time.Now().Add(time.Hour * 2),
+			IsCheapest:   true,
+			IsFastest:    true,
+		},
+	}
+	cachedAt := time.Now().Add(-2 * time.Minute)
+
+	c := mockcache.NewMockCache(t)
+	c.EXPECT().
+		Scan(context.Background(), mock.Anything, mock.Anything).
+		RunAndReturn(
+			func(_ context.Context, _ string, value any) (bool, error) {
+				entry := value.(*searchFlightsCacheEntry)
+				entry.Data = staleFlights
+				entry.CachedAt = cachedAt
+				entry.StaleAt = cachedAt.Add(time.Minute)
+				return true, nil
+			},
+		)
+
+	refreshed := make(chan struct{})
+	c.EXPECT().
+		Set(mock.Anything, mock.Anything, mock.Anything, time.Hour).
+		Run(func(context.Context, string, any, time.Duration) {
+			close(refreshed)
+		}).
+		Return(nil)
+
+	f := mockflightapi.NewMockFlightAPI(t)
+	f.EXPECT().
+		SearchFlights(mock.Anything, "LAX", "JFK", mock.Anything).
+		Return(staleFlights, nil)
+
+	s := &SearchFlightsUseCase{
+		v: validator.New(),
+		c: c,
+		f: flightapi.StaticResolver{f},
+		p: newCachePolicy(),
+		e: &env.Env{},
+		t: NewPopularSearches(c),
+	}
+
+	got, err := s.Execute(context.Background(), SearchFlightsUseCaseInput{
+		Origin:      "LAX",
+		Destination: "JFK",
+		Date:        time.Now(),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, staleFlights, got.Data)
+	assert.True(t, got.Meta.Stale)
+	assert.True(t, got.Meta.CachedAt.Equal(cachedAt))
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("stale search was not refreshed")
+	}
+}
+
+func newCachePolicy() *CachePolicy {
+	e := &env.Env{
+		InMemoryCacheMaxEntries: 100,
+		SearchCacheSoftTTL:      time.Minute,
+		SearchCacheHardTTL:      time.Hour,
+	}
+	return NewCachePolicy(e, inmemorycache.NewInMemoryCache(e))
+}
+
+func TestSearchFlightsUseCase_Execute_FilterAndPaginate(t *testing.T) {
+	c := mockcache.NewMockCache(t)
+	c.EXPECT().
+		Scan(context.Background(), mock.Anything, mock.Anything).
+		Return(false, nil)
+	c.EXPECT().
+		Set(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil)
+
+	flights := []entity.Flight{
+		{ID: "1", Price: 300, Duration: int64(time.Hour)},
+		{ID: "2", Price: 100, Duration: int64(time.Hour) * 5},
+		{ID: "3", Price: 200, Duration: int64(time.Hour) * 2},
+		{ID: "4", Price: 900, Duration: int64(time.Hour) * 2},
+	}
+
+	f := mockflightapi.NewMockFlightAPI(t)
+	f.EXPECT().
+		SearchFlights(mock.Anything, "LAX", "JFK", mock.Anything).
+		Return(flights, nil)
+
+	s := &SearchFlightsUseCase{
+		v: validator.New(),
+		c: c,
+		f: flightapi.StaticResolver{f},
+		p: newCachePolicy(),
+		e: &env.Env{},
+		t: NewPopularSearches(c),
+	}
+
+	got, err := s.Execute(context.Background(), SearchFlightsUseCaseInput{
+		Origin:      "LAX",
+		Destination: "JFK",
+		Date:        time.Now(),
+		MaxPrice:    500,
+		MaxDuration: 180,
+		Page:        2,
+		PageSize:    1,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, got.Meta.Total)
+	assert.Len(t, got.Data, 1)
+	assert.Equal(t, "1", got.Data[0].ID)
+}
+
+// versionedResolver is a resolver whose tenants are configured with the
+// given version.
+type versionedResolver struct {
+	flightapi.StaticResolver
+	version int64
+}
+
+func (v *versionedResolver) ConfigVersion(
+	context.Context,
+	string,
+) (int64, error) {
+	return v.version, nil
+}
+
+func TestSearchFlightsUseCase_cacheKey(t *testing.T) {
+	ctx := context.Background()
+	r := &versionedResolver{}
+	s := &SearchFlightsUseCase{f: r}
+	date := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	key, err := s.cacheKey(ctx, SearchFlightsUseCaseInput{
+		Origin:      "LAX",
+		Destination: "JFK",
+		Date:        date,
+		SortBy:      "price",
+	})
+	assert.Nil(t, err)
+
+	sameSearch, err := s.cacheKey(ctx, SearchFlightsUseCaseInput{
+		Origin:      "lax",
+		Destination: "jfk",
+		Date:        date.Add(time.Hour),
+		SortBy:      "duration",
+		SortOrder:   "desc",
+		MaxPrice:    100,
+	})
+	assert.Nil(t, err)
+
+	otherSearch, err := s.cacheKey(ctx, SearchFlightsUseCaseInput{
+		Origin:      "LAX",
+		Destination: "JFK",
+		Date:        date.AddDate(0, 0, 1),
+	})
+	assert.Nil(t, err)
+
+	otherTenant, err := s.cacheKey(ctx, SearchFlightsUseCaseInput{
+		Origin:      "LAX",
+		Destination: "JFK",
+		Date:        date,
+		TenantID:    "org",
+	})
+	assert.Nil(t, err)
+
+	r.version = 1
+	otherConfig, err := s.cacheKey(ctx, SearchFlightsUseCaseInput{
+		Origin:      "LAX",
+		Destination: "JFK",
+		Date:        date,
+	})
+	assert.Nil(t, err)
+
+	assert.Equal(t, key, sameSearch)
+	assert.NotEqual(t, key, otherSearch)
+	assert.NotEqual(t, key, otherTenant)
+	assert.NotEqual(t, key, otherConfig)
+	assert.True(
+		t,
+		strings.HasPrefix(key, "flight:search:v5:default:LAX:JFK:2025-01-01:"),
+	)
+
+	matched, err := path.Match(
+		SearchCacheKeyPattern("", "lax", "", date),
+		key,
+	)
+	assert.Nil(t, err)
+	assert.True(t, matched)
+
+	matched, err = path.Match(
+		SearchCacheKeyPattern("org", "lax", "", date),
+		key,
+	)
+	assert.Nil(t, err)
+	assert.False(t, matched)
+}
+
+func TestSearchFlightsUseCase_Execute_Coalesce(t *testing.T) {
+	flights := []entity.Flight{
+		{ID: "1", Price: 100, Duration: int64(time.Hour)},
+	}
+
+	c := mockcache.NewMockCache(t)
+	c.EXPECT().
+		Scan(mock.Anything, mock.Anything, mock.Anything).
+		Return(false, nil)
+	c.EXPECT().
+		Set(mock.Anything, mock.Anything, mock.Anything, time.Hour).
+		Return(nil).
+		Once()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	f := mockflightapi.NewMockFlightAPI(t)
+	f.EXPECT().
+		SearchFlights(mock.Anything, "LAX", "JFK", mock.Anything).
+		RunAndReturn(
+			func(
+				context.Context,
+				string,
+				string,
+				time.Time,
+			) ([]entity.Flight, error) {
+				close(started)
+				<-release
+				return flights, nil
+			},
+		).
+		Once()
+
+	s := &SearchFlightsUseCase{
+		v: validator.New(),
+		c: c,
+		f: flightapi.StaticResolver{f},
+		p: newCachePolicy(),
+		e: &env.Env{},
+		t: NewPopularSearches(c),
+	}
+
+	in := SearchFlightsUseCaseInput{
+		Origin:      "LAX",
+		Destination: "JFK",
+		Date:        time.Now(),
+	}
+
+	const requests = 5
+	errCh := make(chan error, requests)
+	for range requests {
+		go func() {
+			_, err := s.Execute(context.Background(), in)
+			errCh <- err
+		}()
+	}
+
+	<-started
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	for range requests {
+		assert.Nil(t, <-errCh)
+	}
+}
+
+type lockingCache struct {
+	*mockcache.MockCache
+	*mockcache.MockLocker
+}
+
+func TestSearchFlightsUseCase_Execute_WaitForLock(t *testing.T) {
+	flights := []entity.Flight{
+		{ID: "1", Price: 100, Duration: int64(time.Hour)},
+	}
+
+	scans := 0
+	c := mockcache.NewMockCache(t)
+	c.EXPECT().
+		Scan(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(
+			func(_ context.Context, _ string, value any) (bool, error) {
+				scans++
+				if scans < 3 {
+					return false, nil
+				}
+				entry := value.(*searchFlightsCacheEntry)
+				entry.Data = flights
+				entry.CachedAt = time.Now()
+				entry.StaleAt = entry.CachedAt.Add(time.Minute)
+				return true, nil
+			},
+		)
+
+	l := mockcache.NewMockLocker(t)
+	l.EXPECT().
+		Lock(mock.Anything, mock.Anything, time.Minute).
+		Return("", false, nil)
+
+	s := &SearchFlightsUseCase{
+		v: validator.New(),
+		c: lockingCache{MockCache: c, MockLocker: l},
+		f: flightapi.StaticResolver{mockflightapi.NewMockFlightAPI(t)},
+		p: newCachePolicy(),
+		e: &env.Env{
+			SearchLockEnabled: true,
+			SearchLockTTL:     time.Minute,
+			SearchLockWait:    time.Second,
+		},
+		t: NewPopularSearches(c),
+	}
+
+	got, err := s.Execute(context.Background(), SearchFlightsUseCaseInput{
+		Origin:      "LAX",
+		Destination: "JFK",
+		Date:        time.Now(),
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, flights, got.Data)
+	assert.Equal(t, 3, scans)
+}
+
+func TestSearchFlightsUseCase_Execute_Untracked(t *testing.T) {
+	ctx := context.Background()
+	e := &env.Env{InMemoryCacheMaxEntries: 100}
+	c := inmemorycache.NewInMemoryCache(e)
+
+	f := mockflightapi.NewMockFlightAPI(t)
+	f.EXPECT().
+		SearchFlights(mock.Anything, "LAX", "JFK", mock.Anything).
+		Return([]entity.Flight{{ID: "1", Price: 100}}, nil)
+
+	ps := NewPopularSearches(c)
+	s := &SearchFlightsUseCase{
+		v: validator.New(),
+		c: c,
+		f: flightapi.StaticResolver{f},
+		p: newCachePolicy(),
+		e: &env.Env{},
+		t: ps,
+	}
+
+	_, err := s.Execute(ctx, SearchFlightsUseCaseInput{
+		Origin:      "LAX",
+		Destination: "JFK",
+		Date:        time.Now().AddDate(0, 0, 1),
+		Untracked:   true,
+	})
+	assert.Nil(t, err)
+
+	top, err := ps.Top(ctx, 10)
+	assert.Nil(t, err)
+	assert.Empty(t, top)
+}
+
+func TestSearchFlightsUseCase_Execute_BudgetExhausted(t *testing.T) {
+	flights := []entity.Flight{{ID: "1", Price: 100}}
+
+	c := mockcache.NewMockCache(t)
+	c.EXPECT().
+		Scan(mock.Anything, mock.Anything, mock.Anything).
+		Return(false, nil)
+
+	// Results missing a provider are cached for the soft TTL only.
+	c.EXPECT().
+		Set(mock.Anything, mock.Anything, mock.Anything, time.Minute).
+		Return(nil)
+
+	f := mockflightapi.NewMockFlightAPI(t)
+	f.EXPECT().
+		SearchFlights(mock.Anything, "LAX", "JFK", mock.Anything).
+		Return(flights, nil)
+
+	exhausted := mockflightapi.NewMockFlightAPI(t)
+	exhausted.EXPECT().
+		SearchFlights(mock.Anything, "LAX", "JFK", mock.Anything).
+		Return(nil, errs.ErrFlightAPIBudgetExhausted)
+
+	s := &SearchFlightsUseCase{
+		v: validator.New(),
+		c: c,
+		f: flightapi.StaticResolver{f, exhausted},
+		p: newCachePolicy(),
+		e: &env.Env{},
+		t: NewPopularSearches(c),
+	}
+
+	got, err := s.Execute(context.Background(), SearchFlightsUseCaseInput{
+		Origin:      "LAX",
+		Destination: "JFK",
+		Date:        time.Now().AddDate(0, 0, 1),
+		Untracked:   true,
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, got.Data, 1)
+	assert.False(t, got.Meta.CachedAt.IsZero())
+}
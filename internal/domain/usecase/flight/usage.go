@@ -0,0 +1,56 @@
+package flight
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/flightapi"
+)
+
+type ListProvidersUsageUseCase struct {
+	m *flightapi.Meter
+}
+
+func NewListProvidersUsageUseCase(
+	m *flightapi.Meter,
+) *ListProvidersUsageUseCase {
+	return &ListProvidersUsageUseCase{
+		m: m,
+	}
+}
+
+type ListProvidersUsageUseCaseInput struct {
+	Date time.Time `json:"date"`
+	// TenantID is the organization to list the usage of, empty for the
+	// default tenant.
+	TenantID string `json:"organization_id"`
+}
+
+type ListProvidersUsageUseCaseOutput struct {
+	Data []flightapi.Usage `json:"data"`
+}
+
+func (l *ListProvidersUsageUseCase) Execute(
+	ctx context.Context,
+	in ListProvidersUsageUseCaseInput,
+) (*ListProvidersUsageUseCaseOutput, error) {
+	if in.Date.IsZero() {
+		in.Date = time.Now()
+	}
+
+	providers := l.m.Providers()
+	out := &ListProvidersUsageUseCaseOutput{
+		Data: make([]flightapi.Usage, 0, len(providers)),
+	}
+
+	for _, p := range providers {
+		usage, err := l.m.Usage(ctx, in.TenantID, p, in.Date)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+		out.Data = append(out.Data, *usage)
+	}
+
+	return out, nil
+}
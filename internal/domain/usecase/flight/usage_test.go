@@ -0,0 +1,50 @@
+package flight
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/mockcache"
+	"github.com/danielmesquitta/flight-api/internal/provider/flightapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListProvidersUsageUseCase_Execute(t *testing.T) {
+	c := mockcache.NewMockCache(t)
+	c.EXPECT().
+		Scan(context.Background(), mock.Anything, mock.Anything).
+		RunAndReturn(
+			func(_ context.Context, key string, value any) (bool, error) {
+				if key == "flightapi:usage:default:serp:2025-01-01:calls" {
+					*value.(*int64) = 100
+					return true, nil
+				}
+				return false, nil
+			},
+		)
+
+	e := &env.Env{SerpAPIDailyCallLimit: 100}
+	l := NewListProvidersUsageUseCase(flightapi.NewMeter(e, c))
+
+	got, err := l.Execute(
+		context.Background(),
+		ListProvidersUsageUseCaseInput{
+			Date: time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local),
+		},
+	)
+
+	assert.Nil(t, err)
+	assert.Len(t, got.Data, 3)
+	for _, usage := range got.Data {
+		assert.Equal(t, "2025-01-01", usage.Date)
+		if usage.Provider == flightapi.ProviderSerp {
+			assert.Equal(t, int64(100), usage.Calls)
+			assert.True(t, usage.Exhausted)
+			continue
+		}
+		assert.False(t, usage.Exhausted)
+	}
+}
@@ -0,0 +1,198 @@
+package flight
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+	"github.com/danielmesquitta/flight-api/internal/provider/flightapi"
+)
+
+const (
+	cacheWarmerLockKey    = "flight:warmer:lock"
+	cacheWarmerLastRunKey = "flight:warmer:last-run"
+)
+
+type CacheWarmerRun struct {
+	StartedAt    time.Time `json:"started_at"`
+	FinishedAt   time.Time `json:"finished_at"`
+	Searches     int       `json:"searches"`
+	Warmed       int       `json:"warmed"`
+	Fresh        int       `json:"fresh"`
+	Failed       int       `json:"failed"`
+	QuotaReached bool      `json:"quota_reached"`
+}
+
+type CacheWarmerStatus struct {
+	Enabled        bool            `json:"enabled"`
+	Interval       string          `json:"interval"`
+	TopN           int             `json:"top_n"`
+	MaxBudgetUsage int64           `json:"max_budget_usage"`
+	NextRunAt      time.Time       `json:"next_run_at,omitzero"`
+	LastRun        *CacheWarmerRun `json:"last_run,omitempty"`
+}
+
+// CacheWarmer periodically refreshes the cached results of the most
+// popular searches, before they go stale.
+type CacheWarmer struct {
+	e  *env.Env
+	c  cache.Cache
+	m  *flightapi.Meter
+	s  *SearchFlightsUseCase
+	ps *PopularSearches
+}
+
+func NewCacheWarmer(
+	e *env.Env,
+	c cache.Cache,
+	m *flightapi.Meter,
+	s *SearchFlightsUseCase,
+	ps *PopularSearches,
+) *CacheWarmer {
+	return &CacheWarmer{
+		e:  e,
+		c:  c,
+		m:  m,
+		s:  s,
+		ps: ps,
+	}
+}
+
+// Start runs the warmer every CACHE_WARMER_INTERVAL until ctx is done.
+// It returns right away if the warmer is disabled.
+func (w *CacheWarmer) Start(ctx context.Context) {
+	if !w.e.CacheWarmerEnabled {
+		return
+	}
+
+	ticker := time.NewTicker(w.e.CacheWarmerInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.Run(ctx); err != nil {
+			slog.ErrorContext(ctx, "failed to warm search cache", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run warms the cache once, unless another instance already did it in
+// the current interval, in which case it returns a nil run.
+func (w *CacheWarmer) Run(ctx context.Context) (*CacheWarmerRun, error) {
+	// The lock is never released, it expires shortly before the next
+	// run, so that a single instance runs per interval.
+	if locker, ok := w.c.(cache.Locker); ok {
+		_, locked, err := locker.Lock(
+			ctx,
+			cacheWarmerLockKey,
+			w.e.CacheWarmerInterval*9/10,
+		)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+		if !locked {
+			return nil, nil
+		}
+	}
+
+	run := &CacheWarmerRun{
+		StartedAt: time.Now(),
+	}
+
+	searches, err := w.ps.Top(ctx, w.e.CacheWarmerTopN)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	run.Searches = len(searches)
+
+	// Searches are refreshed if they would go stale before the next run.
+	freshUntil := run.StartedAt.Add(w.e.CacheWarmerInterval)
+	for _, search := range searches {
+		// Each tenant has its own budget, so reaching one only skips the
+		// searches of that tenant.
+		ok, err := w.m.WithinBudget(
+			ctx,
+			search.TenantID,
+			w.e.CacheWarmerMaxBudgetUsage,
+		)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+		if !ok {
+			run.QuotaReached = true
+			continue
+		}
+
+		warmed, err := w.s.Warm(ctx, SearchFlightsUseCaseInput{
+			Origin:      search.Origin,
+			Destination: search.Destination,
+			Date:        search.Date,
+			TenantID:    search.TenantID,
+		}, freshUntil)
+
+		switch {
+		case err != nil:
+			run.Failed++
+			slog.ErrorContext(
+				ctx,
+				"failed to warm search",
+				"tenant_id", search.TenantID,
+				"origin", search.Origin,
+				"destination", search.Destination,
+				"date", search.Date.Format(time.DateOnly),
+				"error", err,
+			)
+
+		case warmed:
+			run.Warmed++
+
+		default:
+			run.Fresh++
+		}
+	}
+
+	run.FinishedAt = time.Now()
+
+	if err := w.c.Set(ctx, cacheWarmerLastRunKey, run, 0); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return run, nil
+}
+
+// Status returns the schedule of the warmer and its last run in any
+// instance.
+func (w *CacheWarmer) Status(
+	ctx context.Context,
+) (*CacheWarmerStatus, error) {
+	status := &CacheWarmerStatus{
+		Enabled:        w.e.CacheWarmerEnabled,
+		Interval:       w.e.CacheWarmerInterval.String(),
+		TopN:           w.e.CacheWarmerTopN,
+		MaxBudgetUsage: w.e.CacheWarmerMaxBudgetUsage,
+	}
+
+	run := &CacheWarmerRun{}
+	ok, err := w.c.Scan(ctx, cacheWarmerLastRunKey, run)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	if !ok {
+		return status, nil
+	}
+
+	status.LastRun = run
+	if status.Enabled {
+		status.NextRunAt = run.StartedAt.Add(w.e.CacheWarmerInterval)
+	}
+
+	return status, nil
+}
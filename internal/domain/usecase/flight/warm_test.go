@@ -0,0 +1,133 @@
+package flight
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/inmemorycache"
+	"github.com/danielmesquitta/flight-api/internal/provider/flightapi"
+	"github.com/danielmesquitta/flight-api/internal/provider/flightapi/mockflightapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCacheWarmer_Run(t *testing.T) {
+	ctx := context.Background()
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	type Test struct {
+		name             string
+		e                *env.Env
+		calls            int64
+		wantWarmed       int
+		wantQuotaReached bool
+	}
+	tests := []Test{
+		{
+			name: "warms popular searches",
+			e: &env.Env{
+				InMemoryCacheMaxEntries:   100,
+				CacheWarmerInterval:       time.Minute,
+				CacheWarmerTopN:           1,
+				CacheWarmerMaxBudgetUsage: 80,
+			},
+			wantWarmed: 1,
+		},
+		{
+			name: "stops when a provider budget is almost used",
+			e: &env.Env{
+				InMemoryCacheMaxEntries:   100,
+				CacheWarmerInterval:       time.Minute,
+				CacheWarmerTopN:           1,
+				CacheWarmerMaxBudgetUsage: 80,
+				AmadeusAPIDailyCallLimit:  10,
+			},
+			calls:            8,
+			wantQuotaReached: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := inmemorycache.NewInMemoryCache(tt.e)
+			m := flightapi.NewMeter(tt.e, c)
+			for range tt.calls {
+				ok, err := m.Reserve(ctx, "", flightapi.ProviderAmadeus)
+				assert.Nil(t, err)
+				assert.True(t, ok)
+			}
+
+			f := mockflightapi.NewMockFlightAPI(t)
+			if tt.wantWarmed > 0 {
+				f.EXPECT().
+					SearchFlights(mock.Anything, "GRU", "JFK", mock.Anything).
+					Return([]entity.Flight{{ID: "1", Price: 100}}, nil).
+					Once()
+			}
+
+			ps := NewPopularSearches(c)
+			s := NewSearchFlightsUseCase(
+				validator.New(),
+				c,
+				flightapi.StaticResolver{f},
+				newCachePolicy(),
+				tt.e,
+				ps,
+			)
+			w := NewCacheWarmer(tt.e, c, m, s, ps)
+
+			assert.Nil(t, ps.Track(ctx, "", "gru", "jfk", tomorrow))
+			assert.Nil(t, ps.Track(ctx, "", "GRU", "JFK", tomorrow))
+			assert.Nil(t, ps.Track(ctx, "", "LAX", "JFK", tomorrow))
+			assert.Nil(t, ps.Track(ctx, "", "LAX", "JFK", yesterday))
+
+			run, err := w.Run(ctx)
+			assert.Nil(t, err)
+			assert.Equal(t, 1, run.Searches)
+			assert.Equal(t, tt.wantWarmed, run.Warmed)
+			assert.Equal(t, tt.wantQuotaReached, run.QuotaReached)
+
+			run, err = w.Run(ctx)
+			assert.Nil(t, err)
+			assert.Nil(t, run, "should run once per interval")
+
+			status, err := w.Status(ctx)
+			assert.Nil(t, err)
+			assert.NotNil(t, status.LastRun)
+			assert.Equal(t, tt.wantWarmed, status.LastRun.Warmed)
+		})
+	}
+}
+
+func TestPopularSearches_Top(t *testing.T) {
+	ctx := context.Background()
+	c := inmemorycache.NewInMemoryCache(&env.Env{InMemoryCacheMaxEntries: 10})
+	ps := NewPopularSearches(c)
+
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	assert.Nil(t, ps.Track(ctx, "", "LAX", "JFK", tomorrow))
+	assert.Nil(t, ps.Track(ctx, "", "GRU", "JFK", tomorrow))
+	assert.Nil(t, ps.Track(ctx, "", "GRU", "JFK", tomorrow))
+	assert.Nil(t, ps.Track(ctx, "", "GRU", "LIS", yesterday))
+	assert.Nil(t, ps.Track(ctx, "", "GRU", "LIS", yesterday))
+	assert.Nil(t, ps.Track(ctx, "", "GRU", "LIS", yesterday))
+	assert.Nil(t, ps.Track(ctx, "org", "LAX", "JFK", tomorrow))
+
+	top, err := ps.Top(ctx, 10)
+	assert.Nil(t, err)
+	assert.Len(t, top, 3, "past dates should be skipped")
+	assert.Equal(t, "GRU", top[0].Origin)
+	assert.Equal(t, "JFK", top[0].Destination)
+	assert.Equal(t, int64(2), top[0].Searches)
+	assert.Equal(t, "", top[0].TenantID)
+	assert.Equal(t, "LAX", top[1].Origin)
+	assert.Equal(t, "", top[1].TenantID)
+	assert.Equal(t, "LAX", top[2].Origin)
+	assert.Equal(t, "org", top[2].TenantID)
+}
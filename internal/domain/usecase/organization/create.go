@@ -0,0 +1,62 @@
+package organization
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	"github.com/google/uuid"
+)
+
+type CreateOrganizationUseCase struct {
+	v validator.Validator
+	r repo.OrganizationRepository
+}
+
+func NewCreateOrganizationUseCase(
+	v validator.Validator,
+	r repo.OrganizationRepository,
+) *CreateOrganizationUseCase {
+	return &CreateOrganizationUseCase{
+		v: v,
+		r: r,
+	}
+}
+
+type CreateOrganizationUseCaseInput struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+type CreateOrganizationUseCaseOutput struct {
+	Organization entity.Organization `json:"organization"`
+}
+
+// Execute creates an organization without providers, so that its users
+// can't search until a provider is configured for it.
+func (c *CreateOrganizationUseCase) Execute(
+	ctx context.Context,
+	in CreateOrganizationUseCaseInput,
+) (*CreateOrganizationUseCaseOutput, error) {
+	in.Name = strings.TrimSpace(in.Name)
+	if err := c.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	now := time.Now()
+	org := entity.Organization{
+		ID:        uuid.NewString(),
+		Name:      in.Name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := c.r.CreateOrganization(ctx, org); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &CreateOrganizationUseCaseOutput{Organization: org}, nil
+}
@@ -0,0 +1,36 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+type ListOrganizationsUseCase struct {
+	r repo.OrganizationRepository
+}
+
+func NewListOrganizationsUseCase(
+	r repo.OrganizationRepository,
+) *ListOrganizationsUseCase {
+	return &ListOrganizationsUseCase{
+		r: r,
+	}
+}
+
+type ListOrganizationsUseCaseOutput struct {
+	Data []entity.Organization `json:"data"`
+}
+
+func (l *ListOrganizationsUseCase) Execute(
+	ctx context.Context,
+) (*ListOrganizationsUseCaseOutput, error) {
+	orgs, err := l.r.ListOrganizations(ctx)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &ListOrganizationsUseCaseOutput{Data: orgs}, nil
+}
@@ -0,0 +1,126 @@
+package organization
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/secretbox"
+	"github.com/danielmesquitta/flight-api/internal/provider/flightapi"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+// TenantConfigs loads the providers enabled for each organization, with
+// their credentials decrypted, so that its searches use its own
+// contracts.
+type TenantConfigs struct {
+	b *secretbox.Box
+	r repo.OrganizationRepository
+}
+
+// NewTenantConfigs panics if there are organizations but no key to open
+// their credentials with.
+func NewTenantConfigs(
+	b *secretbox.Box,
+	r repo.OrganizationRepository,
+) *TenantConfigs {
+	if !b.Configured() {
+		orgs, err := r.ListOrganizations(context.Background())
+		if err != nil {
+			panic(err)
+		}
+		if len(orgs) > 0 {
+			panic(errs.New(
+				"PROVIDER_CREDENTIALS_KEY is required by the organizations",
+			))
+		}
+	}
+
+	return &TenantConfigs{
+		b: b,
+		r: r,
+	}
+}
+
+func (t *TenantConfigs) LoadTenantConfig(
+	ctx context.Context,
+	tenantID string,
+) (*flightapi.TenantConfig, error) {
+	providers, err := t.r.ListOrganizationProviders(ctx, tenantID)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	config := &flightapi.TenantConfig{
+		Providers: map[flightapi.Provider]flightapi.Credentials{},
+	}
+	for _, p := range providers {
+		if !p.Enabled {
+			continue
+		}
+
+		credentials, err := openCredentials(
+			t.b,
+			p.OrganizationID,
+			p.Provider,
+			p.Credentials,
+		)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+
+		config.Providers[flightapi.Provider(p.Provider)] = *credentials
+	}
+
+	return config, nil
+}
+
+// credentialsAdditionalData binds sealed credentials to the organization
+// and provider, so that they can't be swapped with those of another.
+func credentialsAdditionalData(organizationID, provider string) []byte {
+	return []byte(organizationID + ":" + provider)
+}
+
+func sealCredentials(
+	b *secretbox.Box,
+	organizationID, provider string,
+	credentials flightapi.Credentials,
+) ([]byte, error) {
+	data, err := json.Marshal(credentials)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	sealed, err := b.Seal(
+		data,
+		credentialsAdditionalData(organizationID, provider),
+	)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return sealed, nil
+}
+
+func openCredentials(
+	b *secretbox.Box,
+	organizationID, provider string,
+	sealed []byte,
+) (*flightapi.Credentials, error) {
+	data, err := b.Open(
+		sealed,
+		credentialsAdditionalData(organizationID, provider),
+	)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	credentials := &flightapi.Credentials{}
+	if err := json.Unmarshal(data, credentials); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return credentials, nil
+}
+
+var _ flightapi.TenantConfigLoader = (*TenantConfigs)(nil)
@@ -0,0 +1,172 @@
+package organization
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/secretbox"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/flightapi"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+type ListOrganizationProvidersUseCase struct {
+	v validator.Validator
+	r repo.OrganizationRepository
+}
+
+func NewListOrganizationProvidersUseCase(
+	v validator.Validator,
+	r repo.OrganizationRepository,
+) *ListOrganizationProvidersUseCase {
+	return &ListOrganizationProvidersUseCase{
+		v: v,
+		r: r,
+	}
+}
+
+type ListOrganizationProvidersUseCaseInput struct {
+	OrganizationID string `json:"-" validate:"required"`
+}
+
+type ListOrganizationProvidersUseCaseOutput struct {
+	Data []entity.OrganizationProvider `json:"data"`
+}
+
+func (l *ListOrganizationProvidersUseCase) Execute(
+	ctx context.Context,
+	in ListOrganizationProvidersUseCaseInput,
+) (*ListOrganizationProvidersUseCaseOutput, error) {
+	if err := l.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	if _, err := l.r.GetOrganizationByID(ctx, in.OrganizationID); err != nil {
+		return nil, errs.New(err)
+	}
+
+	providers, err := l.r.ListOrganizationProviders(ctx, in.OrganizationID)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &ListOrganizationProvidersUseCaseOutput{Data: providers}, nil
+}
+
+type ConfigureProviderUseCase struct {
+	v validator.Validator
+	b *secretbox.Box
+	r repo.OrganizationRepository
+	t *flightapi.TenantClients
+}
+
+func NewConfigureProviderUseCase(
+	v validator.Validator,
+	b *secretbox.Box,
+	r repo.OrganizationRepository,
+	t *flightapi.TenantClients,
+) *ConfigureProviderUseCase {
+	return &ConfigureProviderUseCase{
+		v: v,
+		b: b,
+		r: r,
+		t: t,
+	}
+}
+
+type ConfigureProviderUseCaseInput struct {
+	OrganizationID string `json:"-"          validate:"required"`
+	Provider       string `json:"-"          validate:"required,oneof=amadeus serp duffel"`
+	Enabled        bool   `json:"enabled"`
+	APIKey         string `json:"api_key"`
+	APISecret      string `json:"api_secret"`
+}
+
+type ConfigureProviderUseCaseOutput struct {
+	Provider entity.OrganizationProvider `json:"provider"`
+}
+
+// Execute enables or disables a provider for the organization. Without
+// an API key, the credentials stored for the provider are kept, so that
+// it can be toggled without sending them again. The organization
+// searches with the new configuration right away, on every instance, and
+// results cached with the previous one are no longer read.
+func (c *ConfigureProviderUseCase) Execute(
+	ctx context.Context,
+	in ConfigureProviderUseCaseInput,
+) (*ConfigureProviderUseCaseOutput, error) {
+	if err := c.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	if _, err := c.r.GetOrganizationByID(ctx, in.OrganizationID); err != nil {
+		return nil, errs.New(err)
+	}
+
+	credentials, err := c.credentials(ctx, in)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	if in.Enabled && len(credentials) == 0 {
+		return nil, errs.ErrOrganizationProviderCredentialsMissing
+	}
+
+	provider := entity.OrganizationProvider{
+		OrganizationID: in.OrganizationID,
+		Provider:       in.Provider,
+		Enabled:        in.Enabled,
+		Credentials:    credentials,
+		UpdatedAt:      time.Now(),
+	}
+
+	if err := c.r.SaveOrganizationProvider(ctx, provider); err != nil {
+		return nil, errs.New(err)
+	}
+
+	if err := c.t.Invalidate(ctx, in.OrganizationID); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &ConfigureProviderUseCaseOutput{Provider: provider}, nil
+}
+
+// credentials returns the sealed credentials of the input, or the ones
+// stored for the provider if no API key was given.
+func (c *ConfigureProviderUseCase) credentials(
+	ctx context.Context,
+	in ConfigureProviderUseCaseInput,
+) ([]byte, error) {
+	if in.APIKey != "" {
+		// Amadeus authenticates with a key and a secret, the others with
+		// just a key.
+		if flightapi.Provider(in.Provider) == flightapi.ProviderAmadeus &&
+			in.APISecret == "" {
+			return nil, errs.ErrOrganizationProviderCredentialsMissing
+		}
+
+		return sealCredentials(
+			c.b,
+			in.OrganizationID,
+			in.Provider,
+			flightapi.Credentials{
+				APIKey:    in.APIKey,
+				APISecret: in.APISecret,
+			},
+		)
+	}
+
+	providers, err := c.r.ListOrganizationProviders(ctx, in.OrganizationID)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	for _, p := range providers {
+		if p.Provider == in.Provider {
+			return p.Credentials, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,163 @@
+package organization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/secretbox"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/inmemorycache"
+	"github.com/danielmesquitta/flight-api/internal/provider/flightapi"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/inmemoryrepo"
+	"github.com/stretchr/testify/assert"
+)
+
+const testKey = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+func codeOf(err error) errs.Code {
+	return errs.New(err).Code
+}
+
+func TestConfigureProviderUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	r := inmemoryrepo.NewInMemoryRepository()
+	b := secretbox.New(&env.Env{ProviderCredentialsKey: testKey})
+	e := &env.Env{}
+
+	org := entity.Organization{ID: "org", Name: "Agency"}
+	assert.Nil(t, r.CreateOrganization(ctx, org))
+
+	clients := flightapi.NewTenantClients(
+		e,
+		inmemorycache.NewInMemoryCache(e),
+		nil,
+		NewTenantConfigs(b, r),
+	)
+	c := NewConfigureProviderUseCase(validator.New(), b, r, clients)
+	tc := NewTenantConfigs(b, r)
+
+	type Test struct {
+		name    string
+		args    ConfigureProviderUseCaseInput
+		want    map[flightapi.Provider]flightapi.Credentials
+		wantErr error
+	}
+	tests := []Test{
+		{
+			name: "fails to enable a provider without credentials",
+			args: ConfigureProviderUseCaseInput{
+				OrganizationID: "org",
+				Provider:       "duffel",
+				Enabled:        true,
+			},
+			wantErr: errs.ErrOrganizationProviderCredentialsMissing,
+		},
+		{
+			name: "fails to enable amadeus without a secret",
+			args: ConfigureProviderUseCaseInput{
+				OrganizationID: "org",
+				Provider:       "amadeus",
+				Enabled:        true,
+				APIKey:         "key",
+			},
+			wantErr: errs.ErrOrganizationProviderCredentialsMissing,
+		},
+		{
+			name: "enables a provider",
+			args: ConfigureProviderUseCaseInput{
+				OrganizationID: "org",
+				Provider:       "duffel",
+				Enabled:        true,
+				APIKey:         "duffel-key",
+			},
+			want: map[flightapi.Provider]flightapi.Credentials{
+				flightapi.ProviderDuffel: {APIKey: "duffel-key"},
+			},
+		},
+		{
+			name: "disables a provider keeping its credentials",
+			args: ConfigureProviderUseCaseInput{
+				OrganizationID: "org",
+				Provider:       "duffel",
+			},
+			want: map[flightapi.Provider]flightapi.Credentials{},
+		},
+		{
+			name: "enables a provider again with its stored credentials",
+			args: ConfigureProviderUseCaseInput{
+				OrganizationID: "org",
+				Provider:       "duffel",
+				Enabled:        true,
+			},
+			want: map[flightapi.Provider]flightapi.Credentials{
+				flightapi.ProviderDuffel: {APIKey: "duffel-key"},
+			},
+		},
+		{
+			name: "fails with unknown provider",
+			args: ConfigureProviderUseCaseInput{
+				OrganizationID: "org",
+				Provider:       "skyscanner",
+				APIKey:         "key",
+			},
+			wantErr: errs.New("", errs.ErrCodeValidation),
+		},
+		{
+			name: "fails with unknown organization",
+			args: ConfigureProviderUseCaseInput{
+				OrganizationID: "other",
+				Provider:       "duffel",
+				APIKey:         "key",
+			},
+			wantErr: errs.ErrOrganizationNotFound,
+		},
+	}
+	var version int64
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.Execute(ctx, tt.args)
+
+			if tt.wantErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, codeOf(tt.wantErr), codeOf(err))
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.args.Enabled, got.Provider.Enabled)
+			assert.NotContains(t, string(got.Provider.Credentials), "key")
+
+			config, err := tc.LoadTenantConfig(ctx, "org")
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, config.Providers)
+
+			before := version
+			version, err = clients.ConfigVersion(ctx, "org")
+			assert.Nil(t, err)
+			assert.Greater(t, version, before, "version should be bumped")
+		})
+	}
+}
+
+func TestNewTenantConfigs(t *testing.T) {
+	ctx := context.Background()
+	r := inmemoryrepo.NewInMemoryRepository()
+	b := secretbox.New(&env.Env{})
+
+	assert.NotPanics(t, func() { NewTenantConfigs(b, r) })
+
+	org := entity.Organization{ID: "org", Name: "Agency"}
+	assert.Nil(t, r.CreateOrganization(ctx, org))
+
+	assert.Panics(t, func() { NewTenantConfigs(b, r) })
+	assert.NotPanics(t, func() {
+		NewTenantConfigs(
+			secretbox.New(&env.Env{ProviderCredentialsKey: testKey}),
+			r,
+		)
+	})
+}
@@ -0,0 +1,74 @@
+package organization
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+type UpdateUserOrganizationUseCase struct {
+	v validator.Validator
+	u repo.UserRepository
+	r repo.OrganizationRepository
+}
+
+func NewUpdateUserOrganizationUseCase(
+	v validator.Validator,
+	u repo.UserRepository,
+	r repo.OrganizationRepository,
+) *UpdateUserOrganizationUseCase {
+	return &UpdateUserOrganizationUseCase{
+		v: v,
+		u: u,
+		r: r,
+	}
+}
+
+type UpdateUserOrganizationUseCaseInput struct {
+	UserID         string `json:"-"               validate:"required"`
+	OrganizationID string `json:"organization_id"`
+}
+
+type UpdateUserOrganizationUseCaseOutput struct {
+	User entity.User `json:"user"`
+}
+
+// Execute moves the user to the organization, or back to the default
+// tenant if none is given. Access tokens keep the tenant they were
+// issued with until they expire, and refreshed ones get the new one.
+func (u *UpdateUserOrganizationUseCase) Execute(
+	ctx context.Context,
+	in UpdateUserOrganizationUseCaseInput,
+) (*UpdateUserOrganizationUseCaseOutput, error) {
+	if err := u.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	if in.OrganizationID != "" {
+		_, err := u.r.GetOrganizationByID(ctx, in.OrganizationID)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+	}
+
+	err := u.u.UpdateUserOrganization(
+		ctx,
+		in.UserID,
+		in.OrganizationID,
+		time.Now(),
+	)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	user, err := u.u.GetUserByID(ctx, in.UserID)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &UpdateUserOrganizationUseCaseOutput{User: *user}, nil
+}
@@ -0,0 +1,93 @@
+// Package pricealert manages the routes and dates users watch for
+// price drops, and the watcher that checks them in background.
+package pricealert
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	"github.com/google/uuid"
+)
+
+type CreatePriceAlertUseCase struct {
+	v validator.Validator
+	e *env.Env
+	r repo.PriceAlertRepository
+}
+
+func NewCreatePriceAlertUseCase(
+	v validator.Validator,
+	e *env.Env,
+	r repo.PriceAlertRepository,
+) *CreatePriceAlertUseCase {
+	return &CreatePriceAlertUseCase{
+		v: v,
+		e: e,
+		r: r,
+	}
+}
+
+type CreatePriceAlertUseCaseInput struct {
+	UserID      string `json:"-"            validate:"required"`
+	Origin      string `json:"origin"       validate:"required,len=3"`
+	Destination string `json:"destination"  validate:"required,len=3"`
+	Date        string `json:"date"         validate:"required,datetime=2006-01-02"`
+	// TargetPrice is the price, in cents, to notify below. Either it or
+	// the drop percent is required.
+	TargetPrice int64 `json:"target_price" validate:"required_without=DropPercent,omitempty,min=1"`
+	// DropPercent is how much the price must drop from the first price
+	// found, or the last one notified, to notify.
+	DropPercent int64 `json:"drop_percent" validate:"omitempty,min=1,max=99"`
+}
+
+type CreatePriceAlertUseCaseOutput struct {
+	PriceAlert entity.PriceAlert `json:"price_alert"`
+}
+
+// Execute creates an active alert, unless the user already has the max
+// active alerts.
+func (c *CreatePriceAlertUseCase) Execute(
+	ctx context.Context,
+	in CreatePriceAlertUseCaseInput,
+) (*CreatePriceAlertUseCaseOutput, error) {
+	if err := c.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	date, err := time.Parse(time.DateOnly, in.Date)
+	if err != nil {
+		return nil, errs.New(err, errs.ErrCodeValidation)
+	}
+
+	now := time.Now()
+	today := now.UTC().Truncate(24 * time.Hour)
+	if date.Before(today) {
+		return nil, errs.ErrPriceAlertDateInPast
+	}
+
+	alert := entity.PriceAlert{
+		ID:          uuid.NewString(),
+		UserID:      in.UserID,
+		Origin:      strings.ToUpper(in.Origin),
+		Destination: strings.ToUpper(in.Destination),
+		Date:        date,
+		TargetPrice: in.TargetPrice,
+		DropPercent: in.DropPercent,
+		Active:      true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	err = c.r.CreatePriceAlert(ctx, alert, c.e.PriceAlertMaxPerUser)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &CreatePriceAlertUseCaseOutput{PriceAlert: alert}, nil
+}
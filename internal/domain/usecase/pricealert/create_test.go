@@ -0,0 +1,120 @@
+package pricealert
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/mockrepo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreatePriceAlertUseCase_Execute(t *testing.T) {
+	nextMonth := time.Now().AddDate(0, 1, 0).Format(time.DateOnly)
+
+	type Test struct {
+		name    string
+		r       *mockrepo.MockPriceAlertRepository
+		args    CreatePriceAlertUseCaseInput
+		wantErr error
+	}
+	tests := []Test{
+		func() Test {
+			r := mockrepo.NewMockPriceAlertRepository(t)
+			r.EXPECT().
+				CreatePriceAlert(mock.Anything, mock.MatchedBy(
+					func(alert entity.PriceAlert) bool {
+						return alert.UserID == "1" &&
+							alert.Origin == "SYD" &&
+							alert.Active &&
+							alert.ID != ""
+					},
+				), 2).
+				Return(nil)
+
+			return Test{
+				name: "creates an active alert",
+				r:    r,
+				args: CreatePriceAlertUseCaseInput{
+					UserID:      "1",
+					Origin:      "syd",
+					Destination: "BKK",
+					Date:        nextMonth,
+					TargetPrice: 50000,
+				},
+			}
+		}(),
+		func() Test {
+			r := mockrepo.NewMockPriceAlertRepository(t)
+			r.EXPECT().
+				CreatePriceAlert(mock.Anything, mock.Anything, 2).
+				Return(errs.ErrPriceAlertLimitReached)
+
+			return Test{
+				name: "fails when the user has the max alerts",
+				r:    r,
+				args: CreatePriceAlertUseCaseInput{
+					UserID:      "1",
+					Origin:      "SYD",
+					Destination: "BKK",
+					Date:        nextMonth,
+					DropPercent: 10,
+				},
+				wantErr: errs.ErrPriceAlertLimitReached,
+			}
+		}(),
+		{
+			name: "fails with a past date",
+			r:    mockrepo.NewMockPriceAlertRepository(t),
+			args: CreatePriceAlertUseCaseInput{
+				UserID:      "1",
+				Origin:      "SYD",
+				Destination: "BKK",
+				Date:        "2020-01-10",
+				TargetPrice: 50000,
+			},
+			wantErr: errs.ErrPriceAlertDateInPast,
+		},
+		{
+			name: "fails without a target price or drop percent",
+			r:    mockrepo.NewMockPriceAlertRepository(t),
+			args: CreatePriceAlertUseCaseInput{
+				UserID:      "1",
+				Origin:      "SYD",
+				Destination: "BKK",
+				Date:        nextMonth,
+			},
+			wantErr: errs.New("", errs.ErrCodeValidation),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCreatePriceAlertUseCase(
+				validator.New(),
+				&env.Env{PriceAlertMaxPerUser: 2},
+				tt.r,
+			)
+
+			got, err := c.Execute(context.Background(), tt.args)
+
+			if tt.wantErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, errs.New(tt.wantErr).Code, errs.New(err).Code)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(
+				t,
+				tt.args.Date,
+				got.PriceAlert.Date.Format(time.DateOnly),
+			)
+		})
+	}
+}
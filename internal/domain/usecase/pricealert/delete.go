@@ -0,0 +1,45 @@
+package pricealert
+
+import (
+	"context"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+type DeletePriceAlertUseCase struct {
+	v validator.Validator
+	r repo.PriceAlertRepository
+}
+
+func NewDeletePriceAlertUseCase(
+	v validator.Validator,
+	r repo.PriceAlertRepository,
+) *DeletePriceAlertUseCase {
+	return &DeletePriceAlertUseCase{
+		v: v,
+		r: r,
+	}
+}
+
+type DeletePriceAlertUseCaseInput struct {
+	UserID string `json:"-" validate:"required"`
+	ID     string `json:"-" validate:"required"`
+}
+
+// Execute deletes one of the user's alerts, with its price history.
+func (d *DeletePriceAlertUseCase) Execute(
+	ctx context.Context,
+	in DeletePriceAlertUseCaseInput,
+) error {
+	if err := d.v.Validate(in); err != nil {
+		return errs.New(err)
+	}
+
+	if err := d.r.DeletePriceAlert(ctx, in.UserID, in.ID); err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package pricealert
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+type ListPriceAlertsUseCase struct {
+	v validator.Validator
+	r repo.PriceAlertRepository
+}
+
+func NewListPriceAlertsUseCase(
+	v validator.Validator,
+	r repo.PriceAlertRepository,
+) *ListPriceAlertsUseCase {
+	return &ListPriceAlertsUseCase{
+		v: v,
+		r: r,
+	}
+}
+
+type ListPriceAlertsUseCaseInput struct {
+	UserID string `json:"-" validate:"required"`
+}
+
+type ListPriceAlertsUseCaseOutput struct {
+	Data []entity.PriceAlert `json:"data"`
+}
+
+func (l *ListPriceAlertsUseCase) Execute(
+	ctx context.Context,
+	in ListPriceAlertsUseCaseInput,
+) (*ListPriceAlertsUseCaseOutput, error) {
+	if err := l.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	alerts, err := l.r.ListPriceAlerts(ctx, in.UserID)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &ListPriceAlertsUseCaseOutput{Data: alerts}, nil
+}
+
+const defaultPriceAlertChecksLimit = 100
+
+type ListPriceAlertChecksUseCase struct {
+	v validator.Validator
+	r repo.PriceAlertRepository
+}
+
+func NewListPriceAlertChecksUseCase(
+	v validator.Validator,
+	r repo.PriceAlertRepository,
+) *ListPriceAlertChecksUseCase {
+	return &ListPriceAlertChecksUseCase{
+		v: v,
+		r: r,
+	}
+}
+
+type ListPriceAlertChecksUseCaseInput struct {
+	UserID string `json:"-"     validate:"required"`
+	ID     string `json:"-"     validate:"required"`
+	Limit  int    `json:"limit" validate:"omitempty,min=1,max=1000"`
+}
+
+type ListPriceAlertChecksUseCaseOutput struct {
+	Data []entity.PriceAlertCheck `json:"data"`
+}
+
+// Execute returns the price history of one of the user's alerts, newest
+// first.
+func (l *ListPriceAlertChecksUseCase) Execute(
+	ctx context.Context,
+	in ListPriceAlertChecksUseCaseInput,
+) (*ListPriceAlertChecksUseCaseOutput, error) {
+	if err := l.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	if _, err := l.r.GetPriceAlert(ctx, in.UserID, in.ID); err != nil {
+		return nil, errs.New(err)
+	}
+
+	checks, err := l.r.ListPriceAlertChecks(
+		ctx,
+		in.ID,
+		cmp.Or(in.Limit, defaultPriceAlertChecksLimit),
+	)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &ListPriceAlertChecksUseCaseOutput{Data: checks}, nil
+}
@@ -0,0 +1,417 @@
+package pricealert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/flight"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+	"github.com/danielmesquitta/flight-api/internal/provider/flightapi"
+	"github.com/danielmesquitta/flight-api/internal/provider/mailer"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+)
+
+const watcherLockKey = "pricealert:watcher:lock"
+
+type WatcherRun struct {
+	StartedAt    time.Time `json:"started_at"`
+	FinishedAt   time.Time `json:"finished_at"`
+	Alerts       int       `json:"alerts"`
+	Checked      int       `json:"checked"`
+	Notified     int       `json:"notified"`
+	Expired      int       `json:"expired"`
+	Failed       int       `json:"failed"`
+	QuotaReached bool      `json:"quota_reached"`
+}
+
+// Watcher periodically searches the cheapest flight of the active price
+// alerts, and e-mails their users when it is triggered.
+type Watcher struct {
+	e  *env.Env
+	c  cache.Cache
+	m  *flightapi.Meter
+	s  *flight.SearchFlightsUseCase
+	r  repo.PriceAlertRepository
+	u  repo.UserRepository
+	ml mailer.Mailer
+}
+
+func NewWatcher(
+	e *env.Env,
+	c cache.Cache,
+	m *flightapi.Meter,
+	s *flight.SearchFlightsUseCase,
+	r repo.PriceAlertRepository,
+	u repo.UserRepository,
+	ml mailer.Mailer,
+) *Watcher {
+	return &Watcher{
+		e:  e,
+		c:  c,
+		m:  m,
+		s:  s,
+		r:  r,
+		u:  u,
+		ml: ml,
+	}
+}
+
+// Start runs the watcher every PRICE_ALERT_WATCHER_INTERVAL until ctx is
+// done. It returns right away if the watcher is disabled.
+func (w *Watcher) Start(ctx context.Context) {
+	if !w.e.PriceAlertWatcherEnabled {
+		return
+	}
+
+	ticker := time.NewTicker(w.e.PriceAlertWatcherInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := w.Run(ctx); err != nil {
+			slog.ErrorContext(ctx, "failed to watch price alerts", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run checks every active alert once, unless another instance already
+// did it in the current interval, in which case it returns a nil run.
+// Alerts whose date passed are deactivated instead.
+func (w *Watcher) Run(ctx context.Context) (*WatcherRun, error) {
+	run := &WatcherRun{
+		StartedAt: time.Now(),
+	}
+
+	ctx, release, locked, err := w.lock(ctx, run.StartedAt)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	if !locked {
+		return nil, nil
+	}
+	defer release()
+
+	alerts, err := w.r.ListActivePriceAlerts(ctx)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	run.Alerts = len(alerts)
+
+	today := run.StartedAt.UTC().Truncate(24 * time.Hour)
+	users := map[string]*entity.User{}
+
+	var mu sync.Mutex
+	g := errgroup.Group{}
+	g.SetLimit(w.e.PriceAlertWatcherConcurrency)
+
+	for _, alert := range alerts {
+		if alert.Date.Before(today) {
+			err := w.expire(ctx, alert)
+
+			mu.Lock()
+			if err != nil {
+				run.Failed++
+				slog.ErrorContext(
+					ctx,
+					"failed to expire price alert",
+					"price_alert_id", alert.ID,
+					"error", err,
+				)
+			} else {
+				run.Expired++
+			}
+			mu.Unlock()
+
+			continue
+		}
+
+		user, ok := users[alert.UserID]
+		if !ok {
+			user, err = w.u.GetUserByID(ctx, alert.UserID)
+			if err != nil {
+				mu.Lock()
+				run.Failed++
+				mu.Unlock()
+				slog.ErrorContext(
+					ctx,
+					"failed to get price alert user",
+					"price_alert_id", alert.ID,
+					"error", err,
+				)
+				continue
+			}
+			users[alert.UserID] = user
+		}
+
+		g.Go(func() error {
+			checked, notified, err := w.check(ctx, alert, *user)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case err != nil:
+				run.Failed++
+				slog.ErrorContext(
+					ctx,
+					"failed to check price alert",
+					"price_alert_id", alert.ID,
+					"error", err,
+				)
+
+			case !checked:
+				run.QuotaReached = true
+
+			default:
+				run.Checked++
+				if notified {
+					run.Notified++
+				}
+			}
+
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	run.FinishedAt = time.Now()
+
+	return run, nil
+}
+
+// lock acquires the watcher lock, unless another instance holds it. The
+// lock is held for most of the interval from startedAt, so that a single
+// instance runs per interval, and renewed while running, so that a run
+// longer than that isn't overlapped by another one. The returned context
+// is canceled if the lock is lost, and release stops renewing it, keeping
+// it until the end of its first ttl.
+func (w *Watcher) lock(
+	ctx context.Context,
+	startedAt time.Time,
+) (context.Context, func(), bool, error) {
+	locker, ok := w.c.(cache.Locker)
+	if !ok {
+		return ctx, func() {}, true, nil
+	}
+
+	ttl := w.e.PriceAlertWatcherInterval * 9 / 10
+
+	token, locked, err := locker.Lock(ctx, watcherLockKey, ttl)
+	if err != nil {
+		return nil, nil, false, errs.New(err)
+	}
+	if !locked {
+		return nil, nil, false, nil
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			ok, err := locker.Extend(runCtx, watcherLockKey, token, ttl)
+			if err != nil {
+				slog.ErrorContext(
+					ctx,
+					"failed to renew price alert watcher lock",
+					"error", err,
+				)
+				continue
+			}
+			if !ok {
+				slog.WarnContext(ctx, "price alert watcher lock was lost")
+				cancel()
+				return
+			}
+		}
+	}()
+
+	release := func() {
+		cancel()
+		<-done
+
+		var err error
+		if remaining := time.Until(startedAt.Add(ttl)); remaining > 0 {
+			_, err = locker.Extend(ctx, watcherLockKey, token, remaining)
+		} else {
+			err = locker.Unlock(ctx, watcherLockKey, token)
+		}
+		if err != nil {
+			slog.ErrorContext(
+				ctx,
+				"failed to release price alert watcher lock",
+				"error", err,
+			)
+		}
+	}
+
+	return runCtx, release, true, nil
+}
+
+func (w *Watcher) expire(ctx context.Context, alert entity.PriceAlert) error {
+	alert.Active = false
+	alert.UpdatedAt = time.Now()
+
+	if err := w.r.UpdatePriceAlertState(ctx, alert); err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+// check searches the cheapest flight of the alert, notifying the user if
+// it is triggered, and records its price. It reports whether the alert
+// was checked, which it isn't if the tenant of the user used too much of
+// their provider budget, and whether it was notified.
+func (w *Watcher) check(
+	ctx context.Context,
+	alert entity.PriceAlert,
+	user entity.User,
+) (checked, notified bool, err error) {
+	// Each tenant has its own budget, so reaching one only skips the
+	// alerts of that tenant.
+	ok, err := w.m.WithinBudget(
+		ctx,
+		user.OrganizationID,
+		w.e.PriceAlertWatcherMaxBudgetUsage,
+	)
+	if err != nil {
+		return false, false, errs.New(err)
+	}
+	if !ok {
+		return false, false, nil
+	}
+
+	out, err := w.s.Execute(ctx, flight.SearchFlightsUseCaseInput{
+		Origin:      alert.Origin,
+		Destination: alert.Destination,
+		Date:        alert.Date,
+		SortBy:      "price",
+		SortOrder:   "asc",
+		PageSize:    1,
+		TenantID:    user.OrganizationID,
+		Untracked:   true,
+	})
+	if err != nil && !errors.Is(err, errs.ErrSearchFlightsNotFound) {
+		return false, false, errs.New(err)
+	}
+
+	now := time.Now()
+	alert.LastCheckedAt = now
+	alert.UpdatedAt = now
+
+	// Without flights there is no price to record.
+	if err != nil || len(out.Data) == 0 {
+		if err := w.r.UpdatePriceAlertState(ctx, alert); err != nil {
+			return false, false, errs.New(err)
+		}
+		return true, false, nil
+	}
+
+	price := out.Data[0].Price
+	notified = alert.Triggered(price)
+
+	// The alert is read again before notifying, in case it was deleted,
+	// or notified by another run, since it was listed.
+	if notified {
+		current, err := w.r.GetPriceAlert(ctx, alert.UserID, alert.ID)
+		if errors.Is(err, errs.ErrPriceAlertNotFound) {
+			return true, false, nil
+		}
+		if err != nil {
+			return false, false, errs.New(err)
+		}
+		if !current.Active || !current.NotifiedAt.Equal(alert.NotifiedAt) {
+			return true, false, nil
+		}
+	}
+
+	// The state isn't saved if the e-mail fails, so that the next run
+	// tries again.
+	if notified {
+		if err := w.notify(ctx, alert, user, price); err != nil {
+			return false, false, errs.New(err)
+		}
+		alert.NotifiedAt = now
+	}
+
+	if alert.BasePrice == 0 || notified {
+		alert.BasePrice = price
+	}
+	alert.LastPrice = price
+
+	if err := w.r.UpdatePriceAlertState(ctx, alert); err != nil {
+		return false, false, errs.New(err)
+	}
+
+	err = w.r.CreatePriceAlertCheck(ctx, entity.PriceAlertCheck{
+		ID:        uuid.NewString(),
+		AlertID:   alert.ID,
+		Price:     price,
+		Notified:  notified,
+		CheckedAt: now,
+	}, w.e.PriceAlertMaxChecks)
+	if err != nil {
+		return false, false, errs.New(err)
+	}
+
+	return true, notified, nil
+}
+
+func (w *Watcher) notify(
+	ctx context.Context,
+	alert entity.PriceAlert,
+	user entity.User,
+	price int64,
+) error {
+	date := alert.Date.Format(time.DateOnly)
+
+	err := w.ml.Send(ctx, mailer.Message{
+		To: user.Email,
+		Subject: fmt.Sprintf(
+			"Price drop from %s to %s on %s",
+			alert.Origin,
+			alert.Destination,
+			date,
+		),
+		Body: fmt.Sprintf(
+			"The cheapest flight from %s to %s on %s now costs %d.%02d.\n",
+			alert.Origin,
+			alert.Destination,
+			date,
+			price/100,
+			price%100,
+		),
+	})
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
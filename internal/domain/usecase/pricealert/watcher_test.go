@@ -0,0 +1,317 @@
+package pricealert
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/flight"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/inmemorycache"
+	"github.com/danielmesquitta/flight-api/internal/provider/flightapi"
+	"github.com/danielmesquitta/flight-api/internal/provider/flightapi/mockflightapi"
+	"github.com/danielmesquitta/flight-api/internal/provider/mailer/filemailer"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/inmemoryrepo"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/mockrepo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWatcher_Run(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	date := now.UTC().Truncate(24*time.Hour).AddDate(0, 1, 0)
+
+	e := &env.Env{
+		InMemoryCacheMaxEntries:         100,
+		SearchCacheSoftTTL:              time.Minute,
+		SearchCacheHardTTL:              time.Hour,
+		MailerFilePath:                  filepath.Join(t.TempDir(), "mail"),
+		PriceAlertWatcherInterval:       time.Minute,
+		PriceAlertWatcherConcurrency:    2,
+		PriceAlertWatcherMaxBudgetUsage: 60,
+		PriceAlertMaxChecks:             10,
+	}
+
+	r := inmemoryrepo.NewInMemoryRepository()
+	user := entity.User{ID: "1", Email: "johndoe@email.com"}
+	assert.Nil(t, r.CreateUser(ctx, user))
+
+	target := entity.PriceAlert{
+		ID:          "target",
+		UserID:      user.ID,
+		Origin:      "SYD",
+		Destination: "BKK",
+		Date:        date,
+		TargetPrice: 50000,
+		Active:      true,
+	}
+	drop := entity.PriceAlert{
+		ID:          "drop",
+		UserID:      user.ID,
+		Origin:      "SYD",
+		Destination: "MEL",
+		Date:        date,
+		DropPercent: 10,
+		Active:      true,
+	}
+	past := entity.PriceAlert{
+		ID:          "past",
+		UserID:      user.ID,
+		Origin:      "SYD",
+		Destination: "PER",
+		Date:        date.AddDate(0, -2, 0),
+		TargetPrice: 50000,
+		Active:      true,
+	}
+	for _, alert := range []entity.PriceAlert{target, drop, past} {
+		assert.Nil(t, r.CreatePriceAlert(ctx, alert, 10))
+	}
+
+	f := mockflightapi.NewMockFlightAPI(t)
+	f.EXPECT().
+		SearchFlights(mock.Anything, "SYD", "BKK", mock.Anything).
+		Return([]entity.Flight{{ID: "1", Price: 45000}}, nil).
+		Once()
+	f.EXPECT().
+		SearchFlights(mock.Anything, "SYD", "MEL", mock.Anything).
+		Return([]entity.Flight{{ID: "2", Price: 20000}}, nil).
+		Once()
+	f.EXPECT().
+		SearchFlights(mock.Anything, "SYD", "BKK", mock.Anything).
+		Return([]entity.Flight{{ID: "1", Price: 46000}}, nil).
+		Once()
+	f.EXPECT().
+		SearchFlights(mock.Anything, "SYD", "MEL", mock.Anything).
+		Return([]entity.Flight{{ID: "2", Price: 17000}}, nil).
+		Once()
+
+	// Each watcher has its own cache, so that it isn't locked by the
+	// previous run, and searches the providers again.
+	newWatcher := func() *Watcher {
+		c := inmemorycache.NewInMemoryCache(e)
+		s := flight.NewSearchFlightsUseCase(
+			validator.New(),
+			c,
+			flightapi.StaticResolver{f},
+			flight.NewCachePolicy(e, c),
+			e,
+			flight.NewPopularSearches(c),
+		)
+		return NewWatcher(
+			e,
+			c,
+			flightapi.NewMeter(e, c),
+			s,
+			r,
+			r,
+			filemailer.NewFileMailer(e),
+		)
+	}
+
+	w := newWatcher()
+	run, err := w.Run(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, run.Alerts)
+	assert.Equal(t, 2, run.Checked)
+	assert.Equal(t, 1, run.Notified, "should notify below the target")
+	assert.Equal(t, 1, run.Expired)
+	assert.Equal(t, 0, run.Failed)
+
+	run, err = w.Run(ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, run, "should run once per interval")
+
+	got, err := r.GetPriceAlert(ctx, user.ID, past.ID)
+	assert.Nil(t, err)
+	assert.False(t, got.Active, "should deactivate alerts of past dates")
+
+	got, err = r.GetPriceAlert(ctx, user.ID, drop.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(20000), got.BasePrice)
+	assert.True(t, got.NotifiedAt.IsZero())
+
+	run, err = newWatcher().Run(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, run.Alerts)
+	assert.Equal(t, 2, run.Checked)
+	assert.Equal(
+		t,
+		1,
+		run.Notified,
+		"should notify drops, but not prices above the last notified one",
+	)
+
+	got, err = r.GetPriceAlert(ctx, user.ID, target.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(45000), got.BasePrice)
+	assert.Equal(t, int64(46000), got.LastPrice)
+
+	got, err = r.GetPriceAlert(ctx, user.ID, drop.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(17000), got.BasePrice)
+	assert.False(t, got.NotifiedAt.IsZero())
+
+	checks, err := r.ListPriceAlertChecks(ctx, target.ID, 10)
+	assert.Nil(t, err)
+	if assert.Len(t, checks, 2) {
+		assert.Equal(t, int64(46000), checks[0].Price)
+		assert.False(t, checks[0].Notified)
+		assert.True(t, checks[1].Notified)
+	}
+
+	messages, err := filemailer.Read(e.MailerFilePath)
+	assert.Nil(t, err)
+	if assert.Len(t, messages, 2) {
+		assert.Equal(t, user.Email, messages[0].To)
+		assert.Contains(t, messages[0].Body, "450.00")
+		assert.Contains(t, messages[1].Body, "170.00")
+	}
+}
+
+func TestWatcher_Run_QuotaReached(t *testing.T) {
+	ctx := context.Background()
+	e := &env.Env{
+		InMemoryCacheMaxEntries:         100,
+		PriceAlertWatcherInterval:       time.Minute,
+		PriceAlertWatcherConcurrency:    1,
+		PriceAlertWatcherMaxBudgetUsage: 60,
+		AmadeusAPIDailyCallLimit:        10,
+		PriceAlertMaxChecks:             10,
+	}
+
+	r := inmemoryrepo.NewInMemoryRepository()
+	assert.Nil(t, r.CreateUser(ctx, entity.User{ID: "1"}))
+	err := r.CreatePriceAlert(ctx, entity.PriceAlert{
+		ID:          "1",
+		UserID:      "1",
+		Origin:      "SYD",
+		Destination: "BKK",
+		Date:        time.Now().AddDate(0, 1, 0),
+		TargetPrice: 50000,
+		Active:      true,
+	}, 10)
+	assert.Nil(t, err)
+
+	c := inmemorycache.NewInMemoryCache(e)
+	m := flightapi.NewMeter(e, c)
+	for range 6 {
+		ok, err := m.Reserve(ctx, "", flightapi.ProviderAmadeus)
+		assert.Nil(t, err)
+		assert.True(t, ok)
+	}
+
+	// The flight API isn't expected to be searched.
+	f := mockflightapi.NewMockFlightAPI(t)
+	s := flight.NewSearchFlightsUseCase(
+		validator.New(),
+		c,
+		flightapi.StaticResolver{f},
+		flight.NewCachePolicy(e, c),
+		e,
+		flight.NewPopularSearches(c),
+	)
+	w := NewWatcher(e, c, m, s, r, r, filemailer.NewFileMailer(e))
+
+	run, err := w.Run(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, run.Alerts)
+	assert.Equal(t, 0, run.Checked)
+	assert.True(t, run.QuotaReached)
+}
+
+func TestWatcher_Run_Failures(t *testing.T) {
+	ctx := context.Background()
+	date := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 1, 0)
+
+	e := &env.Env{
+		InMemoryCacheMaxEntries:         100,
+		SearchCacheSoftTTL:              time.Minute,
+		SearchCacheHardTTL:              time.Hour,
+		MailerFilePath:                  filepath.Join(t.TempDir(), "mail"),
+		PriceAlertWatcherInterval:       time.Minute,
+		PriceAlertWatcherConcurrency:    1,
+		PriceAlertWatcherMaxBudgetUsage: 60,
+	}
+
+	u := inmemoryrepo.NewInMemoryRepository()
+	assert.Nil(t, u.CreateUser(ctx, entity.User{ID: "1"}))
+
+	past := entity.PriceAlert{
+		ID:          "past",
+		UserID:      "1",
+		Date:        date.AddDate(0, -2, 0),
+		TargetPrice: 50000,
+		Active:      true,
+	}
+	target := entity.PriceAlert{
+		ID:          "target",
+		UserID:      "1",
+		Origin:      "SYD",
+		Destination: "BKK",
+		Date:        date,
+		TargetPrice: 50000,
+		Active:      true,
+	}
+	notified := target
+	notified.NotifiedAt = time.Now()
+
+	r := mockrepo.NewMockPriceAlertRepository(t)
+	r.EXPECT().
+		ListActivePriceAlerts(mock.Anything).
+		Return([]entity.PriceAlert{past, target}, nil)
+	r.EXPECT().
+		UpdatePriceAlertState(mock.Anything, mock.MatchedBy(
+			func(alert entity.PriceAlert) bool {
+				return alert.ID == past.ID
+			},
+		)).
+		Return(errs.ErrPriceAlertNotFound)
+	r.EXPECT().
+		GetPriceAlert(mock.Anything, "1", target.ID).
+		Return(&notified, nil)
+
+	f := mockflightapi.NewMockFlightAPI(t)
+	f.EXPECT().
+		SearchFlights(mock.Anything, "SYD", "BKK", mock.Anything).
+		Return([]entity.Flight{{ID: "1", Price: 45000}}, nil)
+
+	c := inmemorycache.NewInMemoryCache(e)
+	s := flight.NewSearchFlightsUseCase(
+		validator.New(),
+		c,
+		flightapi.StaticResolver{f},
+		flight.NewCachePolicy(e, c),
+		e,
+		flight.NewPopularSearches(c),
+	)
+	w := NewWatcher(
+		e,
+		c,
+		flightapi.NewMeter(e, c),
+		s,
+		r,
+		u,
+		filemailer.NewFileMailer(e),
+	)
+
+	run, err := w.Run(ctx)
+	assert.Nil(t, err, "should keep running when an alert fails to expire")
+	assert.Equal(t, 1, run.Failed)
+	assert.Equal(t, 0, run.Expired)
+	assert.Equal(t, 1, run.Checked)
+	assert.Equal(
+		t,
+		0,
+		run.Notified,
+		"should not notify an alert notified since it was listed",
+	)
+
+	messages, err := filemailer.Read(e.MailerFilePath)
+	assert.Nil(t, err)
+	assert.Empty(t, messages)
+}
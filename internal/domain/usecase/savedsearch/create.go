@@ -0,0 +1,84 @@
+// Package savedsearch manages the flight searches users save to run
+// again, with dates relative to the day they run.
+package savedsearch
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/reldate"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	"github.com/google/uuid"
+)
+
+type CreateSavedSearchUseCase struct {
+	v validator.Validator
+	r repo.SavedSearchRepository
+}
+
+func NewCreateSavedSearchUseCase(
+	v validator.Validator,
+	r repo.SavedSearchRepository,
+) *CreateSavedSearchUseCase {
+	return &CreateSavedSearchUseCase{
+		v: v,
+		r: r,
+	}
+}
+
+type CreateSavedSearchUseCaseInput struct {
+	UserID      string `json:"-"            validate:"required"`
+	Name        string `json:"name"         validate:"required,max=100"`
+	Origin      string `json:"origin"       validate:"required,len=3"`
+	Destination string `json:"destination"  validate:"required,len=3"`
+	// Date is a date, as 2006-01-02, or relative to the day the search
+	// runs, such as "tomorrow", "+30d" or "next friday".
+	Date        string `json:"date"         validate:"required,max=20"`
+	SortBy      string `json:"sort_by"      validate:"omitempty,oneof=price duration departure"`
+	SortOrder   string `json:"sort_order"   validate:"omitempty,oneof=asc desc"`
+	MaxPrice    int64  `json:"max_price"    validate:"omitempty,min=1"`
+	MaxDuration int64  `json:"max_duration" validate:"omitempty,min=1"`
+	PageSize    int    `json:"page_size"    validate:"omitempty,min=1,max=100"`
+}
+
+type CreateSavedSearchUseCaseOutput struct {
+	SavedSearch entity.SavedSearch `json:"saved_search"`
+}
+
+func (c *CreateSavedSearchUseCase) Execute(
+	ctx context.Context,
+	in CreateSavedSearchUseCaseInput,
+) (*CreateSavedSearchUseCaseOutput, error) {
+	if err := c.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	now := time.Now()
+	if _, err := reldate.Parse(in.Date, now); err != nil {
+		return nil, errs.ErrInvalidSavedSearchDate
+	}
+
+	search := entity.SavedSearch{
+		ID:          uuid.NewString(),
+		UserID:      in.UserID,
+		Name:        in.Name,
+		Origin:      in.Origin,
+		Destination: in.Destination,
+		Date:        in.Date,
+		SortBy:      in.SortBy,
+		SortOrder:   in.SortOrder,
+		MaxPrice:    in.MaxPrice,
+		MaxDuration: in.MaxDuration,
+		PageSize:    in.PageSize,
+		CreatedAt:   now,
+	}
+
+	if err := c.r.CreateSavedSearch(ctx, search); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &CreateSavedSearchUseCaseOutput{SavedSearch: search}, nil
+}
@@ -0,0 +1,109 @@
+package savedsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/mockrepo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateSavedSearchUseCase_Execute(t *testing.T) {
+	type Test struct {
+		name    string
+		r       *mockrepo.MockSavedSearchRepository
+		args    CreateSavedSearchUseCaseInput
+		wantErr error
+	}
+	tests := []Test{
+		func() Test {
+			r := mockrepo.NewMockSavedSearchRepository(t)
+			r.EXPECT().
+				CreateSavedSearch(mock.Anything, mock.MatchedBy(
+					func(search entity.SavedSearch) bool {
+						return search.UserID == "1" &&
+							search.Date == "next friday" &&
+							search.ID != ""
+					},
+				)).
+				Return(nil)
+
+			return Test{
+				name: "saves a search with a relative date",
+				r:    r,
+				args: CreateSavedSearchUseCaseInput{
+					UserID:      "1",
+					Name:        "Weekend in Bangkok",
+					Origin:      "SYD",
+					Destination: "BKK",
+					Date:        "next friday",
+				},
+			}
+		}(),
+		func() Test {
+			r := mockrepo.NewMockSavedSearchRepository(t)
+			r.EXPECT().
+				CreateSavedSearch(mock.Anything, mock.Anything).
+				Return(errs.ErrSavedSearchAlreadyExists)
+
+			return Test{
+				name: "fails with a taken name",
+				r:    r,
+				args: CreateSavedSearchUseCaseInput{
+					UserID:      "1",
+					Name:        "Weekend in Bangkok",
+					Origin:      "SYD",
+					Destination: "BKK",
+					Date:        "+30d",
+				},
+				wantErr: errs.ErrSavedSearchAlreadyExists,
+			}
+		}(),
+		{
+			name: "fails with an invalid date",
+			r:    mockrepo.NewMockSavedSearchRepository(t),
+			args: CreateSavedSearchUseCaseInput{
+				UserID:      "1",
+				Name:        "Weekend in Bangkok",
+				Origin:      "SYD",
+				Destination: "BKK",
+				Date:        "someday",
+			},
+			wantErr: errs.ErrInvalidSavedSearchDate,
+		},
+		{
+			name: "fails with an invalid airport code",
+			r:    mockrepo.NewMockSavedSearchRepository(t),
+			args: CreateSavedSearchUseCaseInput{
+				UserID:      "1",
+				Name:        "Weekend in Bangkok",
+				Origin:      "Sydney",
+				Destination: "BKK",
+				Date:        "tomorrow",
+			},
+			wantErr: errs.New("", errs.ErrCodeValidation),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCreateSavedSearchUseCase(validator.New(), tt.r)
+
+			got, err := c.Execute(context.Background(), tt.args)
+
+			if tt.wantErr != nil {
+				assert.NotNil(t, err)
+				assert.Equal(t, errs.New(tt.wantErr).Code, errs.New(err).Code)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.args.Name, got.SavedSearch.Name)
+			assert.Equal(t, tt.args.Date, got.SavedSearch.Date)
+		})
+	}
+}
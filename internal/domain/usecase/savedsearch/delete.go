@@ -0,0 +1,44 @@
+package savedsearch
+
+import (
+	"context"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+type DeleteSavedSearchUseCase struct {
+	v validator.Validator
+	r repo.SavedSearchRepository
+}
+
+func NewDeleteSavedSearchUseCase(
+	v validator.Validator,
+	r repo.SavedSearchRepository,
+) *DeleteSavedSearchUseCase {
+	return &DeleteSavedSearchUseCase{
+		v: v,
+		r: r,
+	}
+}
+
+type DeleteSavedSearchUseCaseInput struct {
+	UserID string `json:"-" validate:"required"`
+	ID     string `json:"-" validate:"required"`
+}
+
+func (d *DeleteSavedSearchUseCase) Execute(
+	ctx context.Context,
+	in DeleteSavedSearchUseCaseInput,
+) error {
+	if err := d.v.Validate(in); err != nil {
+		return errs.New(err)
+	}
+
+	if err := d.r.DeleteSavedSearch(ctx, in.UserID, in.ID); err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package savedsearch
+
+import (
+	"context"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+type ListSavedSearchesUseCase struct {
+	v validator.Validator
+	r repo.SavedSearchRepository
+}
+
+func NewListSavedSearchesUseCase(
+	v validator.Validator,
+	r repo.SavedSearchRepository,
+) *ListSavedSearchesUseCase {
+	return &ListSavedSearchesUseCase{
+		v: v,
+		r: r,
+	}
+}
+
+type ListSavedSearchesUseCaseInput struct {
+	UserID string `json:"-" validate:"required"`
+}
+
+type ListSavedSearchesUseCaseOutput struct {
+	Data []entity.SavedSearch `json:"data"`
+}
+
+func (l *ListSavedSearchesUseCase) Execute(
+	ctx context.Context,
+	in ListSavedSearchesUseCaseInput,
+) (*ListSavedSearchesUseCaseOutput, error) {
+	if err := l.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	searches, err := l.r.ListSavedSearches(ctx, in.UserID)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &ListSavedSearchesUseCaseOutput{Data: searches}, nil
+}
@@ -0,0 +1,88 @@
+package savedsearch
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/flight"
+	"github.com/danielmesquitta/flight-api/internal/pkg/reldate"
+	"github.com/danielmesquitta/flight-api/internal/pkg/validator"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+type RunSavedSearchUseCase struct {
+	v validator.Validator
+	r repo.SavedSearchRepository
+	s *flight.SearchFlightsUseCase
+}
+
+func NewRunSavedSearchUseCase(
+	v validator.Validator,
+	r repo.SavedSearchRepository,
+	s *flight.SearchFlightsUseCase,
+) *RunSavedSearchUseCase {
+	return &RunSavedSearchUseCase{
+		v: v,
+		r: r,
+		s: s,
+	}
+}
+
+type RunSavedSearchUseCaseInput struct {
+	UserID string `json:"-"    validate:"required"`
+	ID     string `json:"-"    validate:"required"`
+	Page   int    `json:"page" validate:"omitempty,min=1"`
+	// TenantID is the organization searching, empty for the default
+	// tenant.
+	TenantID string `json:"-"`
+}
+
+type RunSavedSearchUseCaseOutput struct {
+	// Date is the departure date the search ran for.
+	Date time.Time `json:"date"`
+	flight.SearchFlightsUseCaseOutput
+}
+
+// Execute searches flights with the saved search of the user, for the
+// date it stands for today.
+func (r *RunSavedSearchUseCase) Execute(
+	ctx context.Context,
+	in RunSavedSearchUseCaseInput,
+) (*RunSavedSearchUseCaseOutput, error) {
+	if err := r.v.Validate(in); err != nil {
+		return nil, errs.New(err)
+	}
+
+	search, err := r.r.GetSavedSearch(ctx, in.UserID, in.ID)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	date, err := reldate.Parse(search.Date, time.Now())
+	if err != nil {
+		return nil, errs.ErrInvalidSavedSearchDate
+	}
+
+	out, err := r.s.Execute(ctx, flight.SearchFlightsUseCaseInput{
+		Origin:      search.Origin,
+		Destination: search.Destination,
+		Date:        date,
+		SortBy:      search.SortBy,
+		SortOrder:   search.SortOrder,
+		MaxPrice:    search.MaxPrice,
+		MaxDuration: search.MaxDuration,
+		Page:        in.Page,
+		PageSize:    search.PageSize,
+		TenantID:    in.TenantID,
+		Untracked:   true,
+	})
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return &RunSavedSearchUseCaseOutput{
+		Date:                       date,
+		SearchFlightsUseCaseOutput: *out,
+	}, nil
+}
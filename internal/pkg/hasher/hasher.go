@@ -0,0 +1,50 @@
+package hasher
+
+import (
+	"errors"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type Hasher interface {
+	Hash(password string) (string, error)
+	// Compare reports whether the password matches the hash.
+	Compare(hash, password string) (bool, error)
+}
+
+// Bcrypt hashes passwords with bcrypt, which rejects passwords longer
+// than 72 bytes.
+type Bcrypt struct {
+	cost int
+}
+
+func New() *Bcrypt {
+	return &Bcrypt{
+		cost: bcrypt.DefaultCost,
+	}
+}
+
+func (b *Bcrypt) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		return "", errs.New(err, errs.ErrCodeValidation)
+	}
+	if err != nil {
+		return "", errs.New(err)
+	}
+	return string(hash), nil
+}
+
+func (b *Bcrypt) Compare(hash, password string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errs.New(err)
+	}
+	return true, nil
+}
+
+var _ Hasher = (*Bcrypt)(nil)
@@ -0,0 +1,131 @@
+package jwtutil
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+)
+
+// Denylist keeps track of tokens revoked before their expiration.
+type Denylist struct {
+	c cache.Cache
+}
+
+func NewDenylist(c cache.Cache) *Denylist {
+	return &Denylist{
+		c: c,
+	}
+}
+
+func denylistTokenKey(id string) string {
+	return "auth:revoked:token:" + id
+}
+
+func denylistSubjectKey(subject string) string {
+	return "auth:revoked:subject:" + subject
+}
+
+func denylistFamilyKey(family string) string {
+	return "auth:revoked:family:" + family
+}
+
+// Revoke denies the token until it expires.
+func (d *Denylist) Revoke(ctx context.Context, claims *UserClaims) error {
+	if claims.ID == "" {
+		return errs.New("token has no id to be revoked")
+	}
+
+	ttl := time.Until(claims.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	if err := d.c.Set(ctx, denylistTokenKey(claims.ID), true, ttl); err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+// RevokeSubject denies every token issued to the subject so far. The
+// denial lasts for ttl, which must be at least the tokens lifetime.
+func (d *Denylist) RevokeSubject(
+	ctx context.Context,
+	subject string,
+	ttl time.Duration,
+) error {
+	err := d.c.Set(
+		ctx,
+		denylistSubjectKey(subject),
+		time.Now().Unix(),
+		ttl,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+// RevokeFamily denies every token of the family, issued from the same
+// login. The denial lasts for ttl, which must be at least the tokens
+// lifetime.
+func (d *Denylist) RevokeFamily(
+	ctx context.Context,
+	family string,
+	ttl time.Duration,
+) error {
+	if err := d.c.Set(ctx, denylistFamilyKey(family), true, ttl); err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+// IsRevoked reports whether the token, its family, or every token of its
+// subject, was revoked.
+func (d *Denylist) IsRevoked(
+	ctx context.Context,
+	claims *UserClaims,
+) (bool, error) {
+	if claims.ID != "" {
+		var revoked bool
+		ok, err := d.c.Scan(ctx, denylistTokenKey(claims.ID), &revoked)
+		if err != nil {
+			return false, errs.New(err)
+		}
+		if ok {
+			return true, nil
+		}
+	}
+
+	if claims.Family != "" {
+		var revoked bool
+		ok, err := d.c.Scan(ctx, denylistFamilyKey(claims.Family), &revoked)
+		if err != nil {
+			return false, errs.New(err)
+		}
+		if ok {
+			return true, nil
+		}
+	}
+
+	if claims.Subject != "" {
+		key := denylistSubjectKey(claims.Subject)
+
+		var revokedAt int64
+		ok, err := d.c.Scan(ctx, key, &revokedAt)
+		if err != nil {
+			return false, errs.New(err)
+		}
+		// Token times have a precision of seconds, so a token issued in
+		// the same second as the revocation is revoked as well.
+		if ok && claims.IssuedAt.Unix() <= revokedAt {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
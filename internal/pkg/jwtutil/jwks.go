@@ -0,0 +1,59 @@
+package jwtutil
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+// JWKS is the JSON Web Key Set other services verify access tokens with.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	// N and E are set for RSA keys.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Crv and X are set for Ed25519 keys.
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS returns the public keys access tokens are verified with.
+func (j *JWT) JWKS() JWKS {
+	jwks := JWKS{Keys: []JWK{}}
+	for _, key := range j.signingKeys {
+		jwk := JWK{
+			Use: "sig",
+			Alg: key.Method.Alg(),
+			Kid: key.ID,
+		}
+
+		switch public := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64URL(public.N.Bytes())
+			jwk.E = base64URL(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64URL(public)
+		default:
+			continue
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
+
+func base64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/danielmesquitta/flight-api/internal/config/env"
 	"github.com/danielmesquitta/flight-api/internal/domain/errs"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 const ClaimsKey = "claims"
@@ -17,8 +18,19 @@ type TokenType byte
 
 const (
 	TokenTypeAccess TokenType = iota
+	TokenTypeRefresh
 )
 
+// String returns the value of the token_use claim of the token type,
+// which tells tokens of each type apart even if signed with the same
+// key.
+func (t TokenType) String() string {
+	if t == TokenTypeRefresh {
+		return "refresh"
+	}
+	return "access"
+}
+
 type RegisteredClaims struct {
 	jwt.RegisteredClaims
 }
@@ -33,36 +45,199 @@ func (rc *RegisteredClaims) IsExpired() bool {
 
 type JWT struct {
 	keys map[TokenType][]byte
+	// signingKeys sign access tokens with the first key, and verify them
+	// with any. Access tokens are signed with HS256 when empty.
+	signingKeys []SigningKey
 }
 
 func NewJWT(
 	e *env.Env,
 ) *JWT {
 	keys := map[TokenType][]byte{
-		TokenTypeAccess: []byte(e.JWTAccessTokenSecretKey),
+		TokenTypeAccess:  []byte(e.JWTAccessTokenSecretKey),
+		TokenTypeRefresh: []byte(e.JWTRefreshTokenSecretKey),
+	}
+
+	signingKeys, err := ParseSigningKeys(e.JWTSigningKeys)
+	if err != nil {
+		panic(err)
+	}
+	if len(signingKeys) > 0 && signingKeys[0].Private == nil {
+		panic("the first signing key must be a private key")
 	}
 
 	return &JWT{
-		keys: keys,
+		keys:        keys,
+		signingKeys: signingKeys,
 	}
 }
 
 type UserClaims struct {
+	// Subject is the id of the user.
+	Subject   string
 	Issuer    string
 	IssuedAt  time.Time
 	ExpiresAt time.Time
+	// ID identifies the token itself, so that it can be revoked. A random
+	// one is used when empty.
+	ID string
+	// Plan is the tier the user is subscribed to, empty for the base one.
+	Plan string
+	// TenantID is the organization of the user, empty for the default
+	// tenant.
+	TenantID string
+	// Family groups the tokens issued from the same login.
+	Family string
+	// Roles grant access to restricted routes, and Scopes limit what the
+	// token can be used for.
+	Roles  []string
+	Scopes []string
+	// APIKeyID is set when authenticated with an API key instead of a
+	// token.
+	APIKeyID string
 }
 
 func (j *JWT) NewToken(claims UserClaims, tokenType TokenType) (string, error) {
 	jwtClaims := jwt.MapClaims{
-		"iss": claims.Issuer,
-		"iat": claims.IssuedAt.Unix(),
-		"exp": claims.ExpiresAt.Unix(),
+		"iss":       claims.Issuer,
+		"iat":       claims.IssuedAt.Unix(),
+		"exp":       claims.ExpiresAt.Unix(),
+		"jti":       claims.ID,
+		"token_use": tokenType.String(),
+	}
+	if claims.ID == "" {
+		jwtClaims["jti"] = uuid.NewString()
+	}
+	optionalClaims := map[string]string{
+		"sub":  claims.Subject,
+		"plan": claims.Plan,
+		"tid":  claims.TenantID,
+		"fam":  claims.Family,
+	}
+	for name, value := range optionalClaims {
+		if value != "" {
+			jwtClaims[name] = value
+		}
 	}
+	listClaims := map[string][]string{
+		"roles":  claims.Roles,
+		"scopes": claims.Scopes,
+	}
+	for name, values := range listClaims {
+		if len(values) > 0 {
+			jwtClaims[name] = values
+		}
+	}
+
+	if tokenType == TokenTypeAccess && len(j.signingKeys) > 0 {
+		key := j.signingKeys[0]
+		jwtToken := jwt.NewWithClaims(key.Method, jwtClaims)
+		jwtToken.Header["kid"] = key.ID
+		return jwtToken.SignedString(key.Private)
+	}
+
 	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims)
 	return jwtToken.SignedString(j.keys[tokenType])
 }
 
+// KeyFunc returns the key a token of the given type is verified with,
+// looked up by its kid header when access tokens are signed with
+// asymmetric keys. Tokens of another type are rejected.
+func (j *JWT) KeyFunc(tokenType TokenType) jwt.Keyfunc {
+	return func(token *jwt.Token) (any, error) {
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok || claims["token_use"] != tokenType.String() {
+			return nil, errs.New("unexpected token type")
+		}
+
+		if tokenType != TokenTypeAccess || len(j.signingKeys) == 0 {
+			if token.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+				return nil, errs.New("unexpected signing method")
+			}
+			return j.keys[tokenType], nil
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		for _, key := range j.signingKeys {
+			if key.ID != kid {
+				continue
+			}
+			if token.Method.Alg() != key.Method.Alg() {
+				return nil, errs.New("unexpected signing method")
+			}
+			return key.Public, nil
+		}
+
+		return nil, errs.New("unknown signing key")
+	}
+}
+
+// Parse verifies the signature and expiration of a token of the given
+// type, and returns its claims.
+func (j *JWT) Parse(token string, tokenType TokenType) (*UserClaims, error) {
+	jwtToken, err := jwt.Parse(
+		token,
+		j.KeyFunc(tokenType),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, errs.New(err, errs.ErrCodeUnauthorized)
+	}
+
+	claims, ok := jwtToken.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errs.New("invalid token claims", errs.ErrCodeUnauthorized)
+	}
+
+	return NewUserClaims(claims), nil
+}
+
+// stringsClaim reads a claim holding a list of strings, which is decoded
+// as a list of any.
+func stringsClaim(claims jwt.MapClaims, name string) []string {
+	values, _ := claims[name].([]any)
+
+	strs := make([]string, 0, len(values))
+	for _, value := range values {
+		if str, ok := value.(string); ok {
+			strs = append(strs, str)
+		}
+	}
+
+	return strs
+}
+
+// NewUserClaims reads the claims of a verified token.
+func NewUserClaims(claims jwt.MapClaims) *UserClaims {
+	subject, _ := claims.GetSubject()
+	issuer, _ := claims.GetIssuer()
+	issuedAt, _ := claims.GetIssuedAt()
+	expiresAt, _ := claims.GetExpirationTime()
+	id, _ := claims["jti"].(string)
+	plan, _ := claims["plan"].(string)
+	tenantID, _ := claims["tid"].(string)
+	family, _ := claims["fam"].(string)
+
+	userClaims := &UserClaims{
+		Subject:  subject,
+		Issuer:   issuer,
+		ID:       id,
+		Plan:     plan,
+		TenantID: tenantID,
+		Family:   family,
+		Roles:    stringsClaim(claims, "roles"),
+		Scopes:   stringsClaim(claims, "scopes"),
+	}
+	if issuedAt != nil {
+		userClaims.IssuedAt = issuedAt.Time
+	}
+	if expiresAt != nil {
+		userClaims.ExpiresAt = expiresAt.Time
+	}
+
+	return userClaims
+}
+
 // Decode decodes a JWT and extracts the payload.
 func (j *JWT) Decode(token string) (*RegisteredClaims, error) {
 	parts := strings.Split(token, ".")
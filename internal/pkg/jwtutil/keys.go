@@ -0,0 +1,125 @@
+package jwtutil
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningKey is an asymmetric key access tokens are signed or verified
+// with, identified by the kid header of the tokens.
+type SigningKey struct {
+	ID     string
+	Method jwt.SigningMethod
+	// Private is nil for keys that only verify tokens, such as retired
+	// ones kept until their tokens expire.
+	Private crypto.Signer
+	Public  crypto.PublicKey
+}
+
+// ParseSigningKeys parses comma-separated kid=key pairs, where each key is
+// the path to a PEM file or the PEM itself, with newlines escaped as \n.
+// RSA keys sign with RS256 and Ed25519 keys with EdDSA.
+func ParseSigningKeys(s string) ([]SigningKey, error) {
+	keys := []SigningKey{}
+	for pair := range strings.SplitSeq(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		id, value, ok := strings.Cut(pair, "=")
+		if !ok || id == "" || value == "" {
+			return nil, errs.New(fmt.Sprintf("invalid signing key %q", pair))
+		}
+
+		for _, key := range keys {
+			if key.ID == id {
+				return nil, errs.New(
+					fmt.Sprintf("duplicate signing key %q", id),
+				)
+			}
+		}
+
+		data, err := readPEM(value)
+		if err != nil {
+			return nil, errs.New(fmt.Sprintf("signing key %q: %s", id, err))
+		}
+
+		key, err := parseSigningKey(data)
+		if err != nil {
+			return nil, errs.New(fmt.Sprintf("signing key %q: %s", id, err))
+		}
+		key.ID = id
+
+		keys = append(keys, *key)
+	}
+
+	return keys, nil
+}
+
+func readPEM(value string) ([]byte, error) {
+	if strings.HasPrefix(value, "-----BEGIN") {
+		return []byte(strings.ReplaceAll(value, `\n`, "\n")), nil
+	}
+
+	data, err := os.ReadFile(value)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	return data, nil
+}
+
+func parseSigningKey(data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errs.New("no PEM block found")
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, errs.New(
+			fmt.Sprintf("unsupported PEM block %q", block.Type),
+		)
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		return &SigningKey{
+			Method:  jwt.SigningMethodRS256,
+			Private: key,
+			Public:  &key.PublicKey,
+		}, nil
+	case *rsa.PublicKey:
+		return &SigningKey{Method: jwt.SigningMethodRS256, Public: key}, nil
+	case ed25519.PrivateKey:
+		return &SigningKey{
+			Method:  jwt.SigningMethodEdDSA,
+			Private: key,
+			Public:  key.Public(),
+		}, nil
+	case ed25519.PublicKey:
+		return &SigningKey{Method: jwt.SigningMethodEdDSA, Public: key}, nil
+	default:
+		return nil, errs.New(fmt.Sprintf("unsupported key type %T", parsed))
+	}
+}
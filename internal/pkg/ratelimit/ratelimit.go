@@ -0,0 +1,203 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+)
+
+const keyPrefix = "ratelimit:"
+
+// Budget groups the routes whose requests are counted together.
+type Budget string
+
+const (
+	BudgetDefault Budget = "default"
+	BudgetSearch  Budget = "search"
+	BudgetLogin   Budget = "login"
+	// BudgetIP counts every request per IP address, before its
+	// credentials are checked, so that guessing them is throttled too.
+	BudgetIP Budget = "ip"
+)
+
+// Rule allows Limit requests per Window.
+type Rule struct {
+	Limit  int64
+	Window time.Duration
+}
+
+type Result struct {
+	Rule      Rule
+	Allowed   bool
+	Remaining int64
+	// Reset is how long until the client gets its full budget back.
+	Reset time.Duration
+}
+
+// Limiter counts requests per client and budget with a sliding window,
+// estimated from the counters of the current and previous fixed windows.
+// Counters live in the cache, so that they are shared by every instance.
+type Limiter struct {
+	c     cache.Cache
+	rules map[string]Rule
+}
+
+func NewLimiter(
+	e *env.Env,
+	c cache.Cache,
+) *Limiter {
+	rules, err := parseRules(e.RateLimits)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Limiter{
+		c:     c,
+		rules: rules,
+	}
+}
+
+// Rule returns the rule of a budget for a plan. Plans without their own
+// rule use the rule of the budget, and budgets without a rule use the
+// default one.
+func (l *Limiter) Rule(budget Budget, plan string) (Rule, bool) {
+	for _, b := range []Budget{budget, BudgetDefault} {
+		if plan != "" {
+			if rule, ok := l.rules[ruleKey(b, plan)]; ok {
+				return rule, true
+			}
+		}
+		if rule, ok := l.rules[ruleKey(b, "")]; ok {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Allow counts a request of the client against a budget. Requests of
+// budgets without a rule are always allowed, and a nil result returned.
+func (l *Limiter) Allow(
+	ctx context.Context,
+	client string,
+	budget Budget,
+	plan string,
+) (*Result, error) {
+	rule, ok := l.Rule(budget, plan)
+	if !ok {
+		return nil, nil
+	}
+
+	now := time.Now()
+	window := now.Truncate(rule.Window)
+	elapsed := now.Sub(window)
+
+	var previous int64
+	_, err := l.c.Scan(
+		ctx,
+		key(client, budget, window.Add(-rule.Window)),
+		&previous,
+	)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	currentKey := key(client, budget, window)
+	current, err := l.c.Increment(ctx, currentKey, 1, 2*rule.Window)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	// The previous window counts as much as it overlaps with the sliding
+	// window ending now.
+	weight := 1 - float64(elapsed)/float64(rule.Window)
+	count := int64(math.Ceil(float64(previous)*weight)) + current
+
+	res := &Result{
+		Rule:      rule,
+		Allowed:   count <= rule.Limit,
+		Remaining: max(rule.Limit-count, 0),
+		Reset:     rule.Window - elapsed,
+	}
+
+	// Rejected requests are not counted, so that clients retrying too
+	// soon are not locked out for longer.
+	if !res.Allowed {
+		if _, err := l.c.Increment(ctx, currentKey, -1, 0); err != nil {
+			return nil, errs.New(err)
+		}
+	}
+
+	return res, nil
+}
+
+// ClientKeyPattern returns a cache key pattern matching every counter
+// of a client.
+func ClientKeyPattern(client string) string {
+	return keyPrefix + cache.EscapePattern(client) + ":*"
+}
+
+func key(client string, budget Budget, window time.Time) string {
+	return fmt.Sprintf("%s%s:%s:%d", keyPrefix, client, budget, window.Unix())
+}
+
+func ruleKey(budget Budget, plan string) string {
+	if plan == "" {
+		return string(budget)
+	}
+	return string(budget) + ":" + plan
+}
+
+// parseRules parses rules in the format BUDGET[:PLAN]=LIMIT/WINDOW,
+// comma separated.
+func parseRules(raw string) (map[string]Rule, error) {
+	rules := map[string]Rule{}
+
+	for entry := range strings.SplitSeq(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || name == "" {
+			return nil, errs.New(
+				fmt.Sprintf("invalid rate limit %q", entry),
+			)
+		}
+
+		limit, window, ok := strings.Cut(value, "/")
+		if !ok {
+			return nil, errs.New(
+				fmt.Sprintf("invalid rate limit %q", entry),
+			)
+		}
+
+		rule := Rule{}
+
+		var err error
+		rule.Limit, err = strconv.ParseInt(limit, 10, 64)
+		if err != nil || rule.Limit < 0 {
+			return nil, errs.New(
+				fmt.Sprintf("invalid rate limit %q", entry),
+			)
+		}
+
+		rule.Window, err = time.ParseDuration(window)
+		if err != nil || rule.Window <= 0 {
+			return nil, errs.New(
+				fmt.Sprintf("invalid rate limit %q", entry),
+			)
+		}
+
+		rules[strings.TrimSpace(name)] = rule
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,168 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/inmemorycache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	type Test struct {
+		name        string
+		budget      Budget
+		plan        string
+		requests    int
+		wantAllowed bool
+		wantResult  bool
+		wantLimit   int64
+	}
+
+	tests := []Test{
+		{
+			name:        "should allow requests within the limit",
+			budget:      BudgetSearch,
+			requests:    2,
+			wantAllowed: true,
+			wantResult:  true,
+			wantLimit:   2,
+		},
+		{
+			name:        "should deny requests over the limit",
+			budget:      BudgetSearch,
+			requests:    3,
+			wantAllowed: false,
+			wantResult:  true,
+			wantLimit:   2,
+		},
+		{
+			name:        "should use the limit of the plan",
+			budget:      BudgetSearch,
+			plan:        "pro",
+			requests:    3,
+			wantAllowed: true,
+			wantResult:  true,
+			wantLimit:   5,
+		},
+		{
+			name:        "should use the budget limit for unknown plans",
+			budget:      BudgetSearch,
+			plan:        "free",
+			requests:    3,
+			wantAllowed: false,
+			wantResult:  true,
+			wantLimit:   2,
+		},
+		{
+			name:       "should allow budgets without a rule",
+			budget:     BudgetLogin,
+			requests:   10,
+			wantResult: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &env.Env{
+				InMemoryCacheMaxEntries: 100,
+				RateLimits:              "search=2/1h,search:pro=5/1h",
+			}
+			l := NewLimiter(e, inmemorycache.NewInMemoryCache(e))
+
+			var got *Result
+			var err error
+			for range tt.requests {
+				got, err = l.Allow(context.Background(), "ip:10.0.0.1", tt.budget, tt.plan)
+				assert.Nil(t, err)
+			}
+
+			if !tt.wantResult {
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NotNil(t, got)
+			assert.Equal(t, tt.wantAllowed, got.Allowed)
+			assert.Equal(t, tt.wantLimit, got.Rule.Limit)
+			assert.LessOrEqual(t, got.Reset, time.Hour)
+		})
+	}
+}
+
+func TestLimiter_AllowDoesNotCountDenied(t *testing.T) {
+	e := &env.Env{
+		InMemoryCacheMaxEntries: 100,
+		RateLimits:              "default=1/1h",
+	}
+	l := NewLimiter(e, inmemorycache.NewInMemoryCache(e))
+	ctx := context.Background()
+
+	for range 3 {
+		_, err := l.Allow(ctx, "user:1", BudgetDefault, "")
+		assert.Nil(t, err)
+	}
+
+	var count int64
+	ok, err := l.c.Scan(
+		ctx,
+		key("user:1", BudgetDefault, time.Now().Truncate(time.Hour)),
+		&count,
+	)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    map[string]Rule
+		wantErr bool
+	}{
+		{
+			name: "should parse budgets and plans",
+			raw:  "default=60/1m, search:pro=200/30s",
+			want: map[string]Rule{
+				"default":    {Limit: 60, Window: time.Minute},
+				"search:pro": {Limit: 200, Window: 30 * time.Second},
+			},
+		},
+		{
+			name: "should parse empty rules",
+			raw:  "",
+			want: map[string]Rule{},
+		},
+		{
+			name:    "should fail without a window",
+			raw:     "search=20",
+			wantErr: true,
+		},
+		{
+			name:    "should fail with an invalid limit",
+			raw:     "search=many/1m",
+			wantErr: true,
+		},
+		{
+			name:    "should fail with an empty window",
+			raw:     "search=20/0s",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRules(tt.raw)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
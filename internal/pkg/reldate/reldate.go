@@ -0,0 +1,116 @@
+// Package reldate resolves dates written relative to the day they are
+// used, such as "+30d" or "next friday".
+package reldate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+)
+
+var weekdays = map[string]time.Weekday{}
+
+// maxOffsets bounds offsets, by unit, to about a year, as flights aren't
+// sold further ahead.
+var maxOffsets = map[byte]int{
+	'd': 366,
+	'w': 52,
+	'm': 12,
+}
+
+func init() {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		weekdays[strings.ToLower(d.String())] = d
+	}
+}
+
+// Parse returns the date the expression stands for on the day of now, at
+// midnight UTC. The expression, case insensitive, is one of:
+//   - a date, as 2006-01-02
+//   - today or tomorrow
+//   - +N followed by d, w or m, for N days, weeks or months later, up to
+//     a year, where months that are shorter end at their last day
+//   - next followed by a weekday, for the first such day after today
+func Parse(expr string, now time.Time) (time.Time, error) {
+	expr = strings.ToLower(strings.TrimSpace(expr))
+
+	y, m, d := now.UTC().Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	switch expr {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	}
+
+	if weekday, ok := strings.CutPrefix(expr, "next "); ok {
+		day, ok := weekdays[strings.TrimSpace(weekday)]
+		if !ok {
+			return time.Time{}, errs.New(
+				fmt.Sprintf("unknown weekday in %q", expr),
+			)
+		}
+
+		days := (int(day)-int(today.Weekday())+6)%7 + 1
+		return today.AddDate(0, 0, days), nil
+	}
+
+	if offset, ok := strings.CutPrefix(expr, "+"); ok && len(offset) > 1 {
+		unit := offset[len(offset)-1]
+		maxOffset, ok := maxOffsets[unit]
+		if !ok {
+			return time.Time{}, errs.New(
+				fmt.Sprintf("unknown unit in %q", expr),
+			)
+		}
+
+		n, err := strconv.Atoi(offset[:len(offset)-1])
+		if err != nil || n < 0 {
+			return time.Time{}, errs.New(
+				fmt.Sprintf("invalid offset in %q", expr),
+			)
+		}
+		if n > maxOffset {
+			return time.Time{}, errs.New(
+				fmt.Sprintf("offset in %q is more than a year", expr),
+			)
+		}
+
+		switch unit {
+		case 'd':
+			return today.AddDate(0, 0, n), nil
+		case 'w':
+			return today.AddDate(0, 0, 7*n), nil
+		default:
+			return addMonths(today, n), nil
+		}
+	}
+
+	date, err := time.Parse(time.DateOnly, expr)
+	if err != nil {
+		return time.Time{}, errs.New(fmt.Sprintf("invalid date %q", expr))
+	}
+
+	return date, nil
+}
+
+// addMonths adds n months to date, clamping the day to the last one of
+// the month, unlike time.AddDate, which rolls over into the next month.
+func addMonths(date time.Time, n int) time.Time {
+	y, m, d := date.Date()
+
+	first := time.Date(y, m+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+
+	return time.Date(
+		first.Year(),
+		first.Month(),
+		min(d, last),
+		0, 0, 0, 0,
+		time.UTC,
+	)
+}
@@ -0,0 +1,102 @@
+package reldate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	// A Wednesday, late enough that it is already Thursday east of UTC.
+	now := time.Date(2025, time.January, 15, 23, 0, 0, 0, time.UTC)
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		expr    string
+		want    time.Time
+		wantErr bool
+	}{
+		{expr: "2025-03-01", want: date(time.March, 1)},
+		{expr: "today", want: date(time.January, 15)},
+		{expr: " Tomorrow ", want: date(time.January, 16)},
+		{expr: "+0d", want: date(time.January, 15)},
+		{expr: "+30d", want: date(time.February, 14)},
+		{expr: "+2w", want: date(time.January, 29)},
+		{expr: "+1m", want: date(time.February, 15)},
+		{expr: "next Friday", want: date(time.January, 17)},
+		{expr: "next wednesday", want: date(time.January, 22)},
+		{expr: "next tuesday", want: date(time.January, 21)},
+		{expr: "next week", wantErr: true},
+		{expr: "+d", wantErr: true},
+		{expr: "+-1d", wantErr: true},
+		{expr: "+3y", wantErr: true},
+		{expr: "+366d", want: date(time.January, 16).AddDate(1, 0, 0)},
+		{expr: "+367d", wantErr: true},
+		{expr: "+53w", wantErr: true},
+		{expr: "+12m", want: date(time.January, 15).AddDate(1, 0, 0)},
+		{expr: "+13m", wantErr: true},
+		{expr: "+99999999999999999999d", wantErr: true},
+		{expr: "2025-13-01", wantErr: true},
+		{expr: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := Parse(tt.expr, now)
+
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("month end", func(t *testing.T) {
+		tests := []struct {
+			now  time.Time
+			expr string
+			want time.Time
+		}{
+			{
+				now:  time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC),
+				expr: "+1m",
+				want: time.Date(2025, time.February, 28, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				now:  time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC),
+				expr: "+1m",
+				want: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				now:  time.Date(2025, time.August, 31, 0, 0, 0, 0, time.UTC),
+				expr: "+1m",
+				want: time.Date(2025, time.September, 30, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				now:  time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC),
+				expr: "+2m",
+				want: time.Date(2026, time.February, 28, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				now:  time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC),
+				expr: "+12m",
+				want: time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC),
+			},
+		}
+		for _, tt := range tests {
+			got, err := Parse(tt.expr, tt.now)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got, tt.now)
+		}
+	})
+
+	eastern := now.In(time.FixedZone("UTC+3", 3*60*60))
+	got, err := Parse("today", eastern)
+	assert.Nil(t, err)
+	assert.Equal(t, date(time.January, 15), got, "should use the UTC day")
+}
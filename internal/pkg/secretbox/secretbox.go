@@ -0,0 +1,107 @@
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+)
+
+// keySize is the size of AES-256 keys, in bytes.
+const keySize = 32
+
+// Box encrypts secrets stored at rest with AES-256-GCM, under the key
+// in PROVIDER_CREDENTIALS_KEY. Sealed secrets start with their random
+// nonce, and are bound to the additional data they were sealed with.
+type Box struct {
+	aead cipher.AEAD
+}
+
+func New(e *env.Env) *Box {
+	if e.ProviderCredentialsKey == "" {
+		return &Box{}
+	}
+
+	aead, err := newAEAD(e.ProviderCredentialsKey)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Box{
+		aead: aead,
+	}
+}
+
+// Configured reports whether the box has a key to seal and open secrets.
+func (b *Box) Configured() bool {
+	return b.aead != nil
+}
+
+func newAEAD(key string) (cipher.AEAD, error) {
+	rawKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	if len(rawKey) != keySize {
+		return nil, errs.New(fmt.Sprintf(
+			"secret box key must have %d bytes, got %d",
+			keySize,
+			len(rawKey),
+		))
+	}
+
+	block, err := aes.NewCipher(rawKey)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return aead, nil
+}
+
+// Seal encrypts plaintext, authenticating additionalData along with it.
+func (b *Box) Seal(plaintext, additionalData []byte) ([]byte, error) {
+	if b.aead == nil {
+		return nil, errs.New("secret box key is not configured")
+	}
+
+	nonce := make([]byte, b.aead.NonceSize(), b.aead.NonceSize()+
+		len(plaintext)+b.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return b.aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// Open decrypts a secret sealed with the same additional data.
+func (b *Box) Open(sealed, additionalData []byte) ([]byte, error) {
+	if b.aead == nil {
+		return nil, errs.New("secret box key is not configured")
+	}
+
+	nonceSize := b.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, errs.New("sealed secret is too short")
+	}
+
+	plaintext, err := b.aead.Open(
+		nil,
+		sealed[:nonceSize],
+		sealed[nonceSize:],
+		additionalData,
+	)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,39 @@
+package secretbox
+
+import (
+	"testing"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/stretchr/testify/assert"
+)
+
+const testKey = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+func TestBox(t *testing.T) {
+	b := New(&env.Env{ProviderCredentialsKey: testKey})
+
+	sealed, err := b.Seal([]byte("secret"), []byte("org:duffel"))
+	assert.Nil(t, err)
+	assert.NotContains(t, string(sealed), "secret")
+
+	other, err := b.Seal([]byte("secret"), []byte("org:duffel"))
+	assert.Nil(t, err)
+	assert.NotEqual(t, sealed, other)
+
+	plaintext, err := b.Open(sealed, []byte("org:duffel"))
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", string(plaintext))
+
+	_, err = b.Open(sealed, []byte("org:amadeus"))
+	assert.NotNil(t, err)
+
+	_, err = b.Open(sealed[:4], []byte("org:duffel"))
+	assert.NotNil(t, err)
+
+	_, err = New(&env.Env{}).Seal([]byte("secret"), nil)
+	assert.NotNil(t, err)
+
+	assert.Panics(t, func() {
+		New(&env.Env{ProviderCredentialsKey: "c2hvcnQ="})
+	})
+}
@@ -0,0 +1,20 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+)
+
+// AuditSink appends audit events somewhere they can't be changed by the
+// API, and queries them back.
+type AuditSink interface {
+	Record(ctx context.Context, event entity.AuditEvent) error
+
+	// Query returns the events selected by the filter, newest first, up
+	// to its limit if it has one.
+	Query(
+		ctx context.Context,
+		filter entity.AuditEventFilter,
+	) ([]entity.AuditEvent, error)
+}
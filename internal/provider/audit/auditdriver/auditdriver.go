@@ -0,0 +1,24 @@
+package auditdriver
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/provider/audit"
+	"github.com/danielmesquitta/flight-api/internal/provider/audit/dbaudit"
+	"github.com/danielmesquitta/flight-api/internal/provider/audit/fileaudit"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+// NewAuditSink returns the audit sink implementation selected by
+// AUDIT_DRIVER.
+func NewAuditSink(
+	e *env.Env,
+	r repo.AuditRepository,
+) audit.AuditSink {
+	switch e.AuditDriver {
+	case env.AuditDriverFile:
+		return fileaudit.NewFileSink(e)
+
+	default:
+		return dbaudit.NewDBSink(r)
+	}
+}
@@ -0,0 +1,48 @@
+package dbaudit
+
+import (
+	"context"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/audit"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+// DBSink appends audit events to the database users are stored in.
+type DBSink struct {
+	r repo.AuditRepository
+}
+
+func NewDBSink(
+	r repo.AuditRepository,
+) *DBSink {
+	return &DBSink{
+		r: r,
+	}
+}
+
+func (d *DBSink) Record(
+	ctx context.Context,
+	event entity.AuditEvent,
+) error {
+	if err := d.r.CreateAuditEvent(ctx, event); err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (d *DBSink) Query(
+	ctx context.Context,
+	filter entity.AuditEventFilter,
+) ([]entity.AuditEvent, error) {
+	events, err := d.r.ListAuditEvents(ctx, filter)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return events, nil
+}
+
+var _ audit.AuditSink = (*DBSink)(nil)
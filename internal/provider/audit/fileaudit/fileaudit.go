@@ -0,0 +1,106 @@
+package fileaudit
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/audit"
+)
+
+// FileSink appends audit events as JSON lines to a file, to be shipped
+// to a log pipeline. Queries read the whole file, so they get slower as
+// it grows until it is rotated.
+type FileSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileSink(
+	e *env.Env,
+) *FileSink {
+	return &FileSink{
+		path: e.AuditFilePath,
+	}
+}
+
+func (f *FileSink) Record(
+	_ context.Context,
+	event entity.AuditEvent,
+) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(
+		f.path,
+		os.O_APPEND|os.O_CREATE|os.O_WRONLY,
+		0o600,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (f *FileSink) Query(
+	ctx context.Context,
+	filter entity.AuditEventFilter,
+) ([]entity.AuditEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.Open(f.path)
+	if os.IsNotExist(err) {
+		return []entity.AuditEvent{}, nil
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	defer file.Close()
+
+	events := []entity.AuditEvent{}
+	decoder := json.NewDecoder(file)
+	for decoder.More() {
+		if err := ctx.Err(); err != nil {
+			return nil, errs.New(err)
+		}
+
+		var event entity.AuditEvent
+		if err := decoder.Decode(&event); err != nil {
+			return nil, errs.New(err)
+		}
+		if filter.Match(event) {
+			events = append(events, event)
+		}
+	}
+
+	// Events are appended in order, so the newest are last.
+	slices.Reverse(events)
+	slices.SortStableFunc(events, func(a, b entity.AuditEvent) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[:filter.Limit]
+	}
+
+	return events, nil
+}
+
+var _ audit.AuditSink = (*FileSink)(nil)
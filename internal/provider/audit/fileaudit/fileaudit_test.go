@@ -0,0 +1,67 @@
+package fileaudit
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSink(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	f := NewFileSink(&env.Env{AuditFilePath: path})
+
+	events, err := f.Query(ctx, entity.AuditEventFilter{})
+	assert.Nil(t, err)
+	assert.Empty(t, events)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	recorded := []entity.AuditEvent{
+		{
+			ID:        "1",
+			Actor:     "email:johndoe@email.com",
+			Action:    entity.AuditActionLogin,
+			Outcome:   entity.AuditOutcomeFailure,
+			Reason:    "invalid credentials",
+			CreatedAt: now,
+		},
+		{
+			ID:        "2",
+			Actor:     "user:1",
+			Action:    entity.AuditActionLogin,
+			Outcome:   entity.AuditOutcomeSuccess,
+			CreatedAt: now,
+		},
+		{
+			ID:        "3",
+			Actor:     "admin:admin",
+			Action:    entity.AuditActionCachePurge,
+			Outcome:   entity.AuditOutcomeSuccess,
+			CreatedAt: now.Add(time.Second),
+		},
+	}
+	for _, event := range recorded {
+		assert.Nil(t, f.Record(ctx, event))
+	}
+
+	events, err = f.Query(ctx, entity.AuditEventFilter{})
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		[]entity.AuditEvent{recorded[2], recorded[1], recorded[0]},
+		events,
+		"should list newest first",
+	)
+
+	events, err = f.Query(ctx, entity.AuditEventFilter{
+		Action: entity.AuditActionLogin,
+		Limit:  1,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []entity.AuditEvent{recorded[1]}, events)
+}
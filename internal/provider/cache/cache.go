@@ -2,6 +2,8 @@ package cache
 
 import (
 	"context"
+	"iter"
+	"strings"
 	"time"
 )
 
@@ -19,4 +21,105 @@ type Cache interface {
 		ctx context.Context,
 		keys ...string,
 	) error
+
+	// Take atomically reads and deletes the value stored at key, so that
+	// only one of many concurrent callers gets it.
+	Take(ctx context.Context, key string, value any) (ok bool, err error)
+
+	// Increment atomically adds value to the integer stored at key and
+	// returns the result. The expiration is only applied when the key
+	// is created by this call.
+	Increment(
+		ctx context.Context,
+		key string,
+		value int64,
+		expiration time.Duration,
+	) (int64, error)
+
+	// Keys iterates over the keys matching a glob-style pattern, such as
+	// "flight:*", in no particular order. Keys are read in batches, so
+	// that the cache is never blocked while iterating.
+	Keys(ctx context.Context, pattern string) iter.Seq2[KeyInfo, error]
+}
+
+// KeyInfo describes a stored key. A zero TTL means the key never
+// expires. Size is the memory used by the key, in bytes.
+type KeyInfo struct {
+	Key  string
+	TTL  time.Duration
+	Size int64
+}
+
+var patternReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	`*`, `\*`,
+	`?`, `\?`,
+	`[`, `\[`,
+	`]`, `\]`,
+)
+
+// EscapePattern escapes s to be matched literally by a Keys pattern.
+func EscapePattern(s string) string {
+	return patternReplacer.Replace(s)
+}
+
+// StatsReporter is implemented by caches that keep hit and miss counters.
+type StatsReporter interface {
+	Stats() Stats
+}
+
+type Stats struct {
+	Tiers []TierStats `json:"tiers"`
+}
+
+type TierStats struct {
+	Name     string  `json:"name"`
+	Hits     int64   `json:"hits"`
+	Misses   int64   `json:"misses"`
+	HitRatio float64 `json:"hit_ratio"`
+}
+
+// Locker is implemented by caches that can hold locks shared by every
+// instance using them.
+type Locker interface {
+	// Lock acquires the lock at key for ttl, unless it is already held.
+	// The returned token must be given back to Unlock.
+	Lock(
+		ctx context.Context,
+		key string,
+		ttl time.Duration,
+	) (token string, ok bool, err error)
+
+	// Unlock releases the lock at key, if it is still held with token.
+	Unlock(ctx context.Context, key string, token string) error
+
+	// Extend sets the ttl of the lock at key again, and reports whether
+	// it is still held with token.
+	Extend(
+		ctx context.Context,
+		key string,
+		token string,
+		ttl time.Duration,
+	) (ok bool, err error)
+}
+
+// Ranker is implemented by caches that can keep members ranked by score.
+type Ranker interface {
+	// IncrementScore adds value to the score of member in the ranking at
+	// key. The expiration is refreshed on every call.
+	IncrementScore(
+		ctx context.Context,
+		key string,
+		member string,
+		value float64,
+		expiration time.Duration,
+	) error
+
+	// TopScores returns the n members with the highest scores at key.
+	TopScores(ctx context.Context, key string, n int) ([]Score, error)
+}
+
+type Score struct {
+	Member string  `json:"member"`
+	Score  float64 `json:"score"`
 }
@@ -0,0 +1,27 @@
+package cachedriver
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/inmemorycache"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/rediscache"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/tieredcache"
+)
+
+// NewCache returns the cache implementation selected by CACHE_DRIVER.
+// Only the selected implementation is built, so Redis is never
+// reached when running in memory.
+func NewCache(
+	e *env.Env,
+) cache.Cache {
+	switch e.CacheDriver {
+	case env.CacheDriverInMemory:
+		return inmemorycache.NewInMemoryCache(e)
+
+	case env.CacheDriverTiered:
+		return tieredcache.NewTieredCache(e)
+
+	default:
+		return rediscache.NewRedisCache(e)
+	}
+}
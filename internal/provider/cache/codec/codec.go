@@ -0,0 +1,183 @@
+package codec
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+)
+
+// Structured values are stored behind a header naming how they were
+// encoded, so that entries written before the codec or the compression
+// changed can still be read:
+//
+//	magic (2 bytes) | version | format | compression | payload
+//
+// Entries without the header are read as JSON, as they were stored
+// before the header existed.
+var magic = []byte{0x00, 0xca}
+
+const (
+	version    byte = 1
+	headerSize      = 5
+)
+
+type format byte
+
+const (
+	formatJSON format = iota + 1
+	formatMsgpack
+	formatGob
+)
+
+type compression byte
+
+const (
+	compressionNone compression = iota
+	compressionZstd
+	compressionSnappy
+)
+
+var encoders = map[format]Encoder{
+	formatJSON:    JSONEncoder{},
+	formatMsgpack: MsgpackEncoder{},
+	formatGob:     GobEncoder{},
+}
+
+var formats = map[env.CacheCodec]format{
+	env.CacheCodecJSON:    formatJSON,
+	env.CacheCodecMsgpack: formatMsgpack,
+	env.CacheCodecGob:     formatGob,
+}
+
+var compressions = map[env.CacheCompression]compression{
+	env.CacheCompressionNone:   compressionNone,
+	env.CacheCompressionZstd:   compressionZstd,
+	env.CacheCompressionSnappy: compressionSnappy,
+}
+
+// Codec encodes cache values with the configured format, compressing
+// the ones above the threshold. Strings, bytes, numbers and booleans are
+// stored as plain text, as by cache.Marshal, so that they can still be
+// incremented and read by other clients.
+type Codec struct {
+	format      format
+	compression compression
+	threshold   int
+
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+}
+
+func NewCodec(
+	e *env.Env,
+) *Codec {
+	zstdEncoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	zstdDecoder, err := zstd.NewReader(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Codec{
+		format:      formats[e.CacheCodec],
+		compression: compressions[e.CacheCompression],
+		threshold:   e.CacheCompressionThreshold,
+		zstdEncoder: zstdEncoder,
+		zstdDecoder: zstdDecoder,
+	}
+}
+
+func (c *Codec) Marshal(value any) ([]byte, error) {
+	if isPlain(value) {
+		return cache.Marshal(value)
+	}
+
+	payload, err := encoders[c.format].Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	comp := compressionNone
+	if len(payload) > c.threshold {
+		comp = c.compression
+	}
+
+	switch comp {
+	case compressionZstd:
+		payload = c.zstdEncoder.EncodeAll(payload, nil)
+	case compressionSnappy:
+		payload = snappy.Encode(nil, payload)
+	}
+
+	data := make([]byte, 0, headerSize+len(payload))
+	data = append(data, magic...)
+	data = append(data, version, byte(c.format), byte(comp))
+	data = append(data, payload...)
+
+	return data, nil
+}
+
+// Unmarshal decodes data stored by Marshal into value, which must be a
+// pointer, whatever the codec and compression it was stored with.
+func (c *Codec) Unmarshal(data []byte, value any) error {
+	if isPlain(value) || !bytes.HasPrefix(data, magic) {
+		return cache.Unmarshal(data, value)
+	}
+
+	if len(data) < headerSize {
+		return errs.New("cache entry header is truncated")
+	}
+	if data[2] != version {
+		return errs.New(
+			fmt.Sprintf("unknown cache entry version %d", data[2]),
+		)
+	}
+
+	encoder, ok := encoders[format(data[3])]
+	if !ok {
+		return errs.New(
+			fmt.Sprintf("unknown cache entry format %d", data[3]),
+		)
+	}
+
+	payload := data[headerSize:]
+
+	var err error
+	switch compression(data[4]) {
+	case compressionNone:
+	case compressionZstd:
+		payload, err = c.zstdDecoder.DecodeAll(payload, nil)
+	case compressionSnappy:
+		payload, err = snappy.Decode(nil, payload)
+	default:
+		return errs.New(
+			fmt.Sprintf("unknown cache entry compression %d", data[4]),
+		)
+	}
+	if err != nil {
+		return err
+	}
+
+	return encoder.Unmarshal(payload, value)
+}
+
+// isPlain reports whether value, or the value it points to, is stored
+// as plain text.
+func isPlain(value any) bool {
+	switch value.(type) {
+	case string, []byte, int, int64, float64, bool,
+		*string, *[]byte, *int, *int64, *float64, *bool:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,118 @@
+package codec
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/stretchr/testify/assert"
+)
+
+type value struct {
+	Name      string    `json:"name"`
+	Tags      []string  `json:"tags"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func TestCodec_RoundTrip(t *testing.T) {
+	want := value{
+		Name:      strings.Repeat("flight", 100),
+		Tags:      []string{"a", "b"},
+		CreatedAt: time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	cacheCodecs := []env.CacheCodec{
+		env.CacheCodecJSON,
+		env.CacheCodecMsgpack,
+		env.CacheCodecGob,
+	}
+	cacheCompressions := []env.CacheCompression{
+		env.CacheCompressionNone,
+		env.CacheCompressionZstd,
+		env.CacheCompressionSnappy,
+	}
+
+	for _, cc := range cacheCodecs {
+		for _, comp := range cacheCompressions {
+			t.Run(string(cc)+"/"+string(comp), func(t *testing.T) {
+				c := NewCodec(&env.Env{
+					CacheCodec:                cc,
+					CacheCompression:          comp,
+					CacheCompressionThreshold: 64,
+				})
+
+				data, err := c.Marshal(want)
+				assert.Nil(t, err)
+				assert.Equal(t, byte(formats[cc]), data[3])
+				assert.Equal(t, byte(compressions[comp]), data[4])
+
+				got := value{}
+				assert.Nil(t, c.Unmarshal(data, &got))
+				assert.True(t, want.CreatedAt.Equal(got.CreatedAt))
+				got.CreatedAt = want.CreatedAt
+				assert.Equal(t, want, got)
+			})
+		}
+	}
+}
+
+func TestCodec_ReadsOtherConfigurations(t *testing.T) {
+	want := value{Name: strings.Repeat("flight", 100)}
+
+	old := NewCodec(&env.Env{
+		CacheCodec:                env.CacheCodecGob,
+		CacheCompression:          env.CacheCompressionZstd,
+		CacheCompressionThreshold: 64,
+	})
+	data, err := old.Marshal(want)
+	assert.Nil(t, err)
+
+	c := NewCodec(&env.Env{
+		CacheCodec:       env.CacheCodecMsgpack,
+		CacheCompression: env.CacheCompressionNone,
+	})
+
+	got := value{}
+	assert.Nil(t, c.Unmarshal(data, &got))
+	assert.Equal(t, want, got)
+
+	legacy, err := json.Marshal(want)
+	assert.Nil(t, err)
+
+	got = value{}
+	assert.Nil(t, c.Unmarshal(legacy, &got), "should read entries without header")
+	assert.Equal(t, want, got)
+}
+
+func TestCodec_PlainValues(t *testing.T) {
+	c := NewCodec(&env.Env{
+		CacheCodec:       env.CacheCodecMsgpack,
+		CacheCompression: env.CacheCompressionZstd,
+	})
+
+	data, err := c.Marshal(int64(42))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("42"), data)
+
+	var n int64
+	assert.Nil(t, c.Unmarshal(data, &n))
+	assert.Equal(t, int64(42), n)
+
+	data, err = c.Marshal("value")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), data)
+}
+
+func TestCodec_SkipsCompressionBelowThreshold(t *testing.T) {
+	c := NewCodec(&env.Env{
+		CacheCodec:                env.CacheCodecJSON,
+		CacheCompression:          env.CacheCompressionZstd,
+		CacheCompressionThreshold: 1024,
+	})
+
+	data, err := c.Marshal(value{Name: "small"})
+	assert.Nil(t, err)
+	assert.Equal(t, byte(compressionNone), data[4])
+}
@@ -0,0 +1,67 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Encoder serializes structured values.
+type Encoder interface {
+	Marshal(value any) ([]byte, error)
+	Unmarshal(data []byte, value any) error
+}
+
+type JSONEncoder struct{}
+
+func (JSONEncoder) Marshal(value any) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (JSONEncoder) Unmarshal(data []byte, value any) error {
+	return json.Unmarshal(data, value)
+}
+
+// MsgpackEncoder reads the json struct tags, so that values are encoded
+// with the same field names as JSONEncoder.
+type MsgpackEncoder struct{}
+
+func (MsgpackEncoder) Marshal(value any) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	enc := msgpack.NewEncoder(buf)
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (MsgpackEncoder) Unmarshal(data []byte, value any) error {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(value)
+}
+
+type GobEncoder struct{}
+
+func (GobEncoder) Marshal(value any) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (GobEncoder) Unmarshal(data []byte, value any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(value)
+}
+
+var (
+	_ Encoder = JSONEncoder{}
+	_ Encoder = MsgpackEncoder{}
+	_ Encoder = GobEncoder{}
+)
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// Marshal encodes a value the way it is stored by the cache
+// implementations. Strings, bytes, numbers and booleans are stored
+// as their plain text representation and anything else as JSON.
+func Marshal(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case int:
+		return strconv.AppendInt(nil, int64(v), 10), nil
+	case int64:
+		return strconv.AppendInt(nil, v, 10), nil
+	case float64:
+		return strconv.AppendFloat(nil, v, 'f', -1, 64), nil
+	case bool:
+		if v {
+			return []byte("1"), nil
+		}
+		return []byte("0"), nil
+	default:
+		return json.Marshal(value)
+	}
+}
+
+// Unmarshal decodes raw data stored by Marshal into value, which must
+// be a pointer.
+func Unmarshal(raw []byte, value any) error {
+	switch v := value.(type) {
+	case *string:
+		*v = string(raw)
+	case *[]byte:
+		*v = raw
+	case *int:
+		i, err := strconv.Atoi(string(raw))
+		if err != nil {
+			return err
+		}
+		*v = i
+	case *int64:
+		i, err := strconv.ParseInt(string(raw), 10, 64)
+		if err != nil {
+			return err
+		}
+		*v = i
+	case *float64:
+		f, err := strconv.ParseFloat(string(raw), 64)
+		if err != nil {
+			return err
+		}
+		*v = f
+	case *bool:
+		b, err := strconv.ParseBool(string(raw))
+		if err != nil {
+			return err
+		}
+		*v = b
+	default:
+		if err := json.Unmarshal(raw, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,468 @@
+package inmemorycache
+
+import (
+	"cmp"
+	"container/list"
+	"context"
+	"encoding/json"
+	"iter"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+)
+
+// pinnedPrefixes are the prefixes of the keys that are never evicted,
+// holding state such as revoked tokens, login failures and rate limits,
+// which would be reset if evicted, rather than results that can be
+// searched again.
+var pinnedPrefixes = []string{
+	"auth:",
+	"ratelimit:",
+	"flightapi:tenant:",
+	"flightapi:usage:",
+	"pricealert:",
+	"flight:warmer:",
+}
+
+// minSweepEntries is the number of pinned entries from which the expired
+// ones are swept.
+const minSweepEntries = 1024
+
+// InMemoryCache is an in-process cache, bounded in size by evicting
+// the least recently used entries. Pinned entries aren't evicted, nor
+// counted against the bound, and are swept once expired instead. It
+// stores values the same way as RedisCache, so both can be used
+// interchangeably.
+type InMemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	lru        *list.List
+	pinned     *list.List
+	sweepAt    int
+}
+
+type entry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+	pinned    bool
+}
+
+func isPinned(key string) bool {
+	for _, prefix := range pinnedPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *entry) isExpired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+func NewInMemoryCache(
+	e *env.Env,
+) *InMemoryCache {
+	return &InMemoryCache{
+		maxEntries: e.InMemoryCacheMaxEntries,
+		entries:    map[string]*list.Element{},
+		lru:        list.New(),
+		pinned:     list.New(),
+		sweepAt:    minSweepEntries,
+	}
+}
+
+func (m *InMemoryCache) Scan(
+	_ context.Context,
+	key string,
+	value any,
+) (bool, error) {
+	m.mu.Lock()
+	raw, ok := m.get(key)
+	m.mu.Unlock()
+
+	if !ok {
+		return false, nil
+	}
+
+	// Copy the data, so that callers can't modify the stored entry.
+	raw = append([]byte(nil), raw...)
+
+	if err := cache.Unmarshal(raw, value); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (m *InMemoryCache) Set(
+	_ context.Context,
+	key string,
+	value any,
+	expiration time.Duration,
+) error {
+	data, err := cache.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	// Copy the data, since byte slices are stored as they are given.
+	data = append([]byte(nil), data...)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.set(key, data, expiration)
+
+	return nil
+}
+
+func (m *InMemoryCache) Delete(
+	_ context.Context,
+	keys ...string,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := m.entries[key]; ok {
+			m.remove(el)
+		}
+	}
+
+	return nil
+}
+
+func (m *InMemoryCache) Take(
+	_ context.Context,
+	key string,
+	value any,
+) (bool, error) {
+	m.mu.Lock()
+	raw, ok := m.get(key)
+	if ok {
+		m.remove(m.entries[key])
+	}
+	m.mu.Unlock()
+
+	if !ok {
+		return false, nil
+	}
+
+	if err := cache.Unmarshal(raw, value); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (m *InMemoryCache) Increment(
+	_ context.Context,
+	key string,
+	value int64,
+	expiration time.Duration,
+) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	raw, ok := m.get(key)
+	if !ok {
+		m.set(key, strconv.AppendInt(nil, value, 10), expiration)
+		return value, nil
+	}
+
+	n, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	n += value
+
+	el := m.entries[key]
+	el.Value.(*entry).value = strconv.AppendInt(nil, n, 10)
+
+	return n, nil
+}
+
+// Keys matches keys with path.Match, which supports the same patterns as
+// Redis, except for character ranges being negated with ^.
+func (m *InMemoryCache) Keys(
+	_ context.Context,
+	pattern string,
+) iter.Seq2[cache.KeyInfo, error] {
+	return func(yield func(cache.KeyInfo, error) bool) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			yield(cache.KeyInfo{}, err)
+			return
+		}
+
+		for _, info := range m.keyInfos(pattern) {
+			if !yield(info, nil) {
+				return
+			}
+		}
+	}
+}
+
+// keyInfos returns a snapshot of the keys matching pattern, so that the
+// lock isn't held while iterating.
+func (m *InMemoryCache) keyInfos(pattern string) []cache.KeyInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	var infos []cache.KeyInfo
+	for key, el := range m.entries {
+		e := el.Value.(*entry)
+		if e.isExpired(now) {
+			continue
+		}
+		if ok, _ := path.Match(pattern, key); !ok {
+			continue
+		}
+
+		info := cache.KeyInfo{
+			Key:  key,
+			Size: int64(len(e.value)),
+		}
+		if !e.expiresAt.IsZero() {
+			info.TTL = e.expiresAt.Sub(now)
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+func (m *InMemoryCache) Lock(
+	_ context.Context,
+	key string,
+	ttl time.Duration,
+) (string, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.get(key); ok {
+		return "", false, nil
+	}
+
+	token := uuid.NewString()
+	m.set(key, []byte(token), ttl)
+
+	return token, true, nil
+}
+
+func (m *InMemoryCache) Unlock(
+	_ context.Context,
+	key string,
+	token string,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if raw, ok := m.get(key); ok && string(raw) == token {
+		m.remove(m.entries[key])
+	}
+
+	return nil
+}
+
+func (m *InMemoryCache) Extend(
+	_ context.Context,
+	key string,
+	token string,
+	ttl time.Duration,
+) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	raw, ok := m.get(key)
+	if !ok || string(raw) != token {
+		return false, nil
+	}
+
+	m.set(key, raw, ttl)
+
+	return true, nil
+}
+
+// IncrementScore keeps the ranking as a JSON object of member scores.
+func (m *InMemoryCache) IncrementScore(
+	_ context.Context,
+	key string,
+	member string,
+	value float64,
+	expiration time.Duration,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	scores, err := m.getScores(key)
+	if err != nil {
+		return err
+	}
+	scores[member] += value
+
+	data, err := json.Marshal(scores)
+	if err != nil {
+		return err
+	}
+
+	m.set(key, data, expiration)
+
+	return nil
+}
+
+func (m *InMemoryCache) TopScores(
+	_ context.Context,
+	key string,
+	n int,
+) ([]cache.Score, error) {
+	m.mu.Lock()
+	scores, err := m.getScores(key)
+	m.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	top := make([]cache.Score, 0, len(scores))
+	for member, score := range scores {
+		top = append(top, cache.Score{
+			Member: member,
+			Score:  score,
+		})
+	}
+
+	slices.SortFunc(top, func(a, b cache.Score) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Member, b.Member)
+	})
+
+	return top[:min(max(n, 0), len(top))], nil
+}
+
+// getScores returns the ranking stored at key. The caller must hold the
+// lock.
+func (m *InMemoryCache) getScores(key string) (map[string]float64, error) {
+	scores := map[string]float64{}
+
+	raw, ok := m.get(key)
+	if !ok {
+		return scores, nil
+	}
+
+	if err := json.Unmarshal(raw, &scores); err != nil {
+		return nil, err
+	}
+
+	return scores, nil
+}
+
+// get returns the value stored at key, marking it as recently used.
+// The caller must hold the lock.
+func (m *InMemoryCache) get(key string) ([]byte, bool) {
+	el, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := el.Value.(*entry)
+	if e.isExpired(time.Now()) {
+		m.remove(el)
+		return nil, false
+	}
+
+	if !e.pinned {
+		m.lru.MoveToFront(el)
+	}
+
+	return e.value, true
+}
+
+// set stores value at key, evicting the least recently used entries
+// if the cache is full. The caller must hold the lock.
+func (m *InMemoryCache) set(
+	key string,
+	value []byte,
+	expiration time.Duration,
+) {
+	var expiresAt time.Time
+	if expiration > 0 {
+		expiresAt = time.Now().Add(expiration)
+	}
+
+	if el, ok := m.entries[key]; ok {
+		e := el.Value.(*entry)
+		e.value = value
+		e.expiresAt = expiresAt
+		if !e.pinned {
+			m.lru.MoveToFront(el)
+		}
+		return
+	}
+
+	e := &entry{
+		key:       key,
+		value:     value,
+		expiresAt: expiresAt,
+		pinned:    isPinned(key),
+	}
+
+	if e.pinned {
+		m.entries[key] = m.pinned.PushFront(e)
+		if m.pinned.Len() >= m.sweepAt {
+			m.sweep()
+		}
+		return
+	}
+
+	m.entries[key] = m.lru.PushFront(e)
+
+	for m.maxEntries > 0 && m.lru.Len() > m.maxEntries {
+		m.remove(m.lru.Back())
+	}
+}
+
+// sweep removes the expired pinned entries, and sweeps again once their
+// number doubled. The caller must hold the lock.
+func (m *InMemoryCache) sweep() {
+	now := time.Now()
+	for el := m.pinned.Front(); el != nil; {
+		next := el.Next()
+		if el.Value.(*entry).isExpired(now) {
+			m.remove(el)
+		}
+		el = next
+	}
+
+	m.sweepAt = max(2*m.pinned.Len(), minSweepEntries)
+}
+
+func (m *InMemoryCache) remove(el *list.Element) {
+	e := el.Value.(*entry)
+	if e.pinned {
+		m.pinned.Remove(el)
+	} else {
+		m.lru.Remove(el)
+	}
+	delete(m.entries, e.key)
+}
+
+var (
+	_ cache.Cache  = (*InMemoryCache)(nil)
+	_ cache.Locker = (*InMemoryCache)(nil)
+	_ cache.Ranker = (*InMemoryCache)(nil)
+)
@@ -0,0 +1,277 @@
+package inmemorycache
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/pkg/jwtutil"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryCache_ScanTypes(t *testing.T) {
+	ctx := context.Background()
+	m := NewInMemoryCache(&env.Env{InMemoryCacheMaxEntries: 10})
+
+	type object struct {
+		Name string `json:"name"`
+	}
+
+	assert.Nil(t, m.Set(ctx, "string", "value", 0))
+	assert.Nil(t, m.Set(ctx, "int", 42, 0))
+	assert.Nil(t, m.Set(ctx, "bool", true, 0))
+	assert.Nil(t, m.Set(ctx, "object", object{Name: "name"}, 0))
+
+	var s string
+	ok, err := m.Scan(ctx, "string", &s)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.Equal(t, "value", s)
+
+	var i int64
+	ok, err = m.Scan(ctx, "int", &i)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(42), i)
+
+	var b bool
+	ok, err = m.Scan(ctx, "bool", &b)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.True(t, b)
+
+	var o object
+	ok, err = m.Scan(ctx, "object", &o)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.Equal(t, object{Name: "name"}, o)
+
+	ok, err = m.Scan(ctx, "object", &i)
+	assert.False(t, ok)
+	assert.NotNil(t, err)
+
+	ok, err = m.Scan(ctx, "missing", &s)
+	assert.False(t, ok)
+	assert.Nil(t, err)
+}
+
+func TestInMemoryCache_Expiration(t *testing.T) {
+	ctx := context.Background()
+	m := NewInMemoryCache(&env.Env{InMemoryCacheMaxEntries: 10})
+
+	assert.Nil(t, m.Set(ctx, "key", "value", time.Millisecond))
+	time.Sleep(2 * time.Millisecond)
+
+	var s string
+	ok, err := m.Scan(ctx, "key", &s)
+	assert.False(t, ok)
+	assert.Nil(t, err)
+}
+
+func TestInMemoryCache_Eviction(t *testing.T) {
+	ctx := context.Background()
+	m := NewInMemoryCache(&env.Env{InMemoryCacheMaxEntries: 2})
+
+	assert.Nil(t, m.Set(ctx, "a", "a", 0))
+	assert.Nil(t, m.Set(ctx, "b", "b", 0))
+
+	var s string
+	ok, _ := m.Scan(ctx, "a", &s)
+	assert.True(t, ok)
+
+	assert.Nil(t, m.Set(ctx, "c", "c", 0))
+
+	ok, _ = m.Scan(ctx, "b", &s)
+	assert.False(t, ok, "least recently used entry should be evicted")
+	ok, _ = m.Scan(ctx, "a", &s)
+	assert.True(t, ok)
+	ok, _ = m.Scan(ctx, "c", &s)
+	assert.True(t, ok)
+}
+
+func TestInMemoryCache_PinnedEntries(t *testing.T) {
+	ctx := context.Background()
+	m := NewInMemoryCache(&env.Env{InMemoryCacheMaxEntries: 2})
+	d := jwtutil.NewDenylist(m)
+
+	claims := &jwtutil.UserClaims{}
+	claims.ID = "token"
+	claims.ExpiresAt = time.Now().Add(time.Hour)
+	assert.Nil(t, d.Revoke(ctx, claims))
+
+	for i := range 100 {
+		key := "flight:search:" + strconv.Itoa(i)
+		assert.Nil(t, m.Set(ctx, key, "flights", 0))
+	}
+
+	revoked, err := d.IsRevoked(ctx, claims)
+	assert.Nil(t, err)
+	assert.True(t, revoked, "revoked token should not be evicted")
+
+	var s string
+	ok, _ := m.Scan(ctx, "flight:search:0", &s)
+	assert.False(t, ok, "least recently used entry should be evicted")
+
+	for i := range minSweepEntries {
+		key := "auth:login:failures:expired:" + strconv.Itoa(i)
+		assert.Nil(t, m.Set(ctx, key, i, time.Millisecond))
+	}
+	time.Sleep(10 * time.Millisecond)
+	for i := range minSweepEntries {
+		key := "auth:login:failures:" + strconv.Itoa(i)
+		assert.Nil(t, m.Set(ctx, key, i, time.Hour))
+	}
+
+	m.mu.Lock()
+	pinned := m.pinned.Len()
+	m.mu.Unlock()
+	assert.Less(
+		t,
+		pinned,
+		2*minSweepEntries,
+		"expired entries should be swept",
+	)
+
+	revoked, err = d.IsRevoked(ctx, claims)
+	assert.Nil(t, err)
+	assert.True(t, revoked)
+}
+
+func TestInMemoryCache_Increment(t *testing.T) {
+	ctx := context.Background()
+	m := NewInMemoryCache(&env.Env{InMemoryCacheMaxEntries: 10})
+
+	n, err := m.Increment(ctx, "counter", 2, time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), n)
+
+	n, err = m.Increment(ctx, "counter", 3, time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), n)
+
+	var i int
+	ok, err := m.Scan(ctx, "counter", &i)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, i)
+
+	assert.Nil(t, m.Set(ctx, "string", "value", 0))
+	_, err = m.Increment(ctx, "string", 1, 0)
+	assert.NotNil(t, err)
+}
+
+func TestInMemoryCache_Take(t *testing.T) {
+	ctx := context.Background()
+	m := NewInMemoryCache(&env.Env{InMemoryCacheMaxEntries: 10})
+
+	assert.Nil(t, m.Set(ctx, "key", "value", 0))
+
+	var taken atomic.Int64
+	wg := sync.WaitGroup{}
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var s string
+			ok, err := m.Take(ctx, "key", &s)
+			assert.Nil(t, err)
+			if ok {
+				assert.Equal(t, "value", s)
+				taken.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(1), taken.Load(), "value should be taken once")
+
+	var s string
+	ok, err := m.Scan(ctx, "key", &s)
+	assert.False(t, ok)
+	assert.Nil(t, err)
+}
+
+func TestInMemoryCache_Lock(t *testing.T) {
+	ctx := context.Background()
+	m := NewInMemoryCache(&env.Env{InMemoryCacheMaxEntries: 10})
+
+	token, ok, err := m.Lock(ctx, "lock", time.Minute)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	_, ok, err = m.Lock(ctx, "lock", time.Minute)
+	assert.Nil(t, err)
+	assert.False(t, ok, "lock should be held")
+
+	assert.Nil(t, m.Unlock(ctx, "lock", "other"))
+	_, ok, _ = m.Lock(ctx, "lock", time.Minute)
+	assert.False(t, ok, "lock should not be released with another token")
+
+	ok, err = m.Extend(ctx, "lock", "other", time.Millisecond)
+	assert.Nil(t, err)
+	assert.False(t, ok, "lock should not be extended with another token")
+
+	ok, err = m.Extend(ctx, "lock", token, time.Millisecond)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	time.Sleep(2 * time.Millisecond)
+	_, ok, _ = m.Lock(ctx, "lock", time.Minute)
+	assert.True(t, ok, "lock should expire with its new ttl")
+
+	token, ok, _ = m.Lock(ctx, "other", time.Minute)
+	assert.True(t, ok)
+	assert.Nil(t, m.Unlock(ctx, "other", token))
+	_, ok, _ = m.Lock(ctx, "other", time.Minute)
+	assert.True(t, ok)
+}
+
+func TestInMemoryCache_Ranking(t *testing.T) {
+	ctx := context.Background()
+	m := NewInMemoryCache(&env.Env{InMemoryCacheMaxEntries: 10})
+
+	assert.Nil(t, m.IncrementScore(ctx, "ranking", "a", 1, time.Minute))
+	assert.Nil(t, m.IncrementScore(ctx, "ranking", "b", 3, time.Minute))
+	assert.Nil(t, m.IncrementScore(ctx, "ranking", "a", 1, time.Minute))
+	assert.Nil(t, m.IncrementScore(ctx, "ranking", "c", 1, time.Minute))
+
+	top, err := m.TopScores(ctx, "ranking", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []cache.Score{
+		{Member: "b", Score: 3},
+		{Member: "a", Score: 2},
+	}, top)
+
+	top, err = m.TopScores(ctx, "missing", 2)
+	assert.Nil(t, err)
+	assert.Empty(t, top)
+}
+
+func TestInMemoryCache_Keys(t *testing.T) {
+	ctx := context.Background()
+	m := NewInMemoryCache(&env.Env{InMemoryCacheMaxEntries: 10})
+
+	assert.Nil(t, m.Set(ctx, "flight:a", "value", time.Minute))
+	assert.Nil(t, m.Set(ctx, "flight:b", "value", 0))
+	assert.Nil(t, m.Set(ctx, "other", "value", 0))
+
+	infos := map[string]cache.KeyInfo{}
+	for info, err := range m.Keys(ctx, "flight:*") {
+		assert.Nil(t, err)
+		infos[info.Key] = info
+	}
+
+	assert.Len(t, infos, 2)
+	assert.Equal(t, int64(5), infos["flight:a"].Size)
+	assert.Greater(t, infos["flight:a"].TTL, time.Duration(0))
+	assert.Zero(t, infos["flight:b"].TTL)
+
+	for _, err := range m.Keys(ctx, "[") {
+		assert.NotNil(t, err)
+	}
+}
@@ -6,8 +6,10 @@ package mockcache
 
 import (
 	"context"
+	"iter"
 	"time"
 
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -97,6 +99,111 @@ func (_c *MockCache_Delete_Call) RunAndReturn(run func(ctx context.Context, keys
 	return _c
 }
 
+// Increment provides a mock function for the type MockCache
+func (_mock *MockCache) Increment(ctx context.Context, key string, value int64, expiration time.Duration) (int64, error) {
+	ret := _mock.Called(ctx, key, value, expiration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Increment")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) (int64, error)); ok {
+		return returnFunc(ctx, key, value, expiration)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) int64); ok {
+		r0 = returnFunc(ctx, key, value, expiration)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64, time.Duration) error); ok {
+		r1 = returnFunc(ctx, key, value, expiration)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCache_Increment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Increment'
+type MockCache_Increment_Call struct {
+	*mock.Call
+}
+
+// Increment is a helper method to define mock.On call
+//   - ctx
+//   - key
+//   - value
+//   - expiration
+func (_e *MockCache_Expecter) Increment(ctx interface{}, key interface{}, value interface{}, expiration interface{}) *MockCache_Increment_Call {
+	return &MockCache_Increment_Call{Call: _e.mock.On("Increment", ctx, key, value, expiration)}
+}
+
+func (_c *MockCache_Increment_Call) Run(run func(ctx context.Context, key string, value int64, expiration time.Duration)) *MockCache_Increment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockCache_Increment_Call) Return(n int64, err error) *MockCache_Increment_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockCache_Increment_Call) RunAndReturn(run func(ctx context.Context, key string, value int64, expiration time.Duration) (int64, error)) *MockCache_Increment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Keys provides a mock function for the type MockCache
+func (_mock *MockCache) Keys(ctx context.Context, pattern string) iter.Seq2[cache.KeyInfo, error] {
+	ret := _mock.Called(ctx, pattern)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Keys")
+	}
+
+	var r0 iter.Seq2[cache.KeyInfo, error]
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) iter.Seq2[cache.KeyInfo, error]); ok {
+		r0 = returnFunc(ctx, pattern)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(iter.Seq2[cache.KeyInfo, error])
+		}
+	}
+	return r0
+}
+
+// MockCache_Keys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Keys'
+type MockCache_Keys_Call struct {
+	*mock.Call
+}
+
+// Keys is a helper method to define mock.On call
+//   - ctx
+//   - pattern
+func (_e *MockCache_Expecter) Keys(ctx interface{}, pattern interface{}) *MockCache_Keys_Call {
+	return &MockCache_Keys_Call{Call: _e.mock.On("Keys", ctx, pattern)}
+}
+
+func (_c *MockCache_Keys_Call) Run(run func(ctx context.Context, pattern string)) *MockCache_Keys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCache_Keys_Call) Return(seq2 iter.Seq2[cache.KeyInfo, error]) *MockCache_Keys_Call {
+	_c.Call.Return(seq2)
+	return _c
+}
+
+func (_c *MockCache_Keys_Call) RunAndReturn(run func(ctx context.Context, pattern string) iter.Seq2[cache.KeyInfo, error]) *MockCache_Keys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Scan provides a mock function for the type MockCache
 func (_mock *MockCache) Scan(ctx context.Context, key string, value any) (bool, error) {
 	ret := _mock.Called(ctx, key, value)
@@ -200,3 +307,457 @@ func (_c *MockCache_Set_Call) RunAndReturn(run func(ctx context.Context, key str
 	_c.Call.Return(run)
 	return _c
 }
+
+// Take provides a mock function for the type MockCache
+func (_mock *MockCache) Take(ctx context.Context, key string, value any) (bool, error) {
+	ret := _mock.Called(ctx, key, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Take")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, any) (bool, error)); ok {
+		return returnFunc(ctx, key, value)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, any) bool); ok {
+		r0 = returnFunc(ctx, key, value)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, any) error); ok {
+		r1 = returnFunc(ctx, key, value)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCache_Take_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Take'
+type MockCache_Take_Call struct {
+	*mock.Call
+}
+
+// Take is a helper method to define mock.On call
+//   - ctx
+//   - key
+//   - value
+func (_e *MockCache_Expecter) Take(ctx interface{}, key interface{}, value interface{}) *MockCache_Take_Call {
+	return &MockCache_Take_Call{Call: _e.mock.On("Take", ctx, key, value)}
+}
+
+func (_c *MockCache_Take_Call) Run(run func(ctx context.Context, key string, value any)) *MockCache_Take_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(any))
+	})
+	return _c
+}
+
+func (_c *MockCache_Take_Call) Return(ok bool, err error) *MockCache_Take_Call {
+	_c.Call.Return(ok, err)
+	return _c
+}
+
+func (_c *MockCache_Take_Call) RunAndReturn(run func(ctx context.Context, key string, value any) (bool, error)) *MockCache_Take_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLocker creates a new instance of MockLocker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLocker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLocker {
+	mock := &MockLocker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockLocker is an autogenerated mock type for the Locker type
+type MockLocker struct {
+	mock.Mock
+}
+
+type MockLocker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLocker) EXPECT() *MockLocker_Expecter {
+	return &MockLocker_Expecter{mock: &_m.Mock}
+}
+
+// Extend provides a mock function for the type MockLocker
+func (_mock *MockLocker) Extend(ctx context.Context, key string, token string, ttl time.Duration) (bool, error) {
+	ret := _mock.Called(ctx, key, token, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Extend")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) (bool, error)); ok {
+		return returnFunc(ctx, key, token, ttl)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) bool); ok {
+		r0 = returnFunc(ctx, key, token, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) error); ok {
+		r1 = returnFunc(ctx, key, token, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLocker_Extend_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Extend'
+type MockLocker_Extend_Call struct {
+	*mock.Call
+}
+
+// Extend is a helper method to define mock.On call
+//   - ctx
+//   - key
+//   - token
+//   - ttl
+func (_e *MockLocker_Expecter) Extend(ctx interface{}, key interface{}, token interface{}, ttl interface{}) *MockLocker_Extend_Call {
+	return &MockLocker_Extend_Call{Call: _e.mock.On("Extend", ctx, key, token, ttl)}
+}
+
+func (_c *MockLocker_Extend_Call) Run(run func(ctx context.Context, key string, token string, ttl time.Duration)) *MockLocker_Extend_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockLocker_Extend_Call) Return(ok bool, err error) *MockLocker_Extend_Call {
+	_c.Call.Return(ok, err)
+	return _c
+}
+
+func (_c *MockLocker_Extend_Call) RunAndReturn(run func(ctx context.Context, key string, token string, ttl time.Duration) (bool, error)) *MockLocker_Extend_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Lock provides a mock function for the type MockLocker
+func (_mock *MockLocker) Lock(ctx context.Context, key string, ttl time.Duration) (string, bool, error) {
+	ret := _mock.Called(ctx, key, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Lock")
+	}
+
+	var r0 string
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Duration) (string, bool, error)); ok {
+		return returnFunc(ctx, key, ttl)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Duration) string); ok {
+		r0 = returnFunc(ctx, key, ttl)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, time.Duration) bool); ok {
+		r1 = returnFunc(ctx, key, ttl)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, time.Duration) error); ok {
+		r2 = returnFunc(ctx, key, ttl)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockLocker_Lock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Lock'
+type MockLocker_Lock_Call struct {
+	*mock.Call
+}
+
+// Lock is a helper method to define mock.On call
+//   - ctx
+//   - key
+//   - ttl
+func (_e *MockLocker_Expecter) Lock(ctx interface{}, key interface{}, ttl interface{}) *MockLocker_Lock_Call {
+	return &MockLocker_Lock_Call{Call: _e.mock.On("Lock", ctx, key, ttl)}
+}
+
+func (_c *MockLocker_Lock_Call) Run(run func(ctx context.Context, key string, ttl time.Duration)) *MockLocker_Lock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockLocker_Lock_Call) Return(token string, ok bool, err error) *MockLocker_Lock_Call {
+	_c.Call.Return(token, ok, err)
+	return _c
+}
+
+func (_c *MockLocker_Lock_Call) RunAndReturn(run func(ctx context.Context, key string, ttl time.Duration) (string, bool, error)) *MockLocker_Lock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unlock provides a mock function for the type MockLocker
+func (_mock *MockLocker) Unlock(ctx context.Context, key string, token string) error {
+	ret := _mock.Called(ctx, key, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unlock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, key, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockLocker_Unlock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unlock'
+type MockLocker_Unlock_Call struct {
+	*mock.Call
+}
+
+// Unlock is a helper method to define mock.On call
+//   - ctx
+//   - key
+//   - token
+func (_e *MockLocker_Expecter) Unlock(ctx interface{}, key interface{}, token interface{}) *MockLocker_Unlock_Call {
+	return &MockLocker_Unlock_Call{Call: _e.mock.On("Unlock", ctx, key, token)}
+}
+
+func (_c *MockLocker_Unlock_Call) Run(run func(ctx context.Context, key string, token string)) *MockLocker_Unlock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockLocker_Unlock_Call) Return(err error) *MockLocker_Unlock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockLocker_Unlock_Call) RunAndReturn(run func(ctx context.Context, key string, token string) error) *MockLocker_Unlock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRanker creates a new instance of MockRanker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRanker(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRanker {
+	mock := &MockRanker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRanker is an autogenerated mock type for the Ranker type
+type MockRanker struct {
+	mock.Mock
+}
+
+type MockRanker_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRanker) EXPECT() *MockRanker_Expecter {
+	return &MockRanker_Expecter{mock: &_m.Mock}
+}
+
+// IncrementScore provides a mock function for the type MockRanker
+func (_mock *MockRanker) IncrementScore(ctx context.Context, key string, member string, value float64, expiration time.Duration) error {
+	ret := _mock.Called(ctx, key, member, value, expiration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementScore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, float64, time.Duration) error); ok {
+		r0 = returnFunc(ctx, key, member, value, expiration)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRanker_IncrementScore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementScore'
+type MockRanker_IncrementScore_Call struct {
+	*mock.Call
+}
+
+// IncrementScore is a helper method to define mock.On call
+//   - ctx
+//   - key
+//   - member
+//   - value
+//   - expiration
+func (_e *MockRanker_Expecter) IncrementScore(ctx interface{}, key interface{}, member interface{}, value interface{}, expiration interface{}) *MockRanker_IncrementScore_Call {
+	return &MockRanker_IncrementScore_Call{Call: _e.mock.On("IncrementScore", ctx, key, member, value, expiration)}
+}
+
+func (_c *MockRanker_IncrementScore_Call) Run(run func(ctx context.Context, key string, member string, value float64, expiration time.Duration)) *MockRanker_IncrementScore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(float64), args[4].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockRanker_IncrementScore_Call) Return(err error) *MockRanker_IncrementScore_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRanker_IncrementScore_Call) RunAndReturn(run func(ctx context.Context, key string, member string, value float64, expiration time.Duration) error) *MockRanker_IncrementScore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TopScores provides a mock function for the type MockRanker
+func (_mock *MockRanker) TopScores(ctx context.Context, key string, n int) ([]cache.Score, error) {
+	ret := _mock.Called(ctx, key, n)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TopScores")
+	}
+
+	var r0 []cache.Score
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]cache.Score, error)); ok {
+		return returnFunc(ctx, key, n)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []cache.Score); ok {
+		r0 = returnFunc(ctx, key, n)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]cache.Score)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, key, n)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRanker_TopScores_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TopScores'
+type MockRanker_TopScores_Call struct {
+	*mock.Call
+}
+
+// TopScores is a helper method to define mock.On call
+//   - ctx
+//   - key
+//   - n
+func (_e *MockRanker_Expecter) TopScores(ctx interface{}, key interface{}, n interface{}) *MockRanker_TopScores_Call {
+	return &MockRanker_TopScores_Call{Call: _e.mock.On("TopScores", ctx, key, n)}
+}
+
+func (_c *MockRanker_TopScores_Call) Run(run func(ctx context.Context, key string, n int)) *MockRanker_TopScores_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockRanker_TopScores_Call) Return(scores []cache.Score, err error) *MockRanker_TopScores_Call {
+	_c.Call.Return(scores, err)
+	return _c
+}
+
+func (_c *MockRanker_TopScores_Call) RunAndReturn(run func(ctx context.Context, key string, n int) ([]cache.Score, error)) *MockRanker_TopScores_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStatsReporter creates a new instance of MockStatsReporter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStatsReporter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStatsReporter {
+	mock := &MockStatsReporter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockStatsReporter is an autogenerated mock type for the StatsReporter type
+type MockStatsReporter struct {
+	mock.Mock
+}
+
+type MockStatsReporter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStatsReporter) EXPECT() *MockStatsReporter_Expecter {
+	return &MockStatsReporter_Expecter{mock: &_m.Mock}
+}
+
+// Stats provides a mock function for the type MockStatsReporter
+func (_mock *MockStatsReporter) Stats() cache.Stats {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stats")
+	}
+
+	var r0 cache.Stats
+	if returnFunc, ok := ret.Get(0).(func() cache.Stats); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(cache.Stats)
+	}
+	return r0
+}
+
+// MockStatsReporter_Stats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stats'
+type MockStatsReporter_Stats_Call struct {
+	*mock.Call
+}
+
+// Stats is a helper method to define mock.On call
+func (_e *MockStatsReporter_Expecter) Stats() *MockStatsReporter_Stats_Call {
+	return &MockStatsReporter_Stats_Call{Call: _e.mock.On("Stats")}
+}
+
+func (_c *MockStatsReporter_Stats_Call) Run(run func()) *MockStatsReporter_Stats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockStatsReporter_Stats_Call) Return(stats cache.Stats) *MockStatsReporter_Stats_Call {
+	_c.Call.Return(stats)
+	return _c
+}
+
+func (_c *MockStatsReporter_Stats_Call) RunAndReturn(run func() cache.Stats) *MockStatsReporter_Stats_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -2,18 +2,53 @@ package rediscache
 
 import (
 	"context"
-	"encoding/json"
-	"strconv"
+	"errors"
+	"iter"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 
 	"github.com/danielmesquitta/flight-api/internal/config/env"
 	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/codec"
 )
 
+// unlockScript deletes a lock only if it is still held with the given
+// token, so that a lock that expired and was acquired by someone else is
+// never released.
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// extendScript sets the expiration of a lock only if it is still held
+// with the given token.
+var extendScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// incrementScript adds to a counter and sets its expiration if it has
+// none, in a single step, so that a counter is never left without one.
+var incrementScript = redis.NewScript(`
+local n = redis.call("INCRBY", KEYS[1], ARGV[1])
+if tonumber(ARGV[2]) > 0 and redis.call("PTTL", KEYS[1]) == -1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return n
+`)
+
+// keysScanCount is how many keys are asked for on each SCAN call.
+const keysScanCount = 100
+
 type RedisCache struct {
-	c *redis.Client
+	c     *redis.Client
+	codec *codec.Codec
 }
 
 func NewRedisCache(
@@ -32,7 +67,8 @@ func NewRedisCache(
 	}
 
 	return &RedisCache{
-		c: client,
+		c:     client,
+		codec: codec.NewCodec(e),
 	}
 }
 
@@ -41,52 +77,16 @@ func (r *RedisCache) Scan(
 	key string,
 	value any,
 ) (bool, error) {
-	strCmd := r.c.Get(ctx, key)
-	if strCmd.Err() == redis.Nil {
+	raw, err := r.c.Get(ctx, key).Bytes()
+	if err == redis.Nil {
 		return false, nil
 	}
-	if err := strCmd.Err(); err != nil {
-		return false, err
-	}
-
-	raw, err := strCmd.Result()
 	if err != nil {
 		return false, err
 	}
 
-	switch v := value.(type) {
-	case *string:
-		*v = raw
-	case *[]byte:
-		*v = []byte(raw)
-	case *int:
-		i, err := strconv.Atoi(raw)
-		if err != nil {
-			return false, err
-		}
-		*v = i
-	case *int64:
-		i, err := strconv.ParseInt(raw, 10, 64)
-		if err != nil {
-			return false, err
-		}
-		*v = i
-	case *float64:
-		f, err := strconv.ParseFloat(raw, 64)
-		if err != nil {
-			return false, err
-		}
-		*v = f
-	case *bool:
-		b, err := strconv.ParseBool(raw)
-		if err != nil {
-			return false, err
-		}
-		*v = b
-	default:
-		if err := json.Unmarshal([]byte(raw), value); err != nil {
-			return false, err
-		}
+	if err := r.codec.Unmarshal(raw, value); err != nil {
+		return false, err
 	}
 
 	return true, nil
@@ -98,17 +98,9 @@ func (r *RedisCache) Set(
 	value any,
 	expiration time.Duration,
 ) error {
-	var data any
-
-	switch v := value.(type) {
-	case string, []byte, int, int64, float64, bool:
-		data = v
-	default:
-		b, err := json.Marshal(value)
-		if err != nil {
-			return err
-		}
-		data = b
+	data, err := r.codec.Marshal(value)
+	if err != nil {
+		return err
 	}
 
 	return r.c.Set(ctx, key, data, expiration).Err()
@@ -123,4 +115,232 @@ func (r *RedisCache) Delete(
 	return r.c.Del(ctx, ks...).Err()
 }
 
-var _ cache.Cache = (*RedisCache)(nil)
+func (r *RedisCache) Take(
+	ctx context.Context,
+	key string,
+	value any,
+) (bool, error) {
+	raw, err := r.c.GetDel(ctx, key).Bytes()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.codec.Unmarshal(raw, value); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *RedisCache) Increment(
+	ctx context.Context,
+	key string,
+	value int64,
+	expiration time.Duration,
+) (int64, error) {
+	return incrementScript.Run(
+		ctx,
+		r.c,
+		[]string{key},
+		value,
+		expiration.Milliseconds(),
+	).Int64()
+}
+
+func (r *RedisCache) Keys(
+	ctx context.Context,
+	pattern string,
+) iter.Seq2[cache.KeyInfo, error] {
+	return func(yield func(cache.KeyInfo, error) bool) {
+		var cursor uint64
+		for {
+			keys, next, err := r.c.Scan(
+				ctx,
+				cursor,
+				pattern,
+				keysScanCount,
+			).Result()
+			if err != nil {
+				yield(cache.KeyInfo{}, err)
+				return
+			}
+
+			infos, err := r.keyInfos(ctx, keys)
+			if err != nil {
+				yield(cache.KeyInfo{}, err)
+				return
+			}
+
+			for _, info := range infos {
+				if !yield(info, nil) {
+					return
+				}
+			}
+
+			cursor = next
+			if cursor == 0 {
+				return
+			}
+		}
+	}
+}
+
+// keyInfos reads the TTL and size of the keys in a single round trip,
+// skipping the ones that no longer exist.
+func (r *RedisCache) keyInfos(
+	ctx context.Context,
+	keys []string,
+) ([]cache.KeyInfo, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	pipe := r.c.Pipeline()
+	ttls := make([]*redis.DurationCmd, len(keys))
+	sizes := make([]*redis.IntCmd, len(keys))
+	for i, key := range keys {
+		ttls[i] = pipe.PTTL(ctx, key)
+		sizes[i] = pipe.MemoryUsage(ctx, key)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	infos := make([]cache.KeyInfo, 0, len(keys))
+	for i, key := range keys {
+		if errors.Is(sizes[i].Err(), redis.Nil) || ttls[i].Val() == -2 {
+			continue
+		}
+
+		info := cache.KeyInfo{
+			Key:  key,
+			Size: sizes[i].Val(),
+		}
+		if ttl := ttls[i].Val(); ttl > 0 {
+			info.TTL = ttl
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+func (r *RedisCache) Publish(
+	ctx context.Context,
+	channel string,
+	message any,
+) error {
+	data, err := cache.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	return r.c.Publish(ctx, channel, data).Err()
+}
+
+func (r *RedisCache) Subscribe(
+	ctx context.Context,
+	channels ...string,
+) *redis.PubSub {
+	return r.c.Subscribe(ctx, channels...)
+}
+
+func (r *RedisCache) Lock(
+	ctx context.Context,
+	key string,
+	ttl time.Duration,
+) (string, bool, error) {
+	token := uuid.NewString()
+
+	ok, err := r.c.SetNX(ctx, key, token, ttl).Result()
+	if err != nil {
+		return "", false, err
+	}
+	if !ok {
+		return "", false, nil
+	}
+
+	return token, true, nil
+}
+
+func (r *RedisCache) Unlock(
+	ctx context.Context,
+	key string,
+	token string,
+) error {
+	return unlockScript.Run(ctx, r.c, []string{key}, token).Err()
+}
+
+func (r *RedisCache) Extend(
+	ctx context.Context,
+	key string,
+	token string,
+	ttl time.Duration,
+) (bool, error) {
+	n, err := extendScript.Run(
+		ctx,
+		r.c,
+		[]string{key},
+		token,
+		ttl.Milliseconds(),
+	).Int64()
+	if err != nil {
+		return false, err
+	}
+
+	return n == 1, nil
+}
+
+func (r *RedisCache) IncrementScore(
+	ctx context.Context,
+	key string,
+	member string,
+	value float64,
+	expiration time.Duration,
+) error {
+	pipe := r.c.TxPipeline()
+	pipe.ZIncrBy(ctx, key, value, member)
+	if expiration > 0 {
+		pipe.Expire(ctx, key, expiration)
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *RedisCache) TopScores(
+	ctx context.Context,
+	key string,
+	n int,
+) ([]cache.Score, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	zs, err := r.c.ZRevRangeWithScores(ctx, key, 0, int64(n-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]cache.Score, 0, len(zs))
+	for _, z := range zs {
+		member, _ := z.Member.(string)
+		scores = append(scores, cache.Score{
+			Member: member,
+			Score:  z.Score,
+		})
+	}
+
+	return scores, nil
+}
+
+var (
+	_ cache.Cache  = (*RedisCache)(nil)
+	_ cache.Locker = (*RedisCache)(nil)
+	_ cache.Ranker = (*RedisCache)(nil)
+)
@@ -0,0 +1,337 @@
+package tieredcache
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/codec"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/inmemorycache"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/rediscache"
+)
+
+// invalidationChannel is the Redis pub/sub channel used to evict keys
+// from the L1 cache of every instance.
+const invalidationChannel = "cache:invalidate"
+
+// TieredCache keeps a short lived in-process L1 cache in front of Redis,
+// which acts as L2. Keys set or deleted are evicted from the L1 cache of
+// the other instances through Redis pub/sub. Both tiers hold values
+// encoded as stored in Redis. Counters are only kept in Redis, since
+// any instance may increment them.
+type TieredCache struct {
+	id    string
+	l1    *inmemorycache.InMemoryCache
+	l2    *rediscache.RedisCache
+	l1TTL time.Duration
+	codec *codec.Codec
+
+	l1Hits, l1Misses atomic.Int64
+	l2Hits, l2Misses atomic.Int64
+}
+
+type invalidation struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys"`
+}
+
+func NewTieredCache(
+	e *env.Env,
+) *TieredCache {
+	t := &TieredCache{
+		id:    uuid.NewString(),
+		l1:    inmemorycache.NewInMemoryCache(e),
+		l2:    rediscache.NewRedisCache(e),
+		l1TTL: e.TieredCacheL1TTL,
+		codec: codec.NewCodec(e),
+	}
+
+	go t.listenInvalidations(context.Background())
+
+	return t
+}
+
+func (t *TieredCache) Scan(
+	ctx context.Context,
+	key string,
+	value any,
+) (bool, error) {
+	if isCounter(value) {
+		return t.scanL2(ctx, key, value)
+	}
+
+	var raw []byte
+	ok, err := t.l1.Scan(ctx, key, &raw)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		t.l1Hits.Add(1)
+		return true, t.codec.Unmarshal(raw, value)
+	}
+	t.l1Misses.Add(1)
+
+	ok, err = t.l2.Scan(ctx, key, &raw)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		t.l2Misses.Add(1)
+		return false, nil
+	}
+	t.l2Hits.Add(1)
+
+	if err := t.codec.Unmarshal(raw, value); err != nil {
+		return false, err
+	}
+
+	if err := t.l1.Set(ctx, key, raw, t.l1TTL); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (t *TieredCache) Set(
+	ctx context.Context,
+	key string,
+	value any,
+	expiration time.Duration,
+) error {
+	raw, err := t.codec.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	if err := t.l2.Set(ctx, key, raw, expiration); err != nil {
+		return err
+	}
+
+	if !isCounter(value) {
+		l1TTL := t.l1TTL
+		if expiration > 0 {
+			l1TTL = min(l1TTL, expiration)
+		}
+
+		if err := t.l1.Set(ctx, key, raw, l1TTL); err != nil {
+			return err
+		}
+	}
+
+	return t.invalidate(ctx, key)
+}
+
+func (t *TieredCache) Delete(
+	ctx context.Context,
+	keys ...string,
+) error {
+	if err := t.l1.Delete(ctx, keys...); err != nil {
+		return err
+	}
+
+	if err := t.l2.Delete(ctx, keys...); err != nil {
+		return err
+	}
+
+	return t.invalidate(ctx, keys...)
+}
+
+// Take always goes to Redis, so that a value is only taken once across
+// every instance.
+func (t *TieredCache) Take(
+	ctx context.Context,
+	key string,
+	value any,
+) (bool, error) {
+	if err := t.l1.Delete(ctx, key); err != nil {
+		return false, err
+	}
+
+	var raw []byte
+	ok, err := t.l2.Take(ctx, key, &raw)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, nil
+	}
+
+	if err := t.invalidate(ctx, key); err != nil {
+		return false, err
+	}
+
+	return true, t.codec.Unmarshal(raw, value)
+}
+
+// invalidate evicts the keys from the L1 cache of the other instances.
+func (t *TieredCache) invalidate(ctx context.Context, keys ...string) error {
+	return t.l2.Publish(ctx, invalidationChannel, invalidation{
+		Origin: t.id,
+		Keys:   keys,
+	})
+}
+
+// Increment always goes to Redis, since counters must be shared by
+// every instance.
+func (t *TieredCache) Increment(
+	ctx context.Context,
+	key string,
+	value int64,
+	expiration time.Duration,
+) (int64, error) {
+	return t.l2.Increment(ctx, key, value, expiration)
+}
+
+// Keys lists the keys stored in Redis, which holds every key of the L1
+// cache of any instance.
+func (t *TieredCache) Keys(
+	ctx context.Context,
+	pattern string,
+) iter.Seq2[cache.KeyInfo, error] {
+	return t.l2.Keys(ctx, pattern)
+}
+
+// Lock is always held in Redis, so that it is shared by every instance.
+func (t *TieredCache) Lock(
+	ctx context.Context,
+	key string,
+	ttl time.Duration,
+) (string, bool, error) {
+	return t.l2.Lock(ctx, key, ttl)
+}
+
+func (t *TieredCache) Unlock(
+	ctx context.Context,
+	key string,
+	token string,
+) error {
+	return t.l2.Unlock(ctx, key, token)
+}
+
+func (t *TieredCache) Extend(
+	ctx context.Context,
+	key string,
+	token string,
+	ttl time.Duration,
+) (bool, error) {
+	return t.l2.Extend(ctx, key, token, ttl)
+}
+
+// IncrementScore always goes to Redis, since rankings must be shared by
+// every instance.
+func (t *TieredCache) IncrementScore(
+	ctx context.Context,
+	key string,
+	member string,
+	value float64,
+	expiration time.Duration,
+) error {
+	return t.l2.IncrementScore(ctx, key, member, value, expiration)
+}
+
+func (t *TieredCache) TopScores(
+	ctx context.Context,
+	key string,
+	n int,
+) ([]cache.Score, error) {
+	return t.l2.TopScores(ctx, key, n)
+}
+
+func (t *TieredCache) Stats() cache.Stats {
+	return cache.Stats{
+		Tiers: []cache.TierStats{
+			newTierStats("l1", t.l1Hits.Load(), t.l1Misses.Load()),
+			newTierStats("l2", t.l2Hits.Load(), t.l2Misses.Load()),
+		},
+	}
+}
+
+func (t *TieredCache) listenInvalidations(ctx context.Context) {
+	sub := t.l2.Subscribe(ctx, invalidationChannel)
+	defer func() {
+		_ = sub.Close()
+	}()
+
+	for msg := range sub.Channel() {
+		data := invalidation{}
+		if err := json.Unmarshal([]byte(msg.Payload), &data); err != nil {
+			slog.ErrorContext(
+				ctx,
+				"failed to decode cache invalidation",
+				"error", err,
+			)
+			continue
+		}
+
+		if data.Origin == t.id {
+			continue
+		}
+
+		if err := t.l1.Delete(ctx, data.Keys...); err != nil {
+			slog.ErrorContext(
+				ctx,
+				"failed to apply cache invalidation",
+				"error", err,
+			)
+		}
+	}
+}
+
+// scanL2 reads a value straight from Redis, skipping the L1 cache.
+func (t *TieredCache) scanL2(
+	ctx context.Context,
+	key string,
+	value any,
+) (bool, error) {
+	ok, err := t.l2.Scan(ctx, key, value)
+	if err != nil {
+		return false, err
+	}
+
+	if ok {
+		t.l2Hits.Add(1)
+	} else {
+		t.l2Misses.Add(1)
+	}
+
+	return ok, nil
+}
+
+// isCounter reports whether value, or the value it points to, is an
+// integer, which Increment may change in Redis at any time.
+func isCounter(value any) bool {
+	switch value.(type) {
+	case int, int64, *int, *int64:
+		return true
+	default:
+		return false
+	}
+}
+
+func newTierStats(name string, hits, misses int64) cache.TierStats {
+	stats := cache.TierStats{
+		Name:   name,
+		Hits:   hits,
+		Misses: misses,
+	}
+
+	if total := hits + misses; total > 0 {
+		stats.HitRatio = float64(hits) / float64(total)
+	}
+
+	return stats
+}
+
+var (
+	_ cache.Cache         = (*TieredCache)(nil)
+	_ cache.Locker        = (*TieredCache)(nil)
+	_ cache.Ranker        = (*TieredCache)(nil)
+	_ cache.StatsReporter = (*TieredCache)(nil)
+)
@@ -0,0 +1,105 @@
+package tieredcache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/test/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type entry struct {
+	Value string `json:"value"`
+}
+
+// newReplicas returns two tiered caches sharing a Redis container, as
+// two instances of the server would.
+func newReplicas(t *testing.T) (*TieredCache, *TieredCache) {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx := context.Background()
+	connectionString, cleanUp := container.NewRedisContainer(ctx)
+	t.Cleanup(func() {
+		assert.Nil(t, cleanUp(context.Background()))
+	})
+
+	e := &env.Env{
+		RedisDatabaseURL:        connectionString,
+		InMemoryCacheMaxEntries: 100,
+		TieredCacheL1TTL:        time.Hour,
+	}
+
+	return NewTieredCache(e), NewTieredCache(e)
+}
+
+func TestTieredCache_ReadThrough(t *testing.T) {
+	ctx := context.Background()
+	a, b := newReplicas(t)
+
+	// Set in Redis only, so that no invalidation races with the reads.
+	assert.Nil(t, a.l2.Set(ctx, "key", entry{Value: "1"}, time.Minute))
+
+	got := entry{}
+	ok, err := b.Scan(ctx, "key", &got)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "1", got.Value)
+
+	ok, err = b.Scan(ctx, "key", &got)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	stats := b.Stats()
+	assert.Equal(t, int64(1), stats.Tiers[0].Hits, "should read L1 last")
+	assert.Equal(t, int64(1), stats.Tiers[1].Hits, "should read L2 first")
+}
+
+func TestTieredCache_Invalidation(t *testing.T) {
+	ctx := context.Background()
+	a, b := newReplicas(t)
+
+	assert.Nil(t, a.Set(ctx, "key", entry{Value: "1"}, time.Minute))
+
+	got := entry{}
+	ok, err := b.Scan(ctx, "key", &got)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	assert.Nil(t, a.Set(ctx, "key", entry{Value: "2"}, time.Minute))
+	assert.Eventually(t, func() bool {
+		ok, err := b.Scan(ctx, "key", &got)
+		return err == nil && ok && got.Value == "2"
+	}, 5*time.Second, 10*time.Millisecond, "should evict keys set")
+
+	assert.Nil(t, a.Delete(ctx, "key"))
+	assert.Eventually(t, func() bool {
+		ok, err := b.Scan(ctx, "key", &got)
+		return err == nil && !ok
+	}, 5*time.Second, 10*time.Millisecond, "should evict keys deleted")
+}
+
+func TestTieredCache_Increment(t *testing.T) {
+	ctx := context.Background()
+	a, b := newReplicas(t)
+
+	n, err := a.Increment(ctx, "counter", 1, time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), n)
+
+	var got int64
+	ok, err := b.Scan(ctx, "counter", &got)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), got)
+
+	_, err = a.Increment(ctx, "counter", 2, time.Minute)
+	assert.Nil(t, err)
+
+	ok, err = b.Scan(ctx, "counter", &got)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), got, "counters should never be read from L1")
+}
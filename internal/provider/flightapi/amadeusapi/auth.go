@@ -42,8 +42,8 @@ func (a *AmadeusAPI) authenticate(ctx context.Context) error {
 		SetContext(ctx).
 		SetFormData(map[string]string{
 			"grant_type":    "client_credentials",
-			"client_id":     a.e.AmadeusAPIKey,
-			"client_secret": a.e.AmadeusAPISecret,
+			"client_id":     a.key,
+			"client_secret": a.secret,
 		}).
 		Post("/v1/security/oauth2/token")
 	if err != nil {
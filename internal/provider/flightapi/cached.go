@@ -0,0 +1,96 @@
+package flightapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+)
+
+// CachedFlightAPI decorates a FlightAPI, caching its results per tenant,
+// credentials, route and date, so that a healthy provider can be reused
+// independently of the others, and results found with credentials that
+// were since replaced or revoked aren't read.
+type CachedFlightAPI struct {
+	t   string
+	p   Provider
+	h   string
+	f   FlightAPI
+	c   cache.Cache
+	ttl time.Duration
+}
+
+func NewCachedFlightAPI(
+	tenantID string,
+	p Provider,
+	credentials Credentials,
+	f FlightAPI,
+	c cache.Cache,
+	ttl time.Duration,
+) *CachedFlightAPI {
+	key := credentials.APIKey + ":" + credentials.APISecret
+	sum := sha256.Sum256([]byte(key))
+
+	return &CachedFlightAPI{
+		t:   TenantKey(tenantID),
+		p:   p,
+		h:   hex.EncodeToString(sum[:8]),
+		f:   f,
+		c:   c,
+		ttl: ttl,
+	}
+}
+
+func (c *CachedFlightAPI) SearchFlights(
+	ctx context.Context,
+	origin, destination string,
+	date time.Time,
+) ([]entity.Flight, error) {
+	cacheKey := fmt.Sprintf(
+		"flightapi:flights:%s:%s:%s:%s:%s:%s",
+		c.t,
+		c.p,
+		c.h,
+		strings.ToUpper(origin),
+		strings.ToUpper(destination),
+		date.Format(time.DateOnly),
+	)
+
+	flights := []entity.Flight{}
+	ok, err := c.c.Scan(ctx, cacheKey, &flights)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"failed to scan cache for flight api",
+			"provider", c.p,
+			"error", err,
+		)
+	}
+	if ok {
+		return flights, nil
+	}
+
+	flights, err = c.f.SearchFlights(ctx, origin, destination, date)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.c.Set(ctx, cacheKey, flights, c.ttl); err != nil {
+		slog.ErrorContext(
+			ctx,
+			"failed to set cache for flight api",
+			"provider", c.p,
+			"error", err,
+		)
+	}
+
+	return flights, nil
+}
+
+var _ FlightAPI = (*CachedFlightAPI)(nil)
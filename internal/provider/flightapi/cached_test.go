@@ -0,0 +1,55 @@
+package flightapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/inmemorycache"
+	"github.com/stretchr/testify/assert"
+)
+
+type countingFlightAPI struct {
+	calls int
+}
+
+func (c *countingFlightAPI) SearchFlights(
+	context.Context,
+	string,
+	string,
+	time.Time,
+) ([]entity.Flight, error) {
+	c.calls++
+	return []entity.Flight{}, nil
+}
+
+func TestCachedFlightAPI_SearchFlights(t *testing.T) {
+	ctx := context.Background()
+	e := &env.Env{InMemoryCacheMaxEntries: 100}
+	c := inmemorycache.NewInMemoryCache(e)
+	date := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	f := &countingFlightAPI{}
+	search := func(credentials Credentials, origin, destination string) {
+		api := NewCachedFlightAPI(
+			"org",
+			ProviderDuffel,
+			credentials,
+			f,
+			c,
+			time.Minute,
+		)
+		_, err := api.SearchFlights(ctx, origin, destination, date)
+		assert.Nil(t, err)
+	}
+
+	search(Credentials{APIKey: "old"}, "GRU", "JFK")
+	search(Credentials{APIKey: "old"}, "gru", "jfk")
+	assert.Equal(t, 1, f.calls)
+
+	// Results found with replaced credentials aren't read.
+	search(Credentials{APIKey: "new"}, "GRU", "JFK")
+	assert.Equal(t, 2, f.calls)
+}
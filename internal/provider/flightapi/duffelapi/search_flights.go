@@ -24,6 +24,7 @@ type SearchFlightsData struct {
 
 type SearchFlightsOffer struct {
 	TotalAmount string                    `json:"total_amount"`
+	ExpiresAt   string                    `json:"expires_at"`
 	Slices      []SearchFlightsOfferSlice `json:"slices"`
 }
 
@@ -117,6 +118,19 @@ func (d *DuffelAPI) SearchFlights(
 			return nil, errs.New(err)
 		}
 
+		var expiresAt time.Time
+		if offer.ExpiresAt != "" {
+			expiresAt, err = dateparse.ParseAny(offer.ExpiresAt)
+			if err != nil {
+				slog.ErrorContext(
+					ctx,
+					"failed to parse offer expiration date",
+					"error",
+					err,
+				)
+			}
+		}
+
 		flightNumber := firstSegment.MarketingCarrierFlightNumber
 		if firstSegment.MarketingCarrier.IataCode != "" {
 			flightNumber = firstSegment.MarketingCarrier.IataCode + " " + flightNumber
@@ -138,6 +152,7 @@ func (d *DuffelAPI) SearchFlights(
 			Price:        int64(price * 100),
 			IsCheapest:   false,
 			IsFastest:    false,
+			ExpiresAt:    expiresAt,
 		})
 	}
 
@@ -1,10 +1,12 @@
 package flightapi
 
 import (
+	"cmp"
 	"context"
 	"time"
 
 	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
 	"github.com/danielmesquitta/flight-api/internal/provider/flightapi/amadeusapi"
 	"github.com/danielmesquitta/flight-api/internal/provider/flightapi/duffelapi"
 	"github.com/danielmesquitta/flight-api/internal/provider/flightapi/serpapi"
@@ -18,14 +20,72 @@ type FlightAPI interface {
 	) ([]entity.Flight, error)
 }
 
-func NewFlightAPIs(
-	a *amadeusapi.AmadeusAPI,
-	s *serpapi.SerpAPI,
-	d *duffelapi.DuffelAPI,
-) []FlightAPI {
-	return []FlightAPI{
-		a,
-		s,
-		d,
+type Provider string
+
+const (
+	ProviderAmadeus Provider = "amadeus"
+	ProviderSerp    Provider = "serp"
+	ProviderDuffel  Provider = "duffel"
+)
+
+// Providers are every provider, in the order they are searched.
+var Providers = []Provider{ProviderAmadeus, ProviderSerp, ProviderDuffel}
+
+// DefaultTenant is the tenant of users without an organization, which
+// searches every provider with the keys in the environment.
+const DefaultTenant = "default"
+
+// TenantKey returns the tenant a tenant id is keyed by in the cache.
+func TenantKey(tenantID string) string {
+	return cmp.Or(tenantID, DefaultTenant)
+}
+
+// Credentials authenticate with a provider. Providers authenticated
+// with a single key ignore the secret.
+type Credentials struct {
+	APIKey    string `json:"api_key"`
+	APISecret string `json:"api_secret,omitempty"`
+}
+
+// NewClient returns a client of the provider, authenticated with the
+// credentials.
+func NewClient(p Provider, c Credentials) (FlightAPI, error) {
+	switch p {
+	case ProviderAmadeus:
+		return amadeusapi.NewAmadeusAPI(c.APIKey, c.APISecret), nil
+	case ProviderSerp:
+		return serpapi.NewSerpAPI(c.APIKey), nil
+	case ProviderDuffel:
+		return duffelapi.NewDuffelAPI(c.APIKey), nil
 	}
+	return nil, errs.ErrFlightProviderNotFound
 }
+
+// Resolver returns the flight APIs searched for a tenant.
+type Resolver interface {
+	FlightAPIs(ctx context.Context, tenantID string) ([]FlightAPI, error)
+
+	// ConfigVersion returns a version that changes whenever the providers
+	// of the tenant are configured, so that results found with an older
+	// configuration can be told apart.
+	ConfigVersion(ctx context.Context, tenantID string) (int64, error)
+}
+
+// StaticResolver returns the same flight APIs for every tenant.
+type StaticResolver []FlightAPI
+
+func (s StaticResolver) FlightAPIs(
+	context.Context,
+	string,
+) ([]FlightAPI, error) {
+	return s, nil
+}
+
+func (s StaticResolver) ConfigVersion(
+	context.Context,
+	string,
+) (int64, error) {
+	return 0, nil
+}
+
+var _ Resolver = StaticResolver(nil)
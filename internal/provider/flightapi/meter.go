@@ -0,0 +1,251 @@
+package flightapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+)
+
+// usageRetention is how long daily usage counters are kept in the cache,
+// so that past days can still be reported.
+const usageRetention = 30 * 24 * time.Hour
+
+type usageMetric string
+
+const (
+	usageMetricCalls  usageMetric = "calls"
+	usageMetricErrors usageMetric = "errors"
+	usageMetricCost   usageMetric = "cost"
+)
+
+// Budget holds the daily limits of a provider. A zero limit means
+// unlimited. Costs are expressed in cents.
+type Budget struct {
+	CallLimit   int64
+	CostLimit   int64
+	CostPerCall int64
+}
+
+type Usage struct {
+	Tenant    string   `json:"tenant"`
+	Provider  Provider `json:"provider"`
+	Date      string   `json:"date"`
+	Calls     int64    `json:"calls"`
+	Errors    int64    `json:"errors"`
+	Cost      int64    `json:"cost"`
+	CallLimit int64    `json:"call_limit"`
+	CostLimit int64    `json:"cost_limit"`
+	Exhausted bool     `json:"exhausted"`
+}
+
+// Meter records calls, errors and estimated cost per tenant, provider
+// and day. Every tenant has the same daily budgets.
+type Meter struct {
+	c       cache.Cache
+	budgets map[Provider]Budget
+}
+
+func NewMeter(
+	e *env.Env,
+	c cache.Cache,
+) *Meter {
+	budgets := map[Provider]Budget{
+		ProviderAmadeus: {
+			CallLimit:   e.AmadeusAPIDailyCallLimit,
+			CostLimit:   e.AmadeusAPIDailyCostLimit,
+			CostPerCall: e.AmadeusAPICostPerCall,
+		},
+		ProviderSerp: {
+			CallLimit:   e.SerpAPIDailyCallLimit,
+			CostLimit:   e.SerpAPIDailyCostLimit,
+			CostPerCall: e.SerpAPICostPerCall,
+		},
+		ProviderDuffel: {
+			CallLimit:   e.DuffelAPIDailyCallLimit,
+			CostLimit:   e.DuffelAPIDailyCostLimit,
+			CostPerCall: e.DuffelAPICostPerCall,
+		},
+	}
+
+	return &Meter{
+		c:       c,
+		budgets: budgets,
+	}
+}
+
+// Providers returns the providers known by the meter.
+func (m *Meter) Providers() []Provider {
+	return Providers
+}
+
+// Usage returns the usage of a provider by the tenant in the given day.
+func (m *Meter) Usage(
+	ctx context.Context,
+	tenantID string,
+	p Provider,
+	date time.Time,
+) (*Usage, error) {
+	budget := m.budgets[p]
+	day := date.Format(time.DateOnly)
+	tenant := TenantKey(tenantID)
+
+	usage := &Usage{
+		Tenant:    tenant,
+		Provider:  p,
+		Date:      day,
+		CallLimit: budget.CallLimit,
+		CostLimit: budget.CostLimit,
+	}
+
+	metrics := map[usageMetric]*int64{
+		usageMetricCalls:  &usage.Calls,
+		usageMetricErrors: &usage.Errors,
+		usageMetricCost:   &usage.Cost,
+	}
+	for metric, value := range metrics {
+		_, err := m.c.Scan(ctx, m.key(tenant, p, day, metric), value)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+	}
+
+	callsExhausted := budget.CallLimit > 0 && usage.Calls >= budget.CallLimit
+	costExhausted := budget.CostLimit > 0 && usage.Cost >= budget.CostLimit
+	usage.Exhausted = callsExhausted || costExhausted
+
+	return usage, nil
+}
+
+// Reserve accounts for a call about to be made to the provider by the
+// tenant, and reports whether it is within today's budget. Calls over
+// the budget are released, so that concurrent calls can't overshoot it.
+func (m *Meter) Reserve(
+	ctx context.Context,
+	tenantID string,
+	p Provider,
+) (bool, error) {
+	budget := m.budgets[p]
+	day := time.Now().Format(time.DateOnly)
+	tenant := TenantKey(tenantID)
+
+	increments := map[usageMetric]int64{
+		usageMetricCalls: 1,
+		usageMetricCost:  budget.CostPerCall,
+	}
+	limits := map[usageMetric]int64{
+		usageMetricCalls: budget.CallLimit,
+		usageMetricCost:  budget.CostLimit,
+	}
+
+	reserved := map[usageMetric]int64{}
+	exhausted := false
+	for metric, value := range increments {
+		total, err := m.c.Increment(
+			ctx,
+			m.key(tenant, p, day, metric),
+			value,
+			usageRetention,
+		)
+		if err != nil {
+			return false, errs.New(err)
+		}
+		reserved[metric] = value
+
+		// The budget is exhausted if it was already used before this
+		// call.
+		limit := limits[metric]
+		if limit > 0 && total-value >= limit {
+			exhausted = true
+		}
+	}
+
+	if !exhausted {
+		return true, nil
+	}
+
+	for metric, value := range reserved {
+		_, err := m.c.Increment(
+			ctx,
+			m.key(tenant, p, day, metric),
+			-value,
+			usageRetention,
+		)
+		if err != nil {
+			return false, errs.New(err)
+		}
+	}
+
+	return false, nil
+}
+
+// WithinBudget reports whether every provider has used less than the
+// given percentage of its daily budget for the tenant today, so that
+// background searches leave the rest to user searches.
+func (m *Meter) WithinBudget(
+	ctx context.Context,
+	tenantID string,
+	maxUsage int64,
+) (bool, error) {
+	for _, p := range m.Providers() {
+		usage, err := m.Usage(ctx, tenantID, p, time.Now())
+		if err != nil {
+			return false, errs.New(err)
+		}
+
+		if usage.CallLimit > 0 &&
+			usage.Calls*100 >= usage.CallLimit*maxUsage {
+			return false, nil
+		}
+		if usage.CostLimit > 0 &&
+			usage.Cost*100 >= usage.CostLimit*maxUsage {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// Record accounts for the outcome of a call reserved by the tenant.
+func (m *Meter) Record(
+	ctx context.Context,
+	tenantID string,
+	p Provider,
+	callErr error,
+) error {
+	if callErr == nil {
+		return nil
+	}
+
+	day := time.Now().Format(time.DateOnly)
+
+	_, err := m.c.Increment(
+		ctx,
+		m.key(TenantKey(tenantID), p, day, usageMetricErrors),
+		1,
+		usageRetention,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (m *Meter) key(
+	tenant string,
+	p Provider,
+	day string,
+	metric usageMetric,
+) string {
+	return fmt.Sprintf(
+		"flightapi:usage:%s:%s:%s:%s",
+		tenant,
+		p,
+		day,
+		metric,
+	)
+}
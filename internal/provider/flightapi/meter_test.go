@@ -0,0 +1,59 @@
+package flightapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache/inmemorycache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeter_Reserve(t *testing.T) {
+	ctx := context.Background()
+	e := &env.Env{
+		InMemoryCacheMaxEntries:  100,
+		AmadeusAPIDailyCallLimit: 10,
+		AmadeusAPICostPerCall:    5,
+	}
+	m := NewMeter(e, inmemorycache.NewInMemoryCache(e))
+
+	var wg sync.WaitGroup
+	var allowed atomic.Int64
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := m.Reserve(ctx, "", ProviderAmadeus)
+			assert.Nil(t, err)
+			if ok {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(
+		t,
+		int64(10),
+		allowed.Load(),
+		"concurrent calls should not overshoot the budget",
+	)
+
+	assert.Nil(t, m.Record(ctx, "", ProviderAmadeus, errors.New("failed")))
+
+	usage, err := m.Usage(ctx, "", ProviderAmadeus, time.Now())
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), usage.Calls, "denied calls should be released")
+	assert.Equal(t, int64(50), usage.Cost)
+	assert.Equal(t, int64(1), usage.Errors)
+	assert.True(t, usage.Exhausted)
+
+	ok, err := m.Reserve(ctx, "org", ProviderAmadeus)
+	assert.Nil(t, err)
+	assert.True(t, ok, "each tenant should have its own budget")
+}
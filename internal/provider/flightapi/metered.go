@@ -0,0 +1,67 @@
+package flightapi
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+)
+
+// MeteredFlightAPI decorates a FlightAPI, recording its usage by the
+// tenant and skipping it once the daily budget is exhausted.
+type MeteredFlightAPI struct {
+	t string
+	p Provider
+	f FlightAPI
+	m *Meter
+}
+
+func NewMeteredFlightAPI(
+	tenantID string,
+	p Provider,
+	f FlightAPI,
+	m *Meter,
+) *MeteredFlightAPI {
+	return &MeteredFlightAPI{
+		t: tenantID,
+		p: p,
+		f: f,
+		m: m,
+	}
+}
+
+func (m *MeteredFlightAPI) SearchFlights(
+	ctx context.Context,
+	origin, destination string,
+	date time.Time,
+) ([]entity.Flight, error) {
+	ok, err := m.m.Reserve(ctx, m.t, m.p)
+	if err != nil {
+		slog.ErrorContext(
+			ctx,
+			"failed to reserve flight api budget",
+			"provider", m.p,
+			"error", err,
+		)
+	}
+	if err == nil && !ok {
+		return nil, errs.ErrFlightAPIBudgetExhausted
+	}
+
+	flights, callErr := m.f.SearchFlights(ctx, origin, destination, date)
+
+	if err := m.m.Record(ctx, m.t, m.p, callErr); err != nil {
+		slog.ErrorContext(
+			ctx,
+			"failed to record flight api usage",
+			"provider", m.p,
+			"error", err,
+		)
+	}
+
+	return flights, callErr
+}
+
+var _ FlightAPI = (*MeteredFlightAPI)(nil)
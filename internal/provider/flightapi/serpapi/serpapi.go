@@ -2,25 +2,21 @@ package serpapi
 
 import (
 	"resty.dev/v3"
-
-	"github.com/danielmesquitta/flight-api/internal/config/env"
 )
 
 type SerpAPI struct {
-	e *env.Env
 	c *resty.Client
 }
 
-func NewSerpAPI(e *env.Env) *SerpAPI {
+func NewSerpAPI(key string) *SerpAPI {
 	c := resty.New().
 		SetBaseURL("https://serpapi.com/search").
 		SetQueryParams(map[string]string{
-			"api_key": e.SerpAPIKey,
+			"api_key": key,
 			"engine":  "google_flights",
 		})
 
 	return &SerpAPI{
-		e: e,
 		c: c,
 	}
 }
@@ -0,0 +1,246 @@
+package flightapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/cache"
+)
+
+// TenantConfig holds the credentials of the providers enabled for a
+// tenant.
+type TenantConfig struct {
+	Providers map[Provider]Credentials `json:"providers"`
+}
+
+// TenantConfigLoader loads the configuration of tenants other than the
+// default one.
+type TenantConfigLoader interface {
+	LoadTenantConfig(
+		ctx context.Context,
+		tenantID string,
+	) (*TenantConfig, error)
+}
+
+// TenantClients builds the flight APIs of each tenant, cached and
+// metered per tenant, and keeps them for TENANT_CLIENTS_TTL, so that
+// clients keep their connections and access tokens between searches.
+// Once expired, the configuration of the tenant is loaded again, and
+// its clients are only rebuilt if it changed. Configuring a tenant bumps
+// its version in the cache, which every instance compares with the one
+// its clients were built with, so that none keeps searching with the
+// old configuration.
+type TenantClients struct {
+	e *env.Env
+	c cache.Cache
+	m *Meter
+	l TenantConfigLoader
+
+	mu      sync.Mutex
+	tenants map[string]*tenantClients
+	loads   singleflight.Group
+}
+
+type tenantClients struct {
+	apis      []FlightAPI
+	hash      string
+	version   int64
+	expiresAt time.Time
+}
+
+func tenantConfigVersionKey(tenantID string) string {
+	return "flightapi:tenant:" + TenantKey(tenantID) + ":version"
+}
+
+func NewTenantClients(
+	e *env.Env,
+	c cache.Cache,
+	m *Meter,
+	l TenantConfigLoader,
+) *TenantClients {
+	return &TenantClients{
+		e:       e,
+		c:       c,
+		m:       m,
+		l:       l,
+		tenants: map[string]*tenantClients{},
+	}
+}
+
+func (t *TenantClients) FlightAPIs(
+	ctx context.Context,
+	tenantID string,
+) ([]FlightAPI, error) {
+	version, err := t.ConfigVersion(ctx, tenantID)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	t.mu.Lock()
+	clients, ok := t.tenants[tenantID]
+	t.mu.Unlock()
+
+	if ok && clients.version == version &&
+		time.Now().Before(clients.expiresAt) {
+		return clients.apis, nil
+	}
+
+	// Concurrent searches of a tenant share a single load, which is not
+	// canceled when the search that started it is. Searches that saw
+	// another version don't share it, so that none gets clients built
+	// with an older configuration.
+	loadKey := tenantID + ":" + strconv.FormatInt(version, 10)
+	v, err, _ := t.loads.Do(loadKey, func() (any, error) {
+		return t.load(context.WithoutCancel(ctx), tenantID, version)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]FlightAPI), nil
+}
+
+func (t *TenantClients) ConfigVersion(
+	ctx context.Context,
+	tenantID string,
+) (int64, error) {
+	var version int64
+	_, err := t.c.Scan(ctx, tenantConfigVersionKey(tenantID), &version)
+	if err != nil {
+		return 0, errs.New(err)
+	}
+
+	return version, nil
+}
+
+// Invalidate bumps the configuration version of the tenant, so that the
+// next search of every instance loads its configuration again, and
+// doesn't read the results found with the previous one.
+func (t *TenantClients) Invalidate(ctx context.Context, tenantID string) error {
+	_, err := t.c.Increment(ctx, tenantConfigVersionKey(tenantID), 1, 0)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.tenants, tenantID)
+
+	return nil
+}
+
+func (t *TenantClients) load(
+	ctx context.Context,
+	tenantID string,
+	version int64,
+) ([]FlightAPI, error) {
+	config, err := t.config(ctx, tenantID)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	expiresAt := time.Now().Add(t.e.TenantClientsTTL)
+
+	if clients, ok := t.tenants[tenantID]; ok && clients.hash == hash {
+		clients.version = max(clients.version, version)
+		clients.expiresAt = expiresAt
+		return clients.apis, nil
+	}
+
+	apis := []FlightAPI{}
+	for _, p := range Providers {
+		credentials, ok := config.Providers[p]
+		if !ok {
+			continue
+		}
+
+		client, err := NewClient(p, credentials)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+
+		apis = append(apis, NewCachedFlightAPI(
+			tenantID,
+			p,
+			credentials,
+			NewMeteredFlightAPI(tenantID, p, client, t.m),
+			t.c,
+			t.cacheTTL(p),
+		))
+	}
+
+	// A load of a newer version may have finished first, and its clients
+	// are kept.
+	if clients, ok := t.tenants[tenantID]; ok && clients.version > version {
+		return apis, nil
+	}
+
+	t.tenants[tenantID] = &tenantClients{
+		apis:      apis,
+		hash:      hash,
+		version:   version,
+		expiresAt: expiresAt,
+	}
+
+	return apis, nil
+}
+
+// config returns the configuration of the tenant, where the default
+// tenant searches every provider with the keys in the environment.
+func (t *TenantClients) config(
+	ctx context.Context,
+	tenantID string,
+) (*TenantConfig, error) {
+	if tenantID != "" {
+		return t.l.LoadTenantConfig(ctx, tenantID)
+	}
+
+	return &TenantConfig{
+		Providers: map[Provider]Credentials{
+			ProviderAmadeus: {
+				APIKey:    t.e.AmadeusAPIKey,
+				APISecret: t.e.AmadeusAPISecret,
+			},
+			ProviderSerp: {
+				APIKey: t.e.SerpAPIKey,
+			},
+			ProviderDuffel: {
+				APIKey: t.e.DuffelAPIKey,
+			},
+		},
+	}, nil
+}
+
+func (t *TenantClients) cacheTTL(p Provider) time.Duration {
+	switch p {
+	case ProviderAmadeus:
+		return t.e.AmadeusAPICacheTTL
+	case ProviderSerp:
+		return t.e.SerpAPICacheTTL
+	case ProviderDuffel:
+		return t.e.DuffelAPICacheTTL
+	}
+	return 0
+}
+
+var _ Resolver = (*TenantClients)(nil)
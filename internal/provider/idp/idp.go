@@ -0,0 +1,30 @@
+package idp
+
+import "context"
+
+// IdentityProvider signs users in with external identity providers,
+// through the OpenID Connect authorization code flow with PKCE.
+type IdentityProvider interface {
+	// AuthCodeURL returns where users are sent to sign in with the
+	// provider, which redirects them back with a code and the state.
+	// Returns errs.ErrOIDCProviderNotFound for unknown providers.
+	AuthCodeURL(
+		ctx context.Context,
+		provider, state, nonce, verifier string,
+	) (string, error)
+
+	// Exchange redeems the code for an ID token, and returns the identity
+	// it holds once its signature, audience, expiration and nonce are
+	// verified.
+	Exchange(
+		ctx context.Context,
+		provider, code, verifier, nonce string,
+	) (*Identity, error)
+}
+
+// Identity is the account a user signed in with at a provider.
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
@@ -0,0 +1,245 @@
+// Package idptest provides a local OpenID Connect provider for tests.
+package idptest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/danielmesquitta/flight-api/internal/provider/idp"
+)
+
+const (
+	ClientID     = "flight-api"
+	ClientSecret = "secret"
+	keyID        = "idptest"
+)
+
+// Server signs in its User without asking, as soon as it is sent to the
+// authorization endpoint, and issues ID tokens for them.
+type Server struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+
+	mu    sync.Mutex
+	user  idp.Identity
+	codes map[string]authorization
+}
+
+type authorization struct {
+	user          idp.Identity
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+}
+
+// NewServer starts a provider, closed when the test ends, signing in a
+// user with a verified e-mail.
+func NewServer(t *testing.T) *Server {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Server{
+		key: key,
+		user: idp.Identity{
+			Subject:       uuid.NewString(),
+			Email:         "johndoe@email.com",
+			EmailVerified: true,
+		},
+		codes: map[string]authorization{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("GET /authorize", s.authorize)
+	mux.HandleFunc("POST /token", s.token)
+	mux.HandleFunc("GET /jwks", s.jwks)
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Provider returns the provider in the format of OIDC_PROVIDERS.
+func (s *Server) Provider(name string) string {
+	return name + "|" + s.URL + "|" + ClientID + "|" + ClientSecret
+}
+
+// SetUser changes who is signed in next.
+func (s *Server) SetUser(user idp.Identity) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.user = user
+}
+
+// Authorize follows the authorization URL, and returns the code and state
+// the provider redirects back with.
+func (s *Server) Authorize(t *testing.T, authCodeURL string) (
+	code, state string,
+) {
+	t.Helper()
+
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	res, err := client.Get(authCodeURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	location, err := res.Location()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func (s *Server) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                s.URL,
+		"authorization_endpoint":                s.URL + "/authorize",
+		"token_endpoint":                        s.URL + "/token",
+		"jwks_uri":                              s.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != ClientID ||
+		query.Get("response_type") != "code" ||
+		query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	code := uuid.NewString()
+
+	s.mu.Lock()
+	s.codes[code] = authorization{
+		user:          s.user,
+		redirectURI:   redirectURI.String(),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	s.mu.Unlock()
+
+	params := url.Values{}
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	redirectURI.RawQuery = params.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID = r.PostFormValue("client_id")
+		clientSecret = r.PostFormValue("client_secret")
+	}
+	if clientID != ClientID || clientSecret != ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, tokenError("invalid_client"))
+		return
+	}
+
+	code := r.PostFormValue("code")
+
+	s.mu.Lock()
+	auth, ok := s.codes[code]
+	delete(s.codes, code)
+	s.mu.Unlock()
+
+	challenge := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+	if !ok ||
+		r.PostFormValue("grant_type") != "authorization_code" ||
+		r.PostFormValue("redirect_uri") != auth.redirectURI ||
+		base64.RawURLEncoding.EncodeToString(challenge[:]) !=
+			auth.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, tokenError("invalid_grant"))
+		return
+	}
+
+	now := time.Now()
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            s.URL,
+		"sub":            auth.user.Subject,
+		"aud":            ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Minute).Unix(),
+		"nonce":          auth.nonce,
+		"email":          auth.user.Email,
+		"email_verified": auth.user.EmailVerified,
+	})
+	idToken.Header["kid"] = keyID
+
+	signed, err := idToken.SignedString(s.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": uuid.NewString(),
+		"token_type":   "Bearer",
+		"expires_in":   60,
+		"id_token":     signed,
+	})
+}
+
+func (s *Server) jwks(w http.ResponseWriter, _ *http.Request) {
+	public := s.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": keyID,
+			"n":   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(
+				big.NewInt(int64(public.E)).Bytes(),
+			),
+		}},
+	})
+}
+
+func tokenError(code string) map[string]string {
+	return map[string]string{"error": code}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
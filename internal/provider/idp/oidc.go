@@ -0,0 +1,216 @@
+package idp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+)
+
+// ProviderPlaceholder is replaced by the name of the provider in the
+// redirect URL.
+const ProviderPlaceholder = "{provider}"
+
+// OIDC discovers each provider from its issuer on first use, and keeps
+// it, with the keys its ID tokens are signed with, for the next logins.
+type OIDC struct {
+	client      *http.Client
+	redirectURL string
+	configs     map[string]providerConfig
+
+	mu          sync.Mutex
+	providers   map[string]*oidc.Provider
+	discoveries singleflight.Group
+}
+
+type providerConfig struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+}
+
+func NewOIDC(
+	e *env.Env,
+) *OIDC {
+	configs, err := parseProviders(e.OIDCProviders)
+	if err != nil {
+		panic(err)
+	}
+
+	return &OIDC{
+		client:      &http.Client{Timeout: 10 * time.Second},
+		redirectURL: e.OIDCRedirectURL,
+		configs:     configs,
+		providers:   map[string]*oidc.Provider{},
+	}
+}
+
+func (o *OIDC) AuthCodeURL(
+	ctx context.Context,
+	provider, state, nonce, verifier string,
+) (string, error) {
+	config, _, err := o.oauth2Config(ctx, provider)
+	if err != nil {
+		return "", errs.New(err)
+	}
+
+	return config.AuthCodeURL(
+		state,
+		oidc.Nonce(nonce),
+		oauth2.S256ChallengeOption(verifier),
+	), nil
+}
+
+func (o *OIDC) Exchange(
+	ctx context.Context,
+	provider, code, verifier, nonce string,
+) (*Identity, error) {
+	config, p, err := o.oauth2Config(ctx, provider)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	ctx = oidc.ClientContext(ctx, o.client)
+	token, err := config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, errs.New(err, errs.ErrCodeUnauthorized)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errs.ErrInvalidIDToken
+	}
+
+	idToken, err := p.
+		Verifier(&oidc.Config{ClientID: config.ClientID}).
+		Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, errs.New(err, errs.ErrCodeUnauthorized)
+	}
+	if idToken.Nonce != nonce {
+		return nil, errs.ErrInvalidIDToken
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, errs.New(err, errs.ErrCodeUnauthorized)
+	}
+
+	return &Identity{
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+	}, nil
+}
+
+func (o *OIDC) oauth2Config(
+	ctx context.Context,
+	provider string,
+) (*oauth2.Config, *oidc.Provider, error) {
+	config, ok := o.configs[provider]
+	if !ok {
+		return nil, nil, errs.ErrOIDCProviderNotFound
+	}
+
+	p, err := o.discover(ctx, provider, config.issuer)
+	if err != nil {
+		return nil, nil, errs.New(err)
+	}
+
+	return &oauth2.Config{
+		ClientID:     config.clientID,
+		ClientSecret: config.clientSecret,
+		Endpoint:     p.Endpoint(),
+		RedirectURL: strings.ReplaceAll(
+			o.redirectURL,
+			ProviderPlaceholder,
+			provider,
+		),
+		Scopes: []string{oidc.ScopeOpenID, "email", "profile"},
+	}, p, nil
+}
+
+// discover reads the discovery document of the provider, once it is
+// reachable. Concurrent logins share a single discovery, made without
+// holding the lock, so that a slow issuer doesn't block the others. The
+// provider keeps the context to fetch its keys later, so it must outlive
+// the request.
+func (o *OIDC) discover(
+	ctx context.Context,
+	provider, issuer string,
+) (*oidc.Provider, error) {
+	o.mu.Lock()
+	p, ok := o.providers[provider]
+	o.mu.Unlock()
+	if ok {
+		return p, nil
+	}
+
+	ch := o.discoveries.DoChan(provider, func() (any, error) {
+		ctx := oidc.ClientContext(context.WithoutCancel(ctx), o.client)
+		p, err := oidc.NewProvider(ctx, issuer)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+
+		o.mu.Lock()
+		o.providers[provider] = p
+		o.mu.Unlock()
+
+		return p, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, errs.New(ctx.Err())
+
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*oidc.Provider), nil
+	}
+}
+
+// parseProviders parses providers in the format
+// NAME|ISSUER|CLIENT_ID|CLIENT_SECRET, comma separated.
+func parseProviders(raw string) (map[string]providerConfig, error) {
+	configs := map[string]providerConfig{}
+
+	for entry := range strings.SplitSeq(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, "|")
+		if len(parts) != 4 || parts[0] == "" || parts[1] == "" ||
+			parts[2] == "" {
+			return nil, errs.New(
+				fmt.Sprintf("invalid OIDC provider %q", parts[0]),
+			)
+		}
+
+		configs[parts[0]] = providerConfig{
+			issuer:       parts[1],
+			clientID:     parts[2],
+			clientSecret: parts[3],
+		}
+	}
+
+	return configs, nil
+}
+
+var _ IdentityProvider = (*OIDC)(nil)
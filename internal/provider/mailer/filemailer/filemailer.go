@@ -0,0 +1,96 @@
+package filemailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/mailer"
+)
+
+// FileMailer doesn't send e-mails, but logs their recipient and subject,
+// and appends them as JSON lines to a file when it has a path, so that
+// they can be read in development and tests. Bodies aren't logged, as
+// they may hold tokens.
+type FileMailer struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileMailer(
+	e *env.Env,
+) *FileMailer {
+	return &FileMailer{
+		path: e.MailerFilePath,
+	}
+}
+
+func (f *FileMailer) Send(
+	ctx context.Context,
+	message mailer.Message,
+) error {
+	slog.InfoContext(
+		ctx,
+		"e-mail sent",
+		"to", message.To,
+		"subject", message.Subject,
+	)
+
+	if f.path == "" {
+		return nil
+	}
+
+	line, err := json.Marshal(message)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(
+		f.path,
+		os.O_APPEND|os.O_CREATE|os.O_WRONLY,
+		0o600,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+// Read returns the messages appended to the file at path, oldest first.
+func Read(path string) ([]mailer.Message, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []mailer.Message{}, nil
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	messages := []mailer.Message{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for decoder.More() {
+		var message mailer.Message
+		if err := decoder.Decode(&message); err != nil {
+			return nil, errs.New(err)
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+var _ mailer.Mailer = (*FileMailer)(nil)
@@ -0,0 +1,41 @@
+package filemailer
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/provider/mailer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileMailer_Send(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.jsonl")
+	f := NewFileMailer(&env.Env{MailerFilePath: path})
+
+	messages, err := Read(path)
+	assert.Nil(t, err)
+	assert.Empty(t, messages)
+
+	sent := []mailer.Message{
+		{To: "johndoe@email.com", Subject: "First", Body: "Hello\nthere"},
+		{To: "janedoe@email.com", Subject: "Second", Body: "Bye"},
+	}
+	for _, message := range sent {
+		assert.Nil(t, f.Send(context.Background(), message))
+	}
+
+	messages, err = Read(path)
+	assert.Nil(t, err)
+	assert.Equal(t, sent, messages)
+}
+
+func TestFileMailer_SendWithoutPath(t *testing.T) {
+	f := NewFileMailer(&env.Env{})
+
+	err := f.Send(context.Background(), mailer.Message{
+		To: "johndoe@email.com",
+	})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,14 @@
+package mailer
+
+import "context"
+
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// Message is a plain text e-mail.
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
@@ -0,0 +1,21 @@
+package mailerdriver
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/provider/mailer"
+	"github.com/danielmesquitta/flight-api/internal/provider/mailer/filemailer"
+	"github.com/danielmesquitta/flight-api/internal/provider/mailer/smtpmailer"
+)
+
+// NewMailer returns the mailer implementation selected by MAILER_DRIVER.
+func NewMailer(
+	e *env.Env,
+) mailer.Mailer {
+	switch e.MailerDriver {
+	case env.MailerDriverSMTP:
+		return smtpmailer.NewSMTPMailer(e)
+
+	default:
+		return filemailer.NewFileMailer(e)
+	}
+}
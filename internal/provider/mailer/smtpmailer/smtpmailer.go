@@ -0,0 +1,147 @@
+package smtpmailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/mailer"
+)
+
+// sendTimeout bounds sending an e-mail, from dialing the server to
+// quitting, when the context has no earlier deadline.
+const sendTimeout = 30 * time.Second
+
+// SMTPMailer sends e-mails through an SMTP server, upgrading the
+// connection with STARTTLS when the server supports it. Credentials are
+// only sent when set.
+type SMTPMailer struct {
+	host string
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPMailer(
+	e *env.Env,
+) *SMTPMailer {
+	var auth smtp.Auth
+	if e.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", e.SMTPUsername, e.SMTPPassword, e.SMTPHost)
+	}
+
+	return &SMTPMailer{
+		host: e.SMTPHost,
+		addr: net.JoinHostPort(e.SMTPHost, strconv.Itoa(e.SMTPPort)),
+		from: e.MailerFrom,
+		auth: auth,
+	}
+}
+
+func (s *SMTPMailer) Send(
+	ctx context.Context,
+	message mailer.Message,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return errs.New(err)
+	}
+	defer conn.Close()
+
+	// The deadline also applies to a server that stops responding, and
+	// canceling ctx aborts the conversation.
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return errs.New(err)
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	if err := s.send(conn, message); err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+// send sends the message over conn, as smtp.SendMail does.
+func (s *SMTPMailer) send(conn net.Conn, message mailer.Message) error {
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		err := c.StartTLS(&tls.Config{ServerName: s.host})
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.auth != nil {
+		if ok, _ := c.Extension("AUTH"); ok {
+			if err := c.Auth(s.auth); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := c.Mail(s.from); err != nil {
+		return err
+	}
+	if err := c.Rcpt(message.To); err != nil {
+		return err
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(s.build(message)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return c.Quit()
+}
+
+// build formats the message as a MIME e-mail, with CRLF line endings.
+func (s *SMTPMailer) build(message mailer.Message) []byte {
+	headers := []string{
+		"From: " + s.from,
+		"To: " + message.To,
+		"Subject: " + mime.QEncoding.Encode("utf-8", message.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+	}
+
+	body := strings.ReplaceAll(message.Body, "\r\n", "\n")
+	body = strings.ReplaceAll(body, "\n", "\r\n")
+
+	return fmt.Appendf(
+		nil,
+		"%s\r\n\r\n%s\r\n",
+		strings.Join(headers, "\r\n"),
+		body,
+	)
+}
+
+var _ mailer.Mailer = (*SMTPMailer)(nil)
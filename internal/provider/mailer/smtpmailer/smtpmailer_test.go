@@ -0,0 +1,72 @@
+package smtpmailer
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/provider/mailer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSMTPMailer_build(t *testing.T) {
+	s := NewSMTPMailer(&env.Env{
+		MailerFrom: "no-reply@email.com",
+		SMTPHost:   "localhost",
+		SMTPPort:   587,
+	})
+	assert.Equal(t, "localhost:587", s.addr)
+	assert.Nil(t, s.auth)
+
+	got := string(s.build(mailer.Message{
+		To:      "johndoe@email.com",
+		Subject: "Vérifiez",
+		Body:    "Hello\nthere",
+	}))
+
+	headers, body, ok := strings.Cut(got, "\r\n\r\n")
+	assert.True(t, ok)
+	assert.Contains(t, headers, "From: no-reply@email.com\r\n")
+	assert.Contains(t, headers, "To: johndoe@email.com\r\n")
+	assert.Contains(t, headers, "Subject: =?utf-8?q?V=C3=A9rifiez?=\r\n")
+	assert.Contains(t, headers, "Content-Type: text/plain; charset=utf-8")
+	assert.Equal(t, "Hello\r\nthere\r\n", body)
+}
+
+func TestSMTPMailer_SendTimeout(t *testing.T) {
+	// The server accepts connections, but never greets the client.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	s := NewSMTPMailer(&env.Env{
+		MailerFrom: "no-reply@email.com",
+		SMTPHost:   addr.IP.String(),
+		SMTPPort:   addr.Port,
+	})
+
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		50*time.Millisecond,
+	)
+	defer cancel()
+
+	start := time.Now()
+	err = s.Send(ctx, mailer.Message{To: "johndoe@email.com"})
+	assert.NotNil(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,98 @@
+package inmemoryrepo
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+)
+
+func (m *InMemoryRepository) CreateAPIKey(
+	_ context.Context,
+	key entity.APIKey,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key.Scopes = slices.Clone(key.Scopes)
+	m.apiKeys[key.ID] = key
+
+	return nil
+}
+
+func (m *InMemoryRepository) ListAPIKeys(
+	_ context.Context,
+	userID string,
+) ([]entity.APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := []entity.APIKey{}
+	for _, key := range m.apiKeys {
+		if key.UserID == userID {
+			key.Scopes = slices.Clone(key.Scopes)
+			keys = append(keys, key)
+		}
+	}
+
+	slices.SortFunc(keys, func(a, b entity.APIKey) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	return keys, nil
+}
+
+func (m *InMemoryRepository) GetAPIKeyByHash(
+	_ context.Context,
+	hash string,
+) (*entity.APIKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, key := range m.apiKeys {
+		if key.Hash == hash {
+			key.Scopes = slices.Clone(key.Scopes)
+			return &key, nil
+		}
+	}
+
+	return nil, errs.ErrAPIKeyNotFound
+}
+
+func (m *InMemoryRepository) TouchAPIKey(
+	_ context.Context,
+	id string,
+	lastUsedAt time.Time,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key, ok := m.apiKeys[id]
+	if !ok {
+		return nil
+	}
+
+	key.LastUsedAt = lastUsedAt
+	m.apiKeys[id] = key
+
+	return nil
+}
+
+func (m *InMemoryRepository) DeleteAPIKey(
+	_ context.Context,
+	userID, id string,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key, ok := m.apiKeys[id]
+	if !ok || key.UserID != userID {
+		return errs.ErrAPIKeyNotFound
+	}
+
+	delete(m.apiKeys, id)
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package inmemoryrepo
+
+import (
+	"context"
+	"slices"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+)
+
+func (m *InMemoryRepository) CreateAuditEvent(
+	_ context.Context,
+	event entity.AuditEvent,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.auditEvents = append(m.auditEvents, event)
+
+	return nil
+}
+
+func (m *InMemoryRepository) ListAuditEvents(
+	_ context.Context,
+	filter entity.AuditEventFilter,
+) ([]entity.AuditEvent, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	events := []entity.AuditEvent{}
+	for _, event := range m.auditEvents {
+		if filter.Match(event) {
+			events = append(events, event)
+		}
+	}
+
+	slices.SortStableFunc(events, func(a, b entity.AuditEvent) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	if filter.Limit > 0 && len(events) > filter.Limit {
+		events = events[:filter.Limit]
+	}
+
+	return events, nil
+}
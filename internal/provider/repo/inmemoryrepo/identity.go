@@ -0,0 +1,40 @@
+package inmemoryrepo
+
+import (
+	"context"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+)
+
+func (m *InMemoryRepository) CreateIdentity(
+	_ context.Context,
+	identity entity.Identity,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := identityKey{identity.Provider, identity.Subject}
+	if _, ok := m.identities[key]; ok {
+		return errs.ErrIdentityAlreadyExists
+	}
+
+	m.identities[key] = identity
+
+	return nil
+}
+
+func (m *InMemoryRepository) GetIdentity(
+	_ context.Context,
+	provider, subject string,
+) (*entity.Identity, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	identity, ok := m.identities[identityKey{provider, subject}]
+	if !ok {
+		return nil, errs.ErrIdentityNotFound
+	}
+
+	return &identity, nil
+}
@@ -0,0 +1,45 @@
+package inmemoryrepo
+
+import (
+	"sync"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+)
+
+// InMemoryRepository keeps data in process, so it is lost on restart
+// and not shared between instances. Meant for development and tests.
+type InMemoryRepository struct {
+	mu          sync.RWMutex
+	users       map[string]entity.User
+	apiKeys     map[string]entity.APIKey
+	identities  map[identityKey]entity.Identity
+	orgs        map[string]entity.Organization
+	providers   map[organizationProviderKey]entity.OrganizationProvider
+	auditEvents []entity.AuditEvent
+	searches    map[string]entity.SavedSearch
+	alerts      map[string]entity.PriceAlert
+	checks      []entity.PriceAlertCheck
+}
+
+type identityKey struct {
+	provider, subject string
+}
+
+type organizationProviderKey struct {
+	organizationID, provider string
+}
+
+func NewInMemoryRepository() *InMemoryRepository {
+	return &InMemoryRepository{
+		users:      map[string]entity.User{},
+		apiKeys:    map[string]entity.APIKey{},
+		identities: map[identityKey]entity.Identity{},
+		orgs:       map[string]entity.Organization{},
+		providers:  map[organizationProviderKey]entity.OrganizationProvider{},
+		searches:   map[string]entity.SavedSearch{},
+		alerts:     map[string]entity.PriceAlert{},
+	}
+}
+
+var _ repo.Repository = (*InMemoryRepository)(nil)
@@ -0,0 +1,35 @@
+package inmemoryrepo
+
+import (
+	"testing"
+
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/repotest"
+)
+
+func TestInMemoryRepository_User(t *testing.T) {
+	repotest.TestUserRepository(t, NewInMemoryRepository())
+}
+
+func TestInMemoryRepository_APIKey(t *testing.T) {
+	repotest.TestAPIKeyRepository(t, NewInMemoryRepository())
+}
+
+func TestInMemoryRepository_Identity(t *testing.T) {
+	repotest.TestIdentityRepository(t, NewInMemoryRepository())
+}
+
+func TestInMemoryRepository_Organization(t *testing.T) {
+	repotest.TestOrganizationRepository(t, NewInMemoryRepository())
+}
+
+func TestInMemoryRepository_Audit(t *testing.T) {
+	repotest.TestAuditRepository(t, NewInMemoryRepository())
+}
+
+func TestInMemoryRepository_SavedSearch(t *testing.T) {
+	repotest.TestSavedSearchRepository(t, NewInMemoryRepository())
+}
+
+func TestInMemoryRepository_PriceAlert(t *testing.T) {
+	repotest.TestPriceAlertRepository(t, NewInMemoryRepository())
+}
@@ -0,0 +1,98 @@
+package inmemoryrepo
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+)
+
+func (m *InMemoryRepository) CreateOrganization(
+	_ context.Context,
+	org entity.Organization,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, o := range m.orgs {
+		if o.Name == org.Name {
+			return errs.ErrOrganizationAlreadyExists
+		}
+	}
+
+	m.orgs[org.ID] = org
+
+	return nil
+}
+
+func (m *InMemoryRepository) ListOrganizations(
+	_ context.Context,
+) ([]entity.Organization, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	orgs := make([]entity.Organization, 0, len(m.orgs))
+	for _, org := range m.orgs {
+		orgs = append(orgs, org)
+	}
+
+	slices.SortFunc(orgs, func(a, b entity.Organization) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return orgs, nil
+}
+
+func (m *InMemoryRepository) GetOrganizationByID(
+	_ context.Context,
+	id string,
+) (*entity.Organization, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	org, ok := m.orgs[id]
+	if !ok {
+		return nil, errs.ErrOrganizationNotFound
+	}
+
+	return &org, nil
+}
+
+func (m *InMemoryRepository) SaveOrganizationProvider(
+	_ context.Context,
+	provider entity.OrganizationProvider,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	provider.Credentials = slices.Clone(provider.Credentials)
+	key := organizationProviderKey{provider.OrganizationID, provider.Provider}
+	m.providers[key] = provider
+
+	return nil
+}
+
+func (m *InMemoryRepository) ListOrganizationProviders(
+	_ context.Context,
+	organizationID string,
+) ([]entity.OrganizationProvider, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	providers := []entity.OrganizationProvider{}
+	for key, provider := range m.providers {
+		if key.organizationID != organizationID {
+			continue
+		}
+		provider.Credentials = slices.Clone(provider.Credentials)
+		providers = append(providers, provider)
+	}
+
+	slices.SortFunc(providers, func(a, b entity.OrganizationProvider) int {
+		return cmp.Compare(a.Provider, b.Provider)
+	})
+
+	return providers, nil
+}
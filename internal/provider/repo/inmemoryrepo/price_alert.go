@@ -0,0 +1,199 @@
+package inmemoryrepo
+
+import (
+	"context"
+	"slices"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+)
+
+func (m *InMemoryRepository) CreatePriceAlert(
+	_ context.Context,
+	alert entity.PriceAlert,
+	maxActive int,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	active := 0
+	for _, a := range m.alerts {
+		if a.UserID == alert.UserID && a.Active {
+			active++
+		}
+	}
+	if active >= maxActive {
+		return errs.ErrPriceAlertLimitReached
+	}
+
+	m.alerts[alert.ID] = alert
+
+	return nil
+}
+
+func (m *InMemoryRepository) ListPriceAlerts(
+	_ context.Context,
+	userID string,
+) ([]entity.PriceAlert, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	alerts := []entity.PriceAlert{}
+	for _, alert := range m.alerts {
+		if alert.UserID == userID {
+			alerts = append(alerts, alert)
+		}
+	}
+
+	slices.SortFunc(alerts, func(a, b entity.PriceAlert) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	return alerts, nil
+}
+
+func (m *InMemoryRepository) ListActivePriceAlerts(
+	_ context.Context,
+) ([]entity.PriceAlert, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	alerts := []entity.PriceAlert{}
+	for _, alert := range m.alerts {
+		if alert.Active {
+			alerts = append(alerts, alert)
+		}
+	}
+
+	// Zero times come first, so alerts never checked are checked first.
+	slices.SortFunc(alerts, func(a, b entity.PriceAlert) int {
+		if c := a.LastCheckedAt.Compare(b.LastCheckedAt); c != 0 {
+			return c
+		}
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return alerts, nil
+}
+
+func (m *InMemoryRepository) GetPriceAlert(
+	_ context.Context,
+	userID, id string,
+) (*entity.PriceAlert, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	alert, ok := m.alerts[id]
+	if !ok || alert.UserID != userID {
+		return nil, errs.ErrPriceAlertNotFound
+	}
+
+	return &alert, nil
+}
+
+func (m *InMemoryRepository) UpdatePriceAlertState(
+	_ context.Context,
+	alert entity.PriceAlert,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.alerts[alert.ID]
+	if !ok {
+		return errs.ErrPriceAlertNotFound
+	}
+
+	stored.Active = alert.Active
+	stored.BasePrice = alert.BasePrice
+	stored.LastPrice = alert.LastPrice
+	stored.LastCheckedAt = alert.LastCheckedAt
+	stored.NotifiedAt = alert.NotifiedAt
+	stored.UpdatedAt = alert.UpdatedAt
+	m.alerts[alert.ID] = stored
+
+	return nil
+}
+
+func (m *InMemoryRepository) DeletePriceAlert(
+	_ context.Context,
+	userID, id string,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	alert, ok := m.alerts[id]
+	if !ok || alert.UserID != userID {
+		return errs.ErrPriceAlertNotFound
+	}
+
+	delete(m.alerts, id)
+	m.checks = slices.DeleteFunc(
+		m.checks,
+		func(check entity.PriceAlertCheck) bool {
+			return check.AlertID == id
+		},
+	)
+
+	return nil
+}
+
+func (m *InMemoryRepository) CreatePriceAlertCheck(
+	_ context.Context,
+	check entity.PriceAlertCheck,
+	maxChecks int,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.checks = append(m.checks, check)
+
+	checks := []entity.PriceAlertCheck{}
+	for _, c := range m.checks {
+		if c.AlertID == check.AlertID {
+			checks = append(checks, c)
+		}
+	}
+	if len(checks) <= maxChecks {
+		return nil
+	}
+
+	slices.SortStableFunc(checks, func(a, b entity.PriceAlertCheck) int {
+		return b.CheckedAt.Compare(a.CheckedAt)
+	})
+
+	pruned := map[string]bool{}
+	for _, c := range checks[maxChecks:] {
+		pruned[c.ID] = true
+	}
+	m.checks = slices.DeleteFunc(m.checks, func(c entity.PriceAlertCheck) bool {
+		return pruned[c.ID]
+	})
+
+	return nil
+}
+
+func (m *InMemoryRepository) ListPriceAlertChecks(
+	_ context.Context,
+	alertID string,
+	limit int,
+) ([]entity.PriceAlertCheck, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	checks := []entity.PriceAlertCheck{}
+	for _, check := range m.checks {
+		if check.AlertID == alertID {
+			checks = append(checks, check)
+		}
+	}
+
+	slices.SortStableFunc(checks, func(a, b entity.PriceAlertCheck) int {
+		return b.CheckedAt.Compare(a.CheckedAt)
+	})
+
+	if len(checks) > limit {
+		checks = checks[:limit]
+	}
+
+	return checks, nil
+}
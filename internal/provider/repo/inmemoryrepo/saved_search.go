@@ -0,0 +1,80 @@
+package inmemoryrepo
+
+import (
+	"context"
+	"slices"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+)
+
+func (m *InMemoryRepository) CreateSavedSearch(
+	_ context.Context,
+	search entity.SavedSearch,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, s := range m.searches {
+		if s.UserID == search.UserID && s.Name == search.Name {
+			return errs.ErrSavedSearchAlreadyExists
+		}
+	}
+
+	m.searches[search.ID] = search
+
+	return nil
+}
+
+func (m *InMemoryRepository) ListSavedSearches(
+	_ context.Context,
+	userID string,
+) ([]entity.SavedSearch, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	searches := []entity.SavedSearch{}
+	for _, search := range m.searches {
+		if search.UserID == userID {
+			searches = append(searches, search)
+		}
+	}
+
+	slices.SortFunc(searches, func(a, b entity.SavedSearch) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	return searches, nil
+}
+
+func (m *InMemoryRepository) GetSavedSearch(
+	_ context.Context,
+	userID, id string,
+) (*entity.SavedSearch, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	search, ok := m.searches[id]
+	if !ok || search.UserID != userID {
+		return nil, errs.ErrSavedSearchNotFound
+	}
+
+	return &search, nil
+}
+
+func (m *InMemoryRepository) DeleteSavedSearch(
+	_ context.Context,
+	userID, id string,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	search, ok := m.searches[id]
+	if !ok || search.UserID != userID {
+		return errs.ErrSavedSearchNotFound
+	}
+
+	delete(m.searches, id)
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package inmemoryrepo
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+)
+
+func (m *InMemoryRepository) CreateUser(
+	_ context.Context,
+	user entity.User,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, u := range m.users {
+		if u.Email == user.Email {
+			return errs.ErrUserAlreadyExists
+		}
+	}
+
+	user.Roles = slices.Clone(user.Roles)
+	m.users[user.ID] = user
+
+	return nil
+}
+
+func (m *InMemoryRepository) GetUserByEmail(
+	_ context.Context,
+	email string,
+) (*entity.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, user := range m.users {
+		if user.Email == email {
+			user.Roles = slices.Clone(user.Roles)
+			return &user, nil
+		}
+	}
+
+	return nil, errs.ErrUserNotFound
+}
+
+func (m *InMemoryRepository) GetUserByID(
+	_ context.Context,
+	id string,
+) (*entity.User, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, ok := m.users[id]
+	if !ok {
+		return nil, errs.ErrUserNotFound
+	}
+
+	user.Roles = slices.Clone(user.Roles)
+	return &user, nil
+}
+
+func (m *InMemoryRepository) UpdateUserRoles(
+	_ context.Context,
+	id string,
+	roles []entity.Role,
+	updatedAt time.Time,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[id]
+	if !ok {
+		return errs.ErrUserNotFound
+	}
+
+	user.Roles = slices.Clone(roles)
+	user.UpdatedAt = updatedAt
+	m.users[id] = user
+
+	return nil
+}
+
+func (m *InMemoryRepository) UpdateUserPassword(
+	_ context.Context,
+	id, passwordHash string,
+	updatedAt time.Time,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[id]
+	if !ok {
+		return errs.ErrUserNotFound
+	}
+
+	user.PasswordHash = passwordHash
+	user.UpdatedAt = updatedAt
+	m.users[id] = user
+
+	return nil
+}
+
+func (m *InMemoryRepository) VerifyUserEmail(
+	_ context.Context,
+	id string,
+	verifiedAt time.Time,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[id]
+	if !ok {
+		return errs.ErrUserNotFound
+	}
+
+	user.EmailVerifiedAt = verifiedAt
+	user.UpdatedAt = verifiedAt
+	m.users[id] = user
+
+	return nil
+}
+
+func (m *InMemoryRepository) UpdateUserOrganization(
+	_ context.Context,
+	id, organizationID string,
+	updatedAt time.Time,
+) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[id]
+	if !ok {
+		return errs.ErrUserNotFound
+	}
+
+	user.OrganizationID = organizationID
+	user.UpdatedAt = updatedAt
+	m.users[id] = user
+
+	return nil
+}
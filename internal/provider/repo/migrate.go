@@ -0,0 +1,114 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+)
+
+// Migrate applies the migrations of fsys that were not applied yet, in
+// order. Migrations are SQL files named after their version, such as
+// 0001_create_users.sql, and each one is applied in a transaction.
+func Migrate(ctx context.Context, conn *sql.Conn, fsys fs.FS) error {
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    INTEGER   PRIMARY KEY,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`); err != nil {
+		return errs.New(err)
+	}
+
+	applied, err := appliedVersions(ctx, conn)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return errs.New(err)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return errs.New(fmt.Sprintf("invalid migration name %q", name))
+		}
+		if applied[version] {
+			continue
+		}
+
+		query, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return errs.New(err)
+		}
+
+		if err := applyMigration(ctx, conn, version, string(query)); err != nil {
+			return errs.New(
+				fmt.Sprintf("failed to apply migration %q: %v", name, err),
+			)
+		}
+	}
+
+	return nil
+}
+
+func appliedVersions(
+	ctx context.Context,
+	conn *sql.Conn,
+) (map[int]bool, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]bool{}
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+
+	return applied, rows.Err()
+}
+
+func applyMigration(
+	ctx context.Context,
+	conn *sql.Conn,
+	version int,
+	query string,
+) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		return err
+	}
+
+	// The version is an integer, so it is safe to inline in the query,
+	// which keeps it free of driver specific placeholders.
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(
+		"INSERT INTO schema_migrations (version) VALUES (%d)",
+		version,
+	)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,177 @@
+package pgrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+)
+
+const apiKeyColumns = "id, user_id, name, prefix, hash, scopes, " +
+	"expires_at, last_used_at, created_at"
+
+func (p *PostgresRepository) CreateAPIKey(
+	ctx context.Context,
+	key entity.APIKey,
+) error {
+	_, err := p.db.ExecContext(
+		ctx,
+		"INSERT INTO api_keys ("+apiKeyColumns+") "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		key.ID,
+		key.UserID,
+		key.Name,
+		key.Prefix,
+		key.Hash,
+		strings.Join(key.Scopes, " "),
+		nullTime(key.ExpiresAt),
+		nullTime(key.LastUsedAt),
+		key.CreatedAt,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (p *PostgresRepository) ListAPIKeys(
+	ctx context.Context,
+	userID string,
+) ([]entity.APIKey, error) {
+	rows, err := p.db.QueryContext(
+		ctx,
+		"SELECT "+apiKeyColumns+" FROM api_keys WHERE user_id = $1 "+
+			"ORDER BY created_at DESC",
+		userID,
+	)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	defer rows.Close()
+
+	keys := []entity.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+		keys = append(keys, *key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return keys, nil
+}
+
+func (p *PostgresRepository) GetAPIKeyByHash(
+	ctx context.Context,
+	hash string,
+) (*entity.APIKey, error) {
+	row := p.db.QueryRowContext(
+		ctx,
+		"SELECT "+apiKeyColumns+" FROM api_keys WHERE hash = $1",
+		hash,
+	)
+
+	key, err := scanAPIKey(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return key, nil
+}
+
+func (p *PostgresRepository) TouchAPIKey(
+	ctx context.Context,
+	id string,
+	lastUsedAt time.Time,
+) error {
+	_, err := p.db.ExecContext(
+		ctx,
+		"UPDATE api_keys SET last_used_at = $1 WHERE id = $2",
+		lastUsedAt,
+		id,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (p *PostgresRepository) DeleteAPIKey(
+	ctx context.Context,
+	userID, id string,
+) error {
+	res, err := p.db.ExecContext(
+		ctx,
+		"DELETE FROM api_keys WHERE user_id = $1 AND id = $2",
+		userID,
+		id,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return errs.New(err)
+	}
+	if deleted == 0 {
+		return errs.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAPIKey(row scanner) (*entity.APIKey, error) {
+	key := &entity.APIKey{}
+	var scopes string
+	var expiresAt, lastUsedAt sql.NullTime
+	err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.Name,
+		&key.Prefix,
+		&key.Hash,
+		&scopes,
+		&expiresAt,
+		&lastUsedAt,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	key.Scopes = strings.Fields(scopes)
+	key.ExpiresAt = expiresAt.Time
+	key.LastUsedAt = lastUsedAt.Time
+
+	return key, nil
+}
+
+// nullTime stores zero times as NULL.
+func nullTime(t time.Time) sql.NullTime {
+	if t.IsZero() {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t, Valid: true}
+}
+
+// nullString stores empty strings as NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,116 @@
+package pgrepo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+)
+
+const auditEventColumns = "id, actor, ip, request_id, action, target, " +
+	"outcome, reason, created_at"
+
+func (p *PostgresRepository) CreateAuditEvent(
+	ctx context.Context,
+	event entity.AuditEvent,
+) error {
+	_, err := p.db.ExecContext(
+		ctx,
+		"INSERT INTO audit_events ("+auditEventColumns+") "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		event.ID,
+		event.Actor,
+		event.IP,
+		event.RequestID,
+		event.Action,
+		event.Target,
+		event.Outcome,
+		event.Reason,
+		event.CreatedAt,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (p *PostgresRepository) ListAuditEvents(
+	ctx context.Context,
+	filter entity.AuditEventFilter,
+) ([]entity.AuditEvent, error) {
+	conditions := []string{}
+	args := []any{}
+	where := func(column, operator string, arg any) {
+		args = append(args, arg)
+		conditions = append(
+			conditions,
+			fmt.Sprintf("%s %s $%d", column, operator, len(args)),
+		)
+	}
+
+	if filter.Actor != "" {
+		where("actor", "=", filter.Actor)
+	}
+	if filter.IP != "" {
+		where("ip", "=", filter.IP)
+	}
+	if filter.Action != "" {
+		where("action", "=", filter.Action)
+	}
+	if filter.Target != "" {
+		where("target", "=", filter.Target)
+	}
+	if filter.Outcome != "" {
+		where("outcome", "=", filter.Outcome)
+	}
+	if !filter.From.IsZero() {
+		where("created_at", ">=", filter.From)
+	}
+	if !filter.To.IsZero() {
+		where("created_at", "<", filter.To)
+	}
+
+	query := "SELECT " + auditEventColumns + " FROM audit_events"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	defer rows.Close()
+
+	events := []entity.AuditEvent{}
+	for rows.Next() {
+		event := entity.AuditEvent{}
+		err := rows.Scan(
+			&event.ID,
+			&event.Actor,
+			&event.IP,
+			&event.RequestID,
+			&event.Action,
+			&event.Target,
+			&event.Outcome,
+			&event.Reason,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,67 @@
+package pgrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const identityColumns = "provider, subject, user_id, email, created_at"
+
+func (p *PostgresRepository) CreateIdentity(
+	ctx context.Context,
+	identity entity.Identity,
+) error {
+	_, err := p.db.ExecContext(
+		ctx,
+		"INSERT INTO identities ("+identityColumns+") "+
+			"VALUES ($1, $2, $3, $4, $5)",
+		identity.Provider,
+		identity.Subject,
+		identity.UserID,
+		identity.Email,
+		identity.CreatedAt,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return errs.ErrIdentityAlreadyExists
+	}
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (p *PostgresRepository) GetIdentity(
+	ctx context.Context,
+	provider, subject string,
+) (*entity.Identity, error) {
+	identity := &entity.Identity{}
+	err := p.db.QueryRowContext(
+		ctx,
+		"SELECT "+identityColumns+" FROM identities "+
+			"WHERE provider = $1 AND subject = $2",
+		provider,
+		subject,
+	).Scan(
+		&identity.Provider,
+		&identity.Subject,
+		&identity.UserID,
+		&identity.Email,
+		&identity.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.ErrIdentityNotFound
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return identity, nil
+}
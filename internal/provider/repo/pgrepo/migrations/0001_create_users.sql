@@ -0,0 +1,8 @@
+CREATE TABLE users (
+	id            UUID        PRIMARY KEY,
+	email         TEXT        NOT NULL UNIQUE,
+	password_hash TEXT        NOT NULL,
+	plan          TEXT        NOT NULL DEFAULT '',
+	created_at    TIMESTAMPTZ NOT NULL,
+	updated_at    TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,13 @@
+CREATE TABLE api_keys (
+	id           UUID        PRIMARY KEY,
+	user_id      UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	name         TEXT        NOT NULL,
+	prefix       TEXT        NOT NULL,
+	hash         TEXT        NOT NULL UNIQUE,
+	scopes       TEXT        NOT NULL,
+	expires_at   TIMESTAMPTZ,
+	last_used_at TIMESTAMPTZ,
+	created_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX api_keys_user_id_idx ON api_keys (user_id);
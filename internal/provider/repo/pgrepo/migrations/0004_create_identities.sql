@@ -0,0 +1,10 @@
+CREATE TABLE identities (
+	provider   TEXT        NOT NULL,
+	subject    TEXT        NOT NULL,
+	user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	email      TEXT        NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	PRIMARY KEY (provider, subject)
+);
+
+CREATE INDEX identities_user_id_idx ON identities (user_id);
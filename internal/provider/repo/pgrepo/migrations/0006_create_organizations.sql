@@ -0,0 +1,17 @@
+CREATE TABLE organizations (
+	id         UUID        PRIMARY KEY,
+	name       TEXT        NOT NULL UNIQUE,
+	created_at TIMESTAMPTZ NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE organization_providers (
+	organization_id UUID        NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+	provider        TEXT        NOT NULL,
+	enabled         BOOLEAN     NOT NULL,
+	credentials     BYTEA       NOT NULL,
+	updated_at      TIMESTAMPTZ NOT NULL,
+	PRIMARY KEY (organization_id, provider)
+);
+
+ALTER TABLE users ADD COLUMN organization_id UUID REFERENCES organizations (id) ON DELETE SET NULL;
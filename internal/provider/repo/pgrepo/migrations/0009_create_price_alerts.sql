@@ -0,0 +1,29 @@
+CREATE TABLE price_alerts (
+	id              UUID        PRIMARY KEY,
+	user_id         UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	origin          TEXT        NOT NULL,
+	destination     TEXT        NOT NULL,
+	date            TIMESTAMPTZ NOT NULL,
+	target_price    BIGINT      NOT NULL,
+	drop_percent    BIGINT      NOT NULL,
+	active          BOOLEAN     NOT NULL,
+	base_price      BIGINT      NOT NULL,
+	last_price      BIGINT      NOT NULL,
+	last_checked_at TIMESTAMPTZ,
+	notified_at     TIMESTAMPTZ,
+	created_at      TIMESTAMPTZ NOT NULL,
+	updated_at      TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX price_alerts_user_id_idx ON price_alerts (user_id);
+CREATE INDEX price_alerts_active_idx ON price_alerts (active, last_checked_at);
+
+CREATE TABLE price_alert_checks (
+	id         UUID        PRIMARY KEY,
+	alert_id   UUID        NOT NULL REFERENCES price_alerts (id) ON DELETE CASCADE,
+	price      BIGINT      NOT NULL,
+	notified   BOOLEAN     NOT NULL,
+	checked_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX price_alert_checks_alert_id_idx ON price_alert_checks (alert_id, checked_at);
@@ -0,0 +1,158 @@
+package pgrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const organizationColumns = "id, name, created_at, updated_at"
+
+const organizationProviderColumns = "organization_id, provider, enabled, " +
+	"credentials, updated_at"
+
+func (p *PostgresRepository) CreateOrganization(
+	ctx context.Context,
+	org entity.Organization,
+) error {
+	_, err := p.db.ExecContext(
+		ctx,
+		"INSERT INTO organizations ("+organizationColumns+") "+
+			"VALUES ($1, $2, $3, $4)",
+		org.ID,
+		org.Name,
+		org.CreatedAt,
+		org.UpdatedAt,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return errs.ErrOrganizationAlreadyExists
+	}
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (p *PostgresRepository) ListOrganizations(
+	ctx context.Context,
+) ([]entity.Organization, error) {
+	rows, err := p.db.QueryContext(
+		ctx,
+		"SELECT "+organizationColumns+" FROM organizations ORDER BY name",
+	)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	defer rows.Close()
+
+	orgs := []entity.Organization{}
+	for rows.Next() {
+		org := entity.Organization{}
+		err := rows.Scan(&org.ID, &org.Name, &org.CreatedAt, &org.UpdatedAt)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+		orgs = append(orgs, org)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return orgs, nil
+}
+
+func (p *PostgresRepository) GetOrganizationByID(
+	ctx context.Context,
+	id string,
+) (*entity.Organization, error) {
+	org := &entity.Organization{}
+	err := p.db.QueryRowContext(
+		ctx,
+		"SELECT "+organizationColumns+" FROM organizations WHERE id = $1",
+		id,
+	).Scan(&org.ID, &org.Name, &org.CreatedAt, &org.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.ErrOrganizationNotFound
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return org, nil
+}
+
+func (p *PostgresRepository) SaveOrganizationProvider(
+	ctx context.Context,
+	provider entity.OrganizationProvider,
+) error {
+	// Providers disabled before being given credentials have none.
+	credentials := provider.Credentials
+	if credentials == nil {
+		credentials = []byte{}
+	}
+
+	_, err := p.db.ExecContext(
+		ctx,
+		"INSERT INTO organization_providers "+
+			"("+organizationProviderColumns+") VALUES ($1, $2, $3, $4, $5) "+
+			"ON CONFLICT (organization_id, provider) DO UPDATE SET "+
+			"enabled = excluded.enabled, "+
+			"credentials = excluded.credentials, "+
+			"updated_at = excluded.updated_at",
+		provider.OrganizationID,
+		provider.Provider,
+		provider.Enabled,
+		credentials,
+		provider.UpdatedAt,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (p *PostgresRepository) ListOrganizationProviders(
+	ctx context.Context,
+	organizationID string,
+) ([]entity.OrganizationProvider, error) {
+	rows, err := p.db.QueryContext(
+		ctx,
+		"SELECT "+organizationProviderColumns+" "+
+			"FROM organization_providers WHERE organization_id = $1 "+
+			"ORDER BY provider",
+		organizationID,
+	)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	defer rows.Close()
+
+	providers := []entity.OrganizationProvider{}
+	for rows.Next() {
+		provider := entity.OrganizationProvider{}
+		err := rows.Scan(
+			&provider.OrganizationID,
+			&provider.Provider,
+			&provider.Enabled,
+			&provider.Credentials,
+			&provider.UpdatedAt,
+		)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+		providers = append(providers, provider)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return providers, nil
+}
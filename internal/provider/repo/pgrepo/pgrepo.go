@@ -0,0 +1,74 @@
+package pgrepo
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"io/fs"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	_ "github.com/jackc/pgx/v5/stdlib" // pgx driver
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// migrationsLockID identifies the advisory lock held while migrating,
+// so that replicas starting together don't apply the same migrations.
+const migrationsLockID = 7_320_510_382
+
+// PostgresRepository stores data in the Postgres database of
+// DATABASE_URL.
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(
+	e *env.Env,
+) *PostgresRepository {
+	db, err := sql.Open("pgx", e.DatabaseURL)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := migrate(context.Background(), db); err != nil {
+		panic(err)
+	}
+
+	return &PostgresRepository{
+		db: db,
+	}
+}
+
+func migrate(ctx context.Context, db *sql.DB) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(
+		ctx,
+		"SELECT pg_advisory_lock($1)",
+		migrationsLockID,
+	); err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = conn.ExecContext(
+			ctx,
+			"SELECT pg_advisory_unlock($1)",
+			migrationsLockID,
+		)
+	}()
+
+	fsys, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return err
+	}
+
+	return repo.Migrate(ctx, conn, fsys)
+}
+
+var _ repo.Repository = (*PostgresRepository)(nil)
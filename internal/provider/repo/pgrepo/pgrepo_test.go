@@ -0,0 +1,80 @@
+package pgrepo
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/repotest"
+	"github.com/danielmesquitta/flight-api/test/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+// newEnv returns the env of a database in a Postgres container of its
+// own.
+func newEnv(t *testing.T) *env.Env {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx := context.Background()
+	connectionString, cleanUp := container.NewPostgresContainer(ctx)
+	t.Cleanup(func() {
+		assert.Nil(t, cleanUp(context.Background()))
+	})
+
+	return &env.Env{
+		DatabaseURL: connectionString,
+	}
+}
+
+func TestPostgresRepository_User(t *testing.T) {
+	repotest.TestUserRepository(t, NewPostgresRepository(newEnv(t)))
+}
+
+func TestPostgresRepository_APIKey(t *testing.T) {
+	repotest.TestAPIKeyRepository(t, NewPostgresRepository(newEnv(t)))
+}
+
+func TestPostgresRepository_Identity(t *testing.T) {
+	repotest.TestIdentityRepository(t, NewPostgresRepository(newEnv(t)))
+}
+
+func TestPostgresRepository_Organization(t *testing.T) {
+	repotest.TestOrganizationRepository(t, NewPostgresRepository(newEnv(t)))
+}
+
+func TestPostgresRepository_Audit(t *testing.T) {
+	repotest.TestAuditRepository(t, NewPostgresRepository(newEnv(t)))
+}
+
+func TestPostgresRepository_SavedSearch(t *testing.T) {
+	repotest.TestSavedSearchRepository(t, NewPostgresRepository(newEnv(t)))
+}
+
+func TestPostgresRepository_PriceAlert(t *testing.T) {
+	repotest.TestPriceAlertRepository(t, NewPostgresRepository(newEnv(t)))
+}
+
+func TestPostgresRepository_Migrate(t *testing.T) {
+	e := newEnv(t)
+
+	// Replicas starting together wait for each other's migrations, which
+	// are applied once.
+	repos := make([]*PostgresRepository, 4)
+	var wg sync.WaitGroup
+	for i := range repos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repos[i] = NewPostgresRepository(e)
+		}()
+	}
+	wg.Wait()
+
+	var versions int
+	err := repos[0].db.QueryRow("SELECT COUNT(*) FROM schema_migrations").
+		Scan(&versions)
+	assert.Nil(t, err)
+	assert.Equal(t, 9, versions)
+}
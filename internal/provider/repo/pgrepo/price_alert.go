@@ -0,0 +1,314 @@
+package pgrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+)
+
+const priceAlertColumns = "id, user_id, origin, destination, date, " +
+	"target_price, drop_percent, active, base_price, last_price, " +
+	"last_checked_at, notified_at, created_at, updated_at"
+
+const priceAlertCheckColumns = "id, alert_id, price, notified, checked_at"
+
+// CreatePriceAlert locks the user while counting their active alerts,
+// so that concurrent creations of the same user are serialized.
+func (p *PostgresRepository) CreatePriceAlert(
+	ctx context.Context,
+	alert entity.PriceAlert,
+	maxActive int,
+) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errs.New(err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	_, err = tx.ExecContext(
+		ctx,
+		"SELECT id FROM users WHERE id = $1 FOR UPDATE",
+		alert.UserID,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	var active int
+	err = tx.QueryRowContext(
+		ctx,
+		"SELECT COUNT(*) FROM price_alerts WHERE user_id = $1 AND active",
+		alert.UserID,
+	).Scan(&active)
+	if err != nil {
+		return errs.New(err)
+	}
+	if active >= maxActive {
+		return errs.ErrPriceAlertLimitReached
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO price_alerts ("+priceAlertColumns+") "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, "+
+			"$13, $14)",
+		alert.ID,
+		alert.UserID,
+		alert.Origin,
+		alert.Destination,
+		alert.Date,
+		alert.TargetPrice,
+		alert.DropPercent,
+		alert.Active,
+		alert.BasePrice,
+		alert.LastPrice,
+		nullTime(alert.LastCheckedAt),
+		nullTime(alert.NotifiedAt),
+		alert.CreatedAt,
+		alert.UpdatedAt,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (p *PostgresRepository) ListPriceAlerts(
+	ctx context.Context,
+	userID string,
+) ([]entity.PriceAlert, error) {
+	return p.listPriceAlerts(
+		ctx,
+		"SELECT "+priceAlertColumns+" FROM price_alerts "+
+			"WHERE user_id = $1 ORDER BY created_at DESC",
+		userID,
+	)
+}
+
+func (p *PostgresRepository) ListActivePriceAlerts(
+	ctx context.Context,
+) ([]entity.PriceAlert, error) {
+	return p.listPriceAlerts(
+		ctx,
+		"SELECT "+priceAlertColumns+" FROM price_alerts "+
+			"WHERE active ORDER BY last_checked_at NULLS FIRST, created_at",
+	)
+}
+
+func (p *PostgresRepository) listPriceAlerts(
+	ctx context.Context,
+	query string,
+	args ...any,
+) ([]entity.PriceAlert, error) {
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	defer rows.Close()
+
+	alerts := []entity.PriceAlert{}
+	for rows.Next() {
+		alert, err := scanPriceAlert(rows)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+		alerts = append(alerts, *alert)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return alerts, nil
+}
+
+func (p *PostgresRepository) GetPriceAlert(
+	ctx context.Context,
+	userID, id string,
+) (*entity.PriceAlert, error) {
+	row := p.db.QueryRowContext(
+		ctx,
+		"SELECT "+priceAlertColumns+" FROM price_alerts "+
+			"WHERE user_id = $1 AND id = $2",
+		userID,
+		id,
+	)
+
+	alert, err := scanPriceAlert(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.ErrPriceAlertNotFound
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return alert, nil
+}
+
+func (p *PostgresRepository) UpdatePriceAlertState(
+	ctx context.Context,
+	alert entity.PriceAlert,
+) error {
+	res, err := p.db.ExecContext(
+		ctx,
+		"UPDATE price_alerts SET active = $1, base_price = $2, "+
+			"last_price = $3, last_checked_at = $4, notified_at = $5, "+
+			"updated_at = $6 WHERE id = $7",
+		alert.Active,
+		alert.BasePrice,
+		alert.LastPrice,
+		nullTime(alert.LastCheckedAt),
+		nullTime(alert.NotifiedAt),
+		alert.UpdatedAt,
+		alert.ID,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return errs.New(err)
+	}
+	if updated == 0 {
+		return errs.ErrPriceAlertNotFound
+	}
+
+	return nil
+}
+
+func (p *PostgresRepository) DeletePriceAlert(
+	ctx context.Context,
+	userID, id string,
+) error {
+	res, err := p.db.ExecContext(
+		ctx,
+		"DELETE FROM price_alerts WHERE user_id = $1 AND id = $2",
+		userID,
+		id,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return errs.New(err)
+	}
+	if deleted == 0 {
+		return errs.ErrPriceAlertNotFound
+	}
+
+	return nil
+}
+
+func (p *PostgresRepository) CreatePriceAlertCheck(
+	ctx context.Context,
+	check entity.PriceAlertCheck,
+	maxChecks int,
+) error {
+	_, err := p.db.ExecContext(
+		ctx,
+		"INSERT INTO price_alert_checks ("+priceAlertCheckColumns+") "+
+			"VALUES ($1, $2, $3, $4, $5)",
+		check.ID,
+		check.AlertID,
+		check.Price,
+		check.Notified,
+		check.CheckedAt,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	_, err = p.db.ExecContext(
+		ctx,
+		"DELETE FROM price_alert_checks WHERE id IN ("+
+			"SELECT id FROM price_alert_checks WHERE alert_id = $1 "+
+			"ORDER BY checked_at DESC OFFSET $2)",
+		check.AlertID,
+		maxChecks,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (p *PostgresRepository) ListPriceAlertChecks(
+	ctx context.Context,
+	alertID string,
+	limit int,
+) ([]entity.PriceAlertCheck, error) {
+	rows, err := p.db.QueryContext(
+		ctx,
+		"SELECT "+priceAlertCheckColumns+" FROM price_alert_checks "+
+			"WHERE alert_id = $1 ORDER BY checked_at DESC LIMIT $2",
+		alertID,
+		limit,
+	)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	defer rows.Close()
+
+	checks := []entity.PriceAlertCheck{}
+	for rows.Next() {
+		check := entity.PriceAlertCheck{}
+		err := rows.Scan(
+			&check.ID,
+			&check.AlertID,
+			&check.Price,
+			&check.Notified,
+			&check.CheckedAt,
+		)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+		checks = append(checks, check)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return checks, nil
+}
+
+func scanPriceAlert(row scanner) (*entity.PriceAlert, error) {
+	alert := &entity.PriceAlert{}
+	var lastCheckedAt, notifiedAt sql.NullTime
+	err := row.Scan(
+		&alert.ID,
+		&alert.UserID,
+		&alert.Origin,
+		&alert.Destination,
+		&alert.Date,
+		&alert.TargetPrice,
+		&alert.DropPercent,
+		&alert.Active,
+		&alert.BasePrice,
+		&alert.LastPrice,
+		&lastCheckedAt,
+		&notifiedAt,
+		&alert.CreatedAt,
+		&alert.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	alert.LastCheckedAt = lastCheckedAt.Time
+	alert.NotifiedAt = notifiedAt.Time
+
+	return alert, nil
+}
@@ -0,0 +1,148 @@
+package pgrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const savedSearchColumns = "id, user_id, name, origin, destination, date, " +
+	"sort_by, sort_order, max_price, max_duration, page_size, created_at"
+
+func (p *PostgresRepository) CreateSavedSearch(
+	ctx context.Context,
+	search entity.SavedSearch,
+) error {
+	_, err := p.db.ExecContext(
+		ctx,
+		"INSERT INTO saved_searches ("+savedSearchColumns+") "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+		search.ID,
+		search.UserID,
+		search.Name,
+		search.Origin,
+		search.Destination,
+		search.Date,
+		search.SortBy,
+		search.SortOrder,
+		search.MaxPrice,
+		search.MaxDuration,
+		search.PageSize,
+		search.CreatedAt,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return errs.ErrSavedSearchAlreadyExists
+	}
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (p *PostgresRepository) ListSavedSearches(
+	ctx context.Context,
+	userID string,
+) ([]entity.SavedSearch, error) {
+	rows, err := p.db.QueryContext(
+		ctx,
+		"SELECT "+savedSearchColumns+" FROM saved_searches "+
+			"WHERE user_id = $1 ORDER BY created_at DESC",
+		userID,
+	)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	defer rows.Close()
+
+	searches := []entity.SavedSearch{}
+	for rows.Next() {
+		search, err := scanSavedSearch(rows)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+		searches = append(searches, *search)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return searches, nil
+}
+
+func (p *PostgresRepository) GetSavedSearch(
+	ctx context.Context,
+	userID, id string,
+) (*entity.SavedSearch, error) {
+	row := p.db.QueryRowContext(
+		ctx,
+		"SELECT "+savedSearchColumns+" FROM saved_searches "+
+			"WHERE user_id = $1 AND id = $2",
+		userID,
+		id,
+	)
+
+	search, err := scanSavedSearch(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.ErrSavedSearchNotFound
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return search, nil
+}
+
+func (p *PostgresRepository) DeleteSavedSearch(
+	ctx context.Context,
+	userID, id string,
+) error {
+	res, err := p.db.ExecContext(
+		ctx,
+		"DELETE FROM saved_searches WHERE user_id = $1 AND id = $2",
+		userID,
+		id,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return errs.New(err)
+	}
+	if deleted == 0 {
+		return errs.ErrSavedSearchNotFound
+	}
+
+	return nil
+}
+
+func scanSavedSearch(row scanner) (*entity.SavedSearch, error) {
+	search := &entity.SavedSearch{}
+	err := row.Scan(
+		&search.ID,
+		&search.UserID,
+		&search.Name,
+		&search.Origin,
+		&search.Destination,
+		&search.Date,
+		&search.SortBy,
+		&search.SortOrder,
+		&search.MaxPrice,
+		&search.MaxDuration,
+		&search.PageSize,
+		&search.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return search, nil
+}
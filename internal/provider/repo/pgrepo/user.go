@@ -0,0 +1,187 @@
+package pgrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolation is the Postgres error code of unique constraint
+// violations.
+const uniqueViolation = "23505"
+
+const userColumns = "id, email, password_hash, plan, roles, " +
+	"organization_id, email_verified_at, created_at, updated_at"
+
+func (p *PostgresRepository) CreateUser(
+	ctx context.Context,
+	user entity.User,
+) error {
+	_, err := p.db.ExecContext(
+		ctx,
+		"INSERT INTO users ("+userColumns+") "+
+			"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		user.ID,
+		user.Email,
+		user.PasswordHash,
+		user.Plan,
+		strings.Join(user.Roles, " "),
+		nullString(user.OrganizationID),
+		nullTime(user.EmailVerifiedAt),
+		user.CreatedAt,
+		user.UpdatedAt,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return errs.ErrUserAlreadyExists
+	}
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (p *PostgresRepository) GetUserByEmail(
+	ctx context.Context,
+	email string,
+) (*entity.User, error) {
+	return p.getUser(
+		ctx,
+		"SELECT "+userColumns+" FROM users WHERE email = $1",
+		email,
+	)
+}
+
+func (p *PostgresRepository) GetUserByID(
+	ctx context.Context,
+	id string,
+) (*entity.User, error) {
+	return p.getUser(
+		ctx,
+		"SELECT "+userColumns+" FROM users WHERE id = $1",
+		id,
+	)
+}
+
+func (p *PostgresRepository) getUser(
+	ctx context.Context,
+	query string,
+	args ...any,
+) (*entity.User, error) {
+	user := &entity.User{}
+	var roles string
+	var organizationID sql.NullString
+	var emailVerifiedAt sql.NullTime
+	err := p.db.QueryRowContext(ctx, query, args...).Scan(
+		&user.ID,
+		&user.Email,
+		&user.PasswordHash,
+		&user.Plan,
+		&roles,
+		&organizationID,
+		&emailVerifiedAt,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	user.Roles = strings.Fields(roles)
+	user.OrganizationID = organizationID.String
+	user.EmailVerifiedAt = emailVerifiedAt.Time
+
+	return user, nil
+}
+
+func (p *PostgresRepository) UpdateUserRoles(
+	ctx context.Context,
+	id string,
+	roles []entity.Role,
+	updatedAt time.Time,
+) error {
+	return p.updateUser(
+		ctx,
+		"UPDATE users SET roles = $1, updated_at = $2 WHERE id = $3",
+		strings.Join(roles, " "),
+		updatedAt,
+		id,
+	)
+}
+
+func (p *PostgresRepository) UpdateUserPassword(
+	ctx context.Context,
+	id, passwordHash string,
+	updatedAt time.Time,
+) error {
+	return p.updateUser(
+		ctx,
+		"UPDATE users SET password_hash = $1, updated_at = $2 WHERE id = $3",
+		passwordHash,
+		updatedAt,
+		id,
+	)
+}
+
+func (p *PostgresRepository) VerifyUserEmail(
+	ctx context.Context,
+	id string,
+	verifiedAt time.Time,
+) error {
+	return p.updateUser(
+		ctx,
+		"UPDATE users SET email_verified_at = $1, updated_at = $2 "+
+			"WHERE id = $3",
+		verifiedAt,
+		verifiedAt,
+		id,
+	)
+}
+
+func (p *PostgresRepository) UpdateUserOrganization(
+	ctx context.Context,
+	id, organizationID string,
+	updatedAt time.Time,
+) error {
+	return p.updateUser(
+		ctx,
+		"UPDATE users SET organization_id = $1, updated_at = $2 "+
+			"WHERE id = $3",
+		nullString(organizationID),
+		updatedAt,
+		id,
+	)
+}
+
+// updateUser runs an update of a single user, which must exist.
+func (p *PostgresRepository) updateUser(
+	ctx context.Context,
+	query string,
+	args ...any,
+) error {
+	res, err := p.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return errs.New(err)
+	}
+	if updated == 0 {
+		return errs.ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+)
+
+// Repository groups every repository, implemented over a single
+// database.
+type Repository interface {
+	UserRepository
+	APIKeyRepository
+	IdentityRepository
+	OrganizationRepository
+	AuditRepository
+	SavedSearchRepository
+	PriceAlertRepository
+}
+
+type UserRepository interface {
+	// CreateUser returns errs.ErrUserAlreadyExists if the e-mail is taken.
+	CreateUser(ctx context.Context, user entity.User) error
+
+	// GetUserByEmail returns errs.ErrUserNotFound if there is no user with
+	// the e-mail.
+	GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
+
+	// GetUserByID returns errs.ErrUserNotFound if there is no user with
+	// the id.
+	GetUserByID(ctx context.Context, id string) (*entity.User, error)
+
+	// UpdateUserRoles returns errs.ErrUserNotFound if there is no user
+	// with the id.
+	UpdateUserRoles(
+		ctx context.Context,
+		id string,
+		roles []entity.Role,
+		updatedAt time.Time,
+	) error
+
+	// UpdateUserPassword returns errs.ErrUserNotFound if there is no user
+	// with the id.
+	UpdateUserPassword(
+		ctx context.Context,
+		id, passwordHash string,
+		updatedAt time.Time,
+	) error
+
+	// VerifyUserEmail returns errs.ErrUserNotFound if there is no user
+	// with the id.
+	VerifyUserEmail(ctx context.Context, id string, verifiedAt time.Time) error
+
+	// UpdateUserOrganization returns errs.ErrUserNotFound if there is no
+	// user with the id. An empty organization id moves the user back to
+	// the default tenant.
+	UpdateUserOrganization(
+		ctx context.Context,
+		id, organizationID string,
+		updatedAt time.Time,
+	) error
+}
+
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, key entity.APIKey) error
+
+	// ListAPIKeys returns the keys of the user, newest first.
+	ListAPIKeys(ctx context.Context, userID string) ([]entity.APIKey, error)
+
+	// GetAPIKeyByHash returns errs.ErrAPIKeyNotFound if there is no key
+	// with the hash.
+	GetAPIKeyByHash(ctx context.Context, hash string) (*entity.APIKey, error)
+
+	// TouchAPIKey records when the key was last used.
+	TouchAPIKey(ctx context.Context, id string, lastUsedAt time.Time) error
+
+	// DeleteAPIKey returns errs.ErrAPIKeyNotFound if the user has no key
+	// with the id.
+	DeleteAPIKey(ctx context.Context, userID, id string) error
+}
+
+type IdentityRepository interface {
+	// CreateIdentity returns errs.ErrIdentityAlreadyExists if the
+	// identity is already linked.
+	CreateIdentity(ctx context.Context, identity entity.Identity) error
+
+	// GetIdentity returns errs.ErrIdentityNotFound if there is no identity
+	// with the subject at the provider.
+	GetIdentity(
+		ctx context.Context,
+		provider, subject string,
+	) (*entity.Identity, error)
+}
+
+type OrganizationRepository interface {
+	// CreateOrganization returns errs.ErrOrganizationAlreadyExists if the
+	// name is taken.
+	CreateOrganization(ctx context.Context, org entity.Organization) error
+
+	// ListOrganizations returns every organization, sorted by name.
+	ListOrganizations(ctx context.Context) ([]entity.Organization, error)
+
+	// GetOrganizationByID returns errs.ErrOrganizationNotFound if there is
+	// no organization with the id.
+	GetOrganizationByID(
+		ctx context.Context,
+		id string,
+	) (*entity.Organization, error)
+
+	// SaveOrganizationProvider creates or replaces the provider of the
+	// organization.
+	SaveOrganizationProvider(
+		ctx context.Context,
+		provider entity.OrganizationProvider,
+	) error
+
+	// ListOrganizationProviders returns the providers configured for the
+	// organization, sorted by provider.
+	ListOrganizationProviders(
+		ctx context.Context,
+		organizationID string,
+	) ([]entity.OrganizationProvider, error)
+}
+
+type AuditRepository interface {
+	CreateAuditEvent(ctx context.Context, event entity.AuditEvent) error
+
+	// ListAuditEvents returns the events selected by the filter, newest
+	// first, up to its limit if it has one.
+	ListAuditEvents(
+		ctx context.Context,
+		filter entity.AuditEventFilter,
+	) ([]entity.AuditEvent, error)
+}
+
+type SavedSearchRepository interface {
+	// CreateSavedSearch returns errs.ErrSavedSearchAlreadyExists if the
+	// user has a saved search with the same name.
+	CreateSavedSearch(ctx context.Context, search entity.SavedSearch) error
+
+	// ListSavedSearches returns the saved searches of the user, newest
+	// first.
+	ListSavedSearches(
+		ctx context.Context,
+		userID string,
+	) ([]entity.SavedSearch, error)
+
+	// GetSavedSearch returns errs.ErrSavedSearchNotFound if the user has
+	// no saved search with the id.
+	GetSavedSearch(
+		ctx context.Context,
+		userID, id string,
+	) (*entity.SavedSearch, error)
+
+	// DeleteSavedSearch returns errs.ErrSavedSearchNotFound if the user
+	// has no saved search with the id.
+	DeleteSavedSearch(ctx context.Context, userID, id string) error
+}
+
+type PriceAlertRepository interface {
+	// CreatePriceAlert returns errs.ErrPriceAlertLimitReached if the user
+	// already has maxActive active alerts, checked along with the insert,
+	// so that concurrent creations can't exceed it.
+	CreatePriceAlert(
+		ctx context.Context,
+		alert entity.PriceAlert,
+		maxActive int,
+	) error
+
+	// ListPriceAlerts returns the alerts of the user, newest first.
+	ListPriceAlerts(
+		ctx context.Context,
+		userID string,
+	) ([]entity.PriceAlert, error)
+
+	// ListActivePriceAlerts returns the active alerts of every user,
+	// least recently checked first.
+	ListActivePriceAlerts(ctx context.Context) ([]entity.PriceAlert, error)
+
+	// GetPriceAlert returns errs.ErrPriceAlertNotFound if the user has no
+	// alert with the id.
+	GetPriceAlert(
+		ctx context.Context,
+		userID, id string,
+	) (*entity.PriceAlert, error)
+
+	// UpdatePriceAlertState saves whether the alert is active, and its
+	// prices and check and notification times. It returns
+	// errs.ErrPriceAlertNotFound if there is no alert with the id.
+	UpdatePriceAlertState(ctx context.Context, alert entity.PriceAlert) error
+
+	// DeletePriceAlert deletes the alert and its checks. It returns
+	// errs.ErrPriceAlertNotFound if the user has no alert with the id.
+	DeletePriceAlert(ctx context.Context, userID, id string) error
+
+	// CreatePriceAlertCheck saves the check, keeping only the newest
+	// maxChecks checks of its alert.
+	CreatePriceAlertCheck(
+		ctx context.Context,
+		check entity.PriceAlertCheck,
+		maxChecks int,
+	) error
+
+	// ListPriceAlertChecks returns the checks of the alert, newest first,
+	// up to the limit.
+	ListPriceAlertChecks(
+		ctx context.Context,
+		alertID string,
+		limit int,
+	) ([]entity.PriceAlertCheck, error)
+}
@@ -0,0 +1,26 @@
+package repodriver
+
+import (
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/inmemoryrepo"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/pgrepo"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/sqliterepo"
+)
+
+// NewRepository returns the repository implementation selected by
+// DATABASE_DRIVER, with its migrations applied.
+func NewRepository(
+	e *env.Env,
+) repo.Repository {
+	switch e.DatabaseDriver {
+	case env.DatabaseDriverPostgres:
+		return pgrepo.NewPostgresRepository(e)
+
+	case env.DatabaseDriverInMemory:
+		return inmemoryrepo.NewInMemoryRepository()
+
+	default:
+		return sqliterepo.NewSQLiteRepository(e)
+	}
+}
@@ -0,0 +1,93 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyRepository(t *testing.T, r repo.Repository) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	user := entity.User{
+		ID:           uuid.NewString(),
+		Email:        "apikeys@email.com",
+		PasswordHash: "hash",
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	assert.Nil(t, r.CreateUser(ctx, user))
+
+	older := entity.APIKey{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		Name:      "older",
+		Prefix:    "fa_older",
+		Hash:      "older-hash",
+		Scopes:    []entity.Scope{entity.ScopeFlightsSearch},
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now.Add(-time.Minute),
+	}
+	newer := entity.APIKey{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		Name:      "newer",
+		Prefix:    "fa_newer",
+		Hash:      "newer-hash",
+		Scopes:    []entity.Scope{},
+		CreatedAt: now,
+	}
+	assert.Nil(t, r.CreateAPIKey(ctx, older))
+	assert.Nil(t, r.CreateAPIKey(ctx, newer))
+
+	got, err := r.GetAPIKeyByHash(ctx, older.Hash)
+	assert.Nil(t, err)
+	assertAPIKey(t, older, got)
+
+	lastUsedAt := now.Add(time.Second)
+	assert.Nil(t, r.TouchAPIKey(ctx, newer.ID, lastUsedAt))
+	newer.LastUsedAt = lastUsedAt
+
+	keys, err := r.ListAPIKeys(ctx, user.ID)
+	assert.Nil(t, err)
+	if assert.Len(t, keys, 2) {
+		assertAPIKey(t, newer, &keys[0])
+		assertAPIKey(t, older, &keys[1])
+	}
+
+	keys, err = r.ListAPIKeys(ctx, uuid.NewString())
+	assert.Nil(t, err)
+	assert.Empty(t, keys)
+
+	err = r.DeleteAPIKey(ctx, uuid.NewString(), older.ID)
+	assert.ErrorIs(t, err, errs.ErrAPIKeyNotFound)
+
+	assert.Nil(t, r.DeleteAPIKey(ctx, user.ID, older.ID))
+
+	_, err = r.GetAPIKeyByHash(ctx, older.Hash)
+	assert.ErrorIs(t, err, errs.ErrAPIKeyNotFound)
+
+	err = r.DeleteAPIKey(ctx, user.ID, older.ID)
+	assert.ErrorIs(t, err, errs.ErrAPIKeyNotFound)
+}
+
+func assertAPIKey(t *testing.T, want entity.APIKey, got *entity.APIKey) {
+	if !assert.NotNil(t, got) {
+		return
+	}
+	assert.True(t, want.ExpiresAt.Equal(got.ExpiresAt))
+	assert.True(t, want.LastUsedAt.Equal(got.LastUsedAt))
+	assert.True(t, want.CreatedAt.Equal(got.CreatedAt))
+
+	got.ExpiresAt = want.ExpiresAt
+	got.LastUsedAt = want.LastUsedAt
+	got.CreatedAt = want.CreatedAt
+	assert.Equal(t, want, *got)
+}
@@ -0,0 +1,113 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditRepository(t *testing.T, r repo.Repository) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	login := entity.AuditEvent{
+		ID:        uuid.NewString(),
+		Actor:     "email:johndoe@email.com",
+		IP:        "10.0.0.1",
+		RequestID: "request-1",
+		Action:    entity.AuditActionLogin,
+		Target:    "email:johndoe@email.com",
+		Outcome:   entity.AuditOutcomeFailure,
+		Reason:    "invalid credentials",
+		CreatedAt: now.Add(-time.Minute),
+	}
+	retry := entity.AuditEvent{
+		ID:        uuid.NewString(),
+		Actor:     "user:1",
+		IP:        "10.0.0.1",
+		RequestID: "request-2",
+		Action:    entity.AuditActionLogin,
+		Target:    "user:1",
+		Outcome:   entity.AuditOutcomeSuccess,
+		CreatedAt: now,
+	}
+	purge := entity.AuditEvent{
+		ID:        uuid.NewString(),
+		Actor:     "admin:admin",
+		IP:        "10.0.0.2",
+		RequestID: "request-3",
+		Action:    entity.AuditActionCachePurge,
+		Target:    "/api/v1/admin/cache/keys?pattern=*",
+		Outcome:   entity.AuditOutcomeSuccess,
+		CreatedAt: now.Add(time.Minute),
+	}
+	for _, event := range []entity.AuditEvent{login, retry, purge} {
+		assert.Nil(t, r.CreateAuditEvent(ctx, event))
+	}
+
+	ids := func(filter entity.AuditEventFilter) []string {
+		events, err := r.ListAuditEvents(ctx, filter)
+		assert.Nil(t, err)
+
+		ids := []string{}
+		for _, event := range events {
+			ids = append(ids, event.ID)
+		}
+		return ids
+	}
+
+	assert.Equal(
+		t,
+		[]string{purge.ID, retry.ID, login.ID},
+		ids(entity.AuditEventFilter{}),
+		"should list newest first",
+	)
+	assert.Equal(
+		t,
+		[]string{purge.ID, retry.ID},
+		ids(entity.AuditEventFilter{Limit: 2}),
+	)
+	assert.Equal(
+		t,
+		[]string{retry.ID, login.ID},
+		ids(entity.AuditEventFilter{Action: entity.AuditActionLogin}),
+	)
+	assert.Equal(
+		t,
+		[]string{login.ID},
+		ids(entity.AuditEventFilter{
+			IP:      "10.0.0.1",
+			Outcome: entity.AuditOutcomeFailure,
+		}),
+	)
+	assert.Equal(
+		t,
+		[]string{retry.ID},
+		ids(entity.AuditEventFilter{
+			Actor:  "user:1",
+			Target: "user:1",
+		}),
+	)
+	assert.Equal(
+		t,
+		[]string{retry.ID},
+		ids(entity.AuditEventFilter{From: now, To: now.Add(time.Minute)}),
+	)
+
+	events, err := r.ListAuditEvents(
+		ctx,
+		entity.AuditEventFilter{Actor: login.Actor},
+	)
+	assert.Nil(t, err)
+	if assert.Len(t, events, 1) {
+		got := events[0]
+		assert.True(t, login.CreatedAt.Equal(got.CreatedAt))
+		got.CreatedAt = login.CreatedAt
+		assert.Equal(t, login, got)
+	}
+}
@@ -0,0 +1,49 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityRepository(t *testing.T, r repo.Repository) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	user := entity.User{
+		ID:        uuid.NewString(),
+		Email:     "identities@email.com",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	assert.Nil(t, r.CreateUser(ctx, user))
+
+	identity := entity.Identity{
+		Provider:  "acme",
+		Subject:   "248289761001",
+		UserID:    user.ID,
+		Email:     user.Email,
+		CreatedAt: now,
+	}
+	assert.Nil(t, r.CreateIdentity(ctx, identity))
+
+	err := r.CreateIdentity(ctx, identity)
+	assert.ErrorIs(t, err, errs.ErrIdentityAlreadyExists)
+
+	got, err := r.GetIdentity(ctx, identity.Provider, identity.Subject)
+	assert.Nil(t, err)
+	if assert.NotNil(t, got) {
+		assert.True(t, identity.CreatedAt.Equal(got.CreatedAt))
+		got.CreatedAt = identity.CreatedAt
+		assert.Equal(t, identity, *got)
+	}
+
+	_, err = r.GetIdentity(ctx, "other", identity.Subject)
+	assert.ErrorIs(t, err, errs.ErrIdentityNotFound)
+}
@@ -0,0 +1,120 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizationRepository(t *testing.T, r repo.Repository) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	org := entity.Organization{
+		ID:        uuid.NewString(),
+		Name:      "Globetrotter Travel",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	assert.Nil(t, r.CreateOrganization(ctx, org))
+
+	other := entity.Organization{
+		ID:        uuid.NewString(),
+		Name:      "Atlas Tours",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	assert.Nil(t, r.CreateOrganization(ctx, other))
+
+	sameName := org
+	sameName.ID = uuid.NewString()
+	err := r.CreateOrganization(ctx, sameName)
+	assert.ErrorIs(t, err, errs.ErrOrganizationAlreadyExists)
+
+	got, err := r.GetOrganizationByID(ctx, org.ID)
+	assert.Nil(t, err)
+	if assert.NotNil(t, got) {
+		assert.True(t, org.CreatedAt.Equal(got.CreatedAt))
+		assert.Equal(t, org.Name, got.Name)
+	}
+
+	_, err = r.GetOrganizationByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, errs.ErrOrganizationNotFound)
+
+	orgs, err := r.ListOrganizations(ctx)
+	assert.Nil(t, err)
+	if assert.Len(t, orgs, 2) {
+		assert.Equal(t, other.ID, orgs[0].ID)
+		assert.Equal(t, org.ID, orgs[1].ID)
+	}
+
+	provider := entity.OrganizationProvider{
+		OrganizationID: org.ID,
+		Provider:       "duffel",
+		Enabled:        true,
+		Credentials:    []byte("sealed"),
+		UpdatedAt:      now,
+	}
+	assert.Nil(t, r.SaveOrganizationProvider(ctx, provider))
+
+	provider.Enabled = false
+	provider.Credentials = []byte("resealed")
+	provider.UpdatedAt = now.Add(time.Second)
+	assert.Nil(t, r.SaveOrganizationProvider(ctx, provider))
+
+	assert.Nil(t, r.SaveOrganizationProvider(ctx, entity.OrganizationProvider{
+		OrganizationID: org.ID,
+		Provider:       "amadeus",
+		UpdatedAt:      now,
+	}))
+
+	providers, err := r.ListOrganizationProviders(ctx, org.ID)
+	assert.Nil(t, err)
+	if assert.Len(t, providers, 2) {
+		assert.Equal(t, "amadeus", providers[0].Provider)
+		assert.Empty(t, providers[0].Credentials)
+
+		got := providers[1]
+		assert.True(t, provider.UpdatedAt.Equal(got.UpdatedAt))
+		got.UpdatedAt = provider.UpdatedAt
+		assert.Equal(t, provider, got)
+	}
+
+	providers, err = r.ListOrganizationProviders(ctx, other.ID)
+	assert.Nil(t, err)
+	assert.Empty(t, providers)
+
+	user := entity.User{
+		ID:        uuid.NewString(),
+		Email:     "organizations@email.com",
+		Roles:     []entity.Role{},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	assert.Nil(t, r.CreateUser(ctx, user))
+
+	user.OrganizationID = org.ID
+	user.UpdatedAt = now.Add(time.Second)
+	err = r.UpdateUserOrganization(ctx, user.ID, org.ID, user.UpdatedAt)
+	assert.Nil(t, err)
+
+	gotUser, err := r.GetUserByID(ctx, user.ID)
+	assert.Nil(t, err)
+	assertUser(t, user, gotUser)
+
+	user.OrganizationID = ""
+	assert.Nil(t, r.UpdateUserOrganization(ctx, user.ID, "", user.UpdatedAt))
+
+	gotUser, err = r.GetUserByID(ctx, user.ID)
+	assert.Nil(t, err)
+	assertUser(t, user, gotUser)
+
+	err = r.UpdateUserOrganization(ctx, uuid.NewString(), org.ID, now)
+	assert.ErrorIs(t, err, errs.ErrUserNotFound)
+}
@@ -0,0 +1,200 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriceAlertRepository(t *testing.T, r repo.Repository) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	date := time.Date(2030, time.January, 10, 0, 0, 0, 0, time.UTC)
+
+	user := entity.User{
+		ID:           uuid.NewString(),
+		Email:        "pricealerts@email.com",
+		PasswordHash: "hash",
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	assert.Nil(t, r.CreateUser(ctx, user))
+
+	checked := entity.PriceAlert{
+		ID:            uuid.NewString(),
+		UserID:        user.ID,
+		Origin:        "SYD",
+		Destination:   "BKK",
+		Date:          date,
+		TargetPrice:   50000,
+		Active:        true,
+		BasePrice:     60000,
+		LastPrice:     60000,
+		LastCheckedAt: now.Add(-time.Minute),
+		CreatedAt:     now.Add(-time.Hour),
+		UpdatedAt:     now.Add(-time.Minute),
+	}
+	unchecked := entity.PriceAlert{
+		ID:          uuid.NewString(),
+		UserID:      user.ID,
+		Origin:      "SYD",
+		Destination: "MEL",
+		Date:        date,
+		DropPercent: 10,
+		Active:      true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	assert.Nil(t, r.CreatePriceAlert(ctx, checked, 2))
+	assert.Nil(t, r.CreatePriceAlert(ctx, unchecked, 2))
+
+	extra := unchecked
+	extra.ID = uuid.NewString()
+	err := r.CreatePriceAlert(ctx, extra, 2)
+	assert.ErrorIs(t, err, errs.ErrPriceAlertLimitReached)
+
+	got, err := r.GetPriceAlert(ctx, user.ID, checked.ID)
+	assert.Nil(t, err)
+	assertPriceAlert(t, checked, got)
+
+	_, err = r.GetPriceAlert(ctx, uuid.NewString(), checked.ID)
+	assert.ErrorIs(t, err, errs.ErrPriceAlertNotFound)
+
+	alerts, err := r.ListPriceAlerts(ctx, user.ID)
+	assert.Nil(t, err)
+	if assert.Len(t, alerts, 2) {
+		assertPriceAlert(t, unchecked, &alerts[0])
+		assertPriceAlert(t, checked, &alerts[1])
+	}
+
+	alerts, err = r.ListActivePriceAlerts(ctx)
+	assert.Nil(t, err)
+	if assert.Len(t, alerts, 2, "should list alerts never checked first") {
+		assert.Equal(t, unchecked.ID, alerts[0].ID)
+		assert.Equal(t, checked.ID, alerts[1].ID)
+	}
+
+	checked.Active = false
+	checked.BasePrice = 45000
+	checked.LastPrice = 45000
+	checked.LastCheckedAt = now
+	checked.NotifiedAt = now
+	checked.UpdatedAt = now
+	assert.Nil(t, r.UpdatePriceAlertState(ctx, checked))
+
+	got, err = r.GetPriceAlert(ctx, user.ID, checked.ID)
+	assert.Nil(t, err)
+	assertPriceAlert(t, checked, got)
+
+	alerts, err = r.ListActivePriceAlerts(ctx)
+	assert.Nil(t, err)
+	if assert.Len(t, alerts, 1) {
+		assert.Equal(t, unchecked.ID, alerts[0].ID)
+	}
+
+	missing := unchecked
+	missing.ID = uuid.NewString()
+	err = r.UpdatePriceAlertState(ctx, missing)
+	assert.ErrorIs(t, err, errs.ErrPriceAlertNotFound)
+
+	err = r.CreatePriceAlert(ctx, extra, 2)
+	assert.Nil(t, err, "should only count active alerts")
+
+	older := entity.PriceAlertCheck{
+		ID:        uuid.NewString(),
+		AlertID:   checked.ID,
+		Price:     60000,
+		CheckedAt: now.Add(-time.Minute),
+	}
+	newer := entity.PriceAlertCheck{
+		ID:        uuid.NewString(),
+		AlertID:   checked.ID,
+		Price:     45000,
+		Notified:  true,
+		CheckedAt: now,
+	}
+	assert.Nil(t, r.CreatePriceAlertCheck(ctx, older, 10))
+	assert.Nil(t, r.CreatePriceAlertCheck(ctx, newer, 10))
+
+	checks, err := r.ListPriceAlertChecks(ctx, checked.ID, 10)
+	assert.Nil(t, err)
+	if assert.Len(t, checks, 2) {
+		assertPriceAlertCheck(t, newer, checks[0])
+		assertPriceAlertCheck(t, older, checks[1])
+	}
+
+	checks, err = r.ListPriceAlertChecks(ctx, checked.ID, 1)
+	assert.Nil(t, err)
+	if assert.Len(t, checks, 1) {
+		assert.Equal(t, newer.ID, checks[0].ID)
+	}
+
+	newest := entity.PriceAlertCheck{
+		ID:        uuid.NewString(),
+		AlertID:   checked.ID,
+		Price:     40000,
+		CheckedAt: now.Add(time.Minute),
+	}
+	assert.Nil(t, r.CreatePriceAlertCheck(ctx, newest, 2))
+
+	checks, err = r.ListPriceAlertChecks(ctx, checked.ID, 10)
+	assert.Nil(t, err)
+	if assert.Len(t, checks, 2, "should delete the oldest checks") {
+		assert.Equal(t, newest.ID, checks[0].ID)
+		assert.Equal(t, newer.ID, checks[1].ID)
+	}
+
+	err = r.DeletePriceAlert(ctx, uuid.NewString(), checked.ID)
+	assert.ErrorIs(t, err, errs.ErrPriceAlertNotFound)
+
+	assert.Nil(t, r.DeletePriceAlert(ctx, user.ID, checked.ID))
+
+	_, err = r.GetPriceAlert(ctx, user.ID, checked.ID)
+	assert.ErrorIs(t, err, errs.ErrPriceAlertNotFound)
+
+	checks, err = r.ListPriceAlertChecks(ctx, checked.ID, 10)
+	assert.Nil(t, err)
+	assert.Empty(t, checks, "should delete the checks of the alert")
+
+	err = r.DeletePriceAlert(ctx, user.ID, checked.ID)
+	assert.ErrorIs(t, err, errs.ErrPriceAlertNotFound)
+}
+
+func assertPriceAlert(
+	t *testing.T,
+	want entity.PriceAlert,
+	got *entity.PriceAlert,
+) {
+	if !assert.NotNil(t, got) {
+		return
+	}
+	assert.True(t, want.Date.Equal(got.Date))
+	assert.True(t, want.LastCheckedAt.Equal(got.LastCheckedAt))
+	assert.True(t, want.NotifiedAt.Equal(got.NotifiedAt))
+	assert.True(t, want.CreatedAt.Equal(got.CreatedAt))
+	assert.True(t, want.UpdatedAt.Equal(got.UpdatedAt))
+
+	got.Date = want.Date
+	got.LastCheckedAt = want.LastCheckedAt
+	got.NotifiedAt = want.NotifiedAt
+	got.CreatedAt = want.CreatedAt
+	got.UpdatedAt = want.UpdatedAt
+	assert.Equal(t, want, *got)
+}
+
+func assertPriceAlertCheck(
+	t *testing.T,
+	want entity.PriceAlertCheck,
+	got entity.PriceAlertCheck,
+) {
+	assert.True(t, want.CheckedAt.Equal(got.CheckedAt))
+
+	got.CheckedAt = want.CheckedAt
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,101 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedSearchRepository(t *testing.T, r repo.Repository) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	user := entity.User{
+		ID:           uuid.NewString(),
+		Email:        "savedsearches@email.com",
+		PasswordHash: "hash",
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	assert.Nil(t, r.CreateUser(ctx, user))
+
+	older := entity.SavedSearch{
+		ID:          uuid.NewString(),
+		UserID:      user.ID,
+		Name:        "Weekend in Bangkok",
+		Origin:      "SYD",
+		Destination: "BKK",
+		Date:        "next friday",
+		SortBy:      "duration",
+		SortOrder:   "desc",
+		MaxPrice:    100000,
+		MaxDuration: 600,
+		PageSize:    10,
+		CreatedAt:   now.Add(-time.Minute),
+	}
+	newer := entity.SavedSearch{
+		ID:          uuid.NewString(),
+		UserID:      user.ID,
+		Name:        "Next month",
+		Origin:      "SYD",
+		Destination: "MEL",
+		Date:        "+30d",
+		CreatedAt:   now,
+	}
+	assert.Nil(t, r.CreateSavedSearch(ctx, older))
+	assert.Nil(t, r.CreateSavedSearch(ctx, newer))
+
+	duplicate := newer
+	duplicate.ID = uuid.NewString()
+	err := r.CreateSavedSearch(ctx, duplicate)
+	assert.ErrorIs(t, err, errs.ErrSavedSearchAlreadyExists)
+
+	got, err := r.GetSavedSearch(ctx, user.ID, older.ID)
+	assert.Nil(t, err)
+	assertSavedSearch(t, older, got)
+
+	_, err = r.GetSavedSearch(ctx, uuid.NewString(), older.ID)
+	assert.ErrorIs(t, err, errs.ErrSavedSearchNotFound)
+
+	searches, err := r.ListSavedSearches(ctx, user.ID)
+	assert.Nil(t, err)
+	if assert.Len(t, searches, 2) {
+		assertSavedSearch(t, newer, &searches[0])
+		assertSavedSearch(t, older, &searches[1])
+	}
+
+	searches, err = r.ListSavedSearches(ctx, uuid.NewString())
+	assert.Nil(t, err)
+	assert.Empty(t, searches)
+
+	err = r.DeleteSavedSearch(ctx, uuid.NewString(), older.ID)
+	assert.ErrorIs(t, err, errs.ErrSavedSearchNotFound)
+
+	assert.Nil(t, r.DeleteSavedSearch(ctx, user.ID, older.ID))
+
+	_, err = r.GetSavedSearch(ctx, user.ID, older.ID)
+	assert.ErrorIs(t, err, errs.ErrSavedSearchNotFound)
+
+	err = r.DeleteSavedSearch(ctx, user.ID, older.ID)
+	assert.ErrorIs(t, err, errs.ErrSavedSearchNotFound)
+}
+
+func assertSavedSearch(
+	t *testing.T,
+	want entity.SavedSearch,
+	got *entity.SavedSearch,
+) {
+	if !assert.NotNil(t, got) {
+		return
+	}
+	assert.True(t, want.CreatedAt.Equal(got.CreatedAt))
+
+	got.CreatedAt = want.CreatedAt
+	assert.Equal(t, want, *got)
+}
@@ -0,0 +1,98 @@
+// Package repotest holds the tests shared by every repository
+// implementation.
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserRepository(t *testing.T, r repo.UserRepository) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	user := entity.User{
+		ID:           uuid.NewString(),
+		Email:        "johndoe@email.com",
+		PasswordHash: "hash",
+		Plan:         "pro",
+		Roles:        []entity.Role{},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	assert.Nil(t, r.CreateUser(ctx, user))
+
+	got, err := r.GetUserByEmail(ctx, user.Email)
+	assert.Nil(t, err)
+	assertUser(t, user, got)
+
+	got, err = r.GetUserByID(ctx, user.ID)
+	assert.Nil(t, err)
+	assertUser(t, user, got)
+
+	sameEmail := user
+	sameEmail.ID = uuid.NewString()
+	assert.ErrorIs(t, r.CreateUser(ctx, sameEmail), errs.ErrUserAlreadyExists)
+
+	user.Roles = []entity.Role{entity.RoleAdmin}
+	user.UpdatedAt = now.Add(time.Second)
+	assert.Nil(t, r.UpdateUserRoles(ctx, user.ID, user.Roles, user.UpdatedAt))
+
+	got, err = r.GetUserByID(ctx, user.ID)
+	assert.Nil(t, err)
+	assertUser(t, user, got)
+
+	err = r.UpdateUserRoles(ctx, uuid.NewString(), user.Roles, now)
+	assert.ErrorIs(t, err, errs.ErrUserNotFound)
+
+	user.PasswordHash = "new-hash"
+	user.UpdatedAt = now.Add(2 * time.Second)
+	err = r.UpdateUserPassword(
+		ctx,
+		user.ID,
+		user.PasswordHash,
+		user.UpdatedAt,
+	)
+	assert.Nil(t, err)
+
+	user.EmailVerifiedAt = now.Add(3 * time.Second)
+	user.UpdatedAt = user.EmailVerifiedAt
+	assert.Nil(t, r.VerifyUserEmail(ctx, user.ID, user.EmailVerifiedAt))
+
+	got, err = r.GetUserByID(ctx, user.ID)
+	assert.Nil(t, err)
+	assertUser(t, user, got)
+
+	err = r.UpdateUserPassword(ctx, uuid.NewString(), "hash", now)
+	assert.ErrorIs(t, err, errs.ErrUserNotFound)
+
+	err = r.VerifyUserEmail(ctx, uuid.NewString(), now)
+	assert.ErrorIs(t, err, errs.ErrUserNotFound)
+
+	_, err = r.GetUserByEmail(ctx, "janedoe@email.com")
+	assert.ErrorIs(t, err, errs.ErrUserNotFound)
+
+	_, err = r.GetUserByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, errs.ErrUserNotFound)
+}
+
+func assertUser(t *testing.T, want entity.User, got *entity.User) {
+	if !assert.NotNil(t, got) {
+		return
+	}
+	assert.True(t, want.CreatedAt.Equal(got.CreatedAt))
+	assert.True(t, want.UpdatedAt.Equal(got.UpdatedAt))
+	assert.True(t, want.EmailVerifiedAt.Equal(got.EmailVerifiedAt))
+
+	got.CreatedAt, got.UpdatedAt = want.CreatedAt, want.UpdatedAt
+	got.EmailVerifiedAt = want.EmailVerifiedAt
+	assert.Equal(t, want, *got)
+}
@@ -0,0 +1,112 @@
+package sqliterepo
+
+import (
+	"context"
+	"strings"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+)
+
+const auditEventColumns = "id, actor, ip, request_id, action, target, " +
+	"outcome, reason, created_at"
+
+func (s *SQLiteRepository) CreateAuditEvent(
+	ctx context.Context,
+	event entity.AuditEvent,
+) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		"INSERT INTO audit_events ("+auditEventColumns+") "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		event.ID,
+		event.Actor,
+		event.IP,
+		event.RequestID,
+		event.Action,
+		event.Target,
+		event.Outcome,
+		event.Reason,
+		event.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (s *SQLiteRepository) ListAuditEvents(
+	ctx context.Context,
+	filter entity.AuditEventFilter,
+) ([]entity.AuditEvent, error) {
+	conditions := []string{}
+	args := []any{}
+	where := func(condition string, arg any) {
+		conditions = append(conditions, condition)
+		args = append(args, arg)
+	}
+
+	if filter.Actor != "" {
+		where("actor = ?", filter.Actor)
+	}
+	if filter.IP != "" {
+		where("ip = ?", filter.IP)
+	}
+	if filter.Action != "" {
+		where("action = ?", filter.Action)
+	}
+	if filter.Target != "" {
+		where("target = ?", filter.Target)
+	}
+	if filter.Outcome != "" {
+		where("outcome = ?", filter.Outcome)
+	}
+	if !filter.From.IsZero() {
+		where("created_at >= ?", filter.From.UTC())
+	}
+	if !filter.To.IsZero() {
+		where("created_at < ?", filter.To.UTC())
+	}
+
+	query := "SELECT " + auditEventColumns + " FROM audit_events"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY created_at DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	defer rows.Close()
+
+	events := []entity.AuditEvent{}
+	for rows.Next() {
+		event := entity.AuditEvent{}
+		err := rows.Scan(
+			&event.ID,
+			&event.Actor,
+			&event.IP,
+			&event.RequestID,
+			&event.Action,
+			&event.Target,
+			&event.Outcome,
+			&event.Reason,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,68 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/mattn/go-sqlite3"
+)
+
+const identityColumns = "provider, subject, user_id, email, created_at"
+
+func (s *SQLiteRepository) CreateIdentity(
+	ctx context.Context,
+	identity entity.Identity,
+) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		"INSERT INTO identities ("+identityColumns+") "+
+			"VALUES (?, ?, ?, ?, ?)",
+		identity.Provider,
+		identity.Subject,
+		identity.UserID,
+		identity.Email,
+		identity.CreatedAt.UTC(),
+	)
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) &&
+		sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey {
+		return errs.ErrIdentityAlreadyExists
+	}
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (s *SQLiteRepository) GetIdentity(
+	ctx context.Context,
+	provider, subject string,
+) (*entity.Identity, error) {
+	identity := &entity.Identity{}
+	err := s.db.QueryRowContext(
+		ctx,
+		"SELECT "+identityColumns+" FROM identities "+
+			"WHERE provider = ? AND subject = ?",
+		provider,
+		subject,
+	).Scan(
+		&identity.Provider,
+		&identity.Subject,
+		&identity.UserID,
+		&identity.Email,
+		&identity.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.ErrIdentityNotFound
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return identity, nil
+}
@@ -0,0 +1,8 @@
+CREATE TABLE users (
+	id            TEXT      PRIMARY KEY,
+	email         TEXT      NOT NULL UNIQUE,
+	password_hash TEXT      NOT NULL,
+	plan          TEXT      NOT NULL DEFAULT '',
+	created_at    TIMESTAMP NOT NULL,
+	updated_at    TIMESTAMP NOT NULL
+);
@@ -0,0 +1,13 @@
+CREATE TABLE api_keys (
+	id           TEXT      PRIMARY KEY,
+	user_id      TEXT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	name         TEXT      NOT NULL,
+	prefix       TEXT      NOT NULL,
+	hash         TEXT      NOT NULL UNIQUE,
+	scopes       TEXT      NOT NULL,
+	expires_at   TIMESTAMP,
+	last_used_at TIMESTAMP,
+	created_at   TIMESTAMP NOT NULL
+);
+
+CREATE INDEX api_keys_user_id_idx ON api_keys (user_id);
@@ -0,0 +1,17 @@
+CREATE TABLE organizations (
+	id         TEXT      PRIMARY KEY,
+	name       TEXT      NOT NULL UNIQUE,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE organization_providers (
+	organization_id TEXT      NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+	provider        TEXT      NOT NULL,
+	enabled         BOOLEAN   NOT NULL,
+	credentials     BLOB      NOT NULL,
+	updated_at      TIMESTAMP NOT NULL,
+	PRIMARY KEY (organization_id, provider)
+);
+
+ALTER TABLE users ADD COLUMN organization_id TEXT REFERENCES organizations (id) ON DELETE SET NULL;
@@ -0,0 +1,15 @@
+CREATE TABLE audit_events (
+	id         TEXT      PRIMARY KEY,
+	actor      TEXT      NOT NULL,
+	ip         TEXT      NOT NULL,
+	request_id TEXT      NOT NULL,
+	action     TEXT      NOT NULL,
+	target     TEXT      NOT NULL,
+	outcome    TEXT      NOT NULL,
+	reason     TEXT      NOT NULL,
+	created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX audit_events_created_at_idx ON audit_events (created_at);
+CREATE INDEX audit_events_actor_idx ON audit_events (actor, created_at);
+CREATE INDEX audit_events_action_idx ON audit_events (action, created_at);
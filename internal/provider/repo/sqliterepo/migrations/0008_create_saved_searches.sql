@@ -0,0 +1,15 @@
+CREATE TABLE saved_searches (
+	id           TEXT      PRIMARY KEY,
+	user_id      TEXT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	name         TEXT      NOT NULL,
+	origin       TEXT      NOT NULL,
+	destination  TEXT      NOT NULL,
+	date         TEXT      NOT NULL,
+	sort_by      TEXT      NOT NULL,
+	sort_order   TEXT      NOT NULL,
+	max_price    INTEGER   NOT NULL,
+	max_duration INTEGER   NOT NULL,
+	page_size    INTEGER   NOT NULL,
+	created_at   TIMESTAMP NOT NULL,
+	UNIQUE (user_id, name)
+);
@@ -0,0 +1,29 @@
+CREATE TABLE price_alerts (
+	id              TEXT      PRIMARY KEY,
+	user_id         TEXT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+	origin          TEXT      NOT NULL,
+	destination     TEXT      NOT NULL,
+	date            TIMESTAMP NOT NULL,
+	target_price    INTEGER   NOT NULL,
+	drop_percent    INTEGER   NOT NULL,
+	active          BOOLEAN   NOT NULL,
+	base_price      INTEGER   NOT NULL,
+	last_price      INTEGER   NOT NULL,
+	last_checked_at TIMESTAMP,
+	notified_at     TIMESTAMP,
+	created_at      TIMESTAMP NOT NULL,
+	updated_at      TIMESTAMP NOT NULL
+);
+
+CREATE INDEX price_alerts_user_id_idx ON price_alerts (user_id);
+CREATE INDEX price_alerts_active_idx ON price_alerts (active, last_checked_at);
+
+CREATE TABLE price_alert_checks (
+	id         TEXT      PRIMARY KEY,
+	alert_id   TEXT      NOT NULL REFERENCES price_alerts (id) ON DELETE CASCADE,
+	price      INTEGER   NOT NULL,
+	notified   BOOLEAN   NOT NULL,
+	checked_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX price_alert_checks_alert_id_idx ON price_alert_checks (alert_id, checked_at);
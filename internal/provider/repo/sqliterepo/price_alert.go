@@ -0,0 +1,314 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+)
+
+const priceAlertColumns = "id, user_id, origin, destination, date, " +
+	"target_price, drop_percent, active, base_price, last_price, " +
+	"last_checked_at, notified_at, created_at, updated_at"
+
+const priceAlertCheckColumns = "id, alert_id, price, notified, checked_at"
+
+// CreatePriceAlert counts the active alerts of the user in the insert,
+// which SQLite runs atomically, as it has a single writer.
+func (s *SQLiteRepository) CreatePriceAlert(
+	ctx context.Context,
+	alert entity.PriceAlert,
+	maxActive int,
+) error {
+	res, err := s.db.ExecContext(
+		ctx,
+		"INSERT INTO price_alerts ("+priceAlertColumns+") "+
+			"SELECT ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? "+
+			"WHERE (SELECT COUNT(*) FROM price_alerts "+
+			"WHERE user_id = ? AND active) < ?",
+		alert.ID,
+		alert.UserID,
+		alert.Origin,
+		alert.Destination,
+		alert.Date.UTC(),
+		alert.TargetPrice,
+		alert.DropPercent,
+		alert.Active,
+		alert.BasePrice,
+		alert.LastPrice,
+		nullTime(alert.LastCheckedAt),
+		nullTime(alert.NotifiedAt),
+		alert.CreatedAt.UTC(),
+		alert.UpdatedAt.UTC(),
+		alert.UserID,
+		maxActive,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	created, err := res.RowsAffected()
+	if err != nil {
+		return errs.New(err)
+	}
+	if created == 0 {
+		return errs.ErrPriceAlertLimitReached
+	}
+
+	return nil
+}
+
+func (s *SQLiteRepository) ListPriceAlerts(
+	ctx context.Context,
+	userID string,
+) ([]entity.PriceAlert, error) {
+	return s.listPriceAlerts(
+		ctx,
+		"SELECT "+priceAlertColumns+" FROM price_alerts "+
+			"WHERE user_id = ? ORDER BY created_at DESC",
+		userID,
+	)
+}
+
+func (s *SQLiteRepository) ListActivePriceAlerts(
+	ctx context.Context,
+) ([]entity.PriceAlert, error) {
+	return s.listPriceAlerts(
+		ctx,
+		"SELECT "+priceAlertColumns+" FROM price_alerts "+
+			"WHERE active ORDER BY last_checked_at NULLS FIRST, created_at",
+	)
+}
+
+func (s *SQLiteRepository) listPriceAlerts(
+	ctx context.Context,
+	query string,
+	args ...any,
+) ([]entity.PriceAlert, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	defer rows.Close()
+
+	alerts := []entity.PriceAlert{}
+	for rows.Next() {
+		alert, err := scanPriceAlert(rows)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+		alerts = append(alerts, *alert)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return alerts, nil
+}
+
+func (s *SQLiteRepository) GetPriceAlert(
+	ctx context.Context,
+	userID, id string,
+) (*entity.PriceAlert, error) {
+	row := s.db.QueryRowContext(
+		ctx,
+		"SELECT "+priceAlertColumns+" FROM price_alerts "+
+			"WHERE user_id = ? AND id = ?",
+		userID,
+		id,
+	)
+
+	alert, err := scanPriceAlert(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.ErrPriceAlertNotFound
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return alert, nil
+}
+
+func (s *SQLiteRepository) UpdatePriceAlertState(
+	ctx context.Context,
+	alert entity.PriceAlert,
+) error {
+	res, err := s.db.ExecContext(
+		ctx,
+		"UPDATE price_alerts SET active = ?, base_price = ?, "+
+			"last_price = ?, last_checked_at = ?, notified_at = ?, "+
+			"updated_at = ? WHERE id = ?",
+		alert.Active,
+		alert.BasePrice,
+		alert.LastPrice,
+		nullTime(alert.LastCheckedAt),
+		nullTime(alert.NotifiedAt),
+		alert.UpdatedAt.UTC(),
+		alert.ID,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return errs.New(err)
+	}
+	if updated == 0 {
+		return errs.ErrPriceAlertNotFound
+	}
+
+	return nil
+}
+
+func (s *SQLiteRepository) DeletePriceAlert(
+	ctx context.Context,
+	userID, id string,
+) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errs.New(err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	res, err := tx.ExecContext(
+		ctx,
+		"DELETE FROM price_alerts WHERE user_id = ? AND id = ?",
+		userID,
+		id,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return errs.New(err)
+	}
+	if deleted == 0 {
+		return errs.ErrPriceAlertNotFound
+	}
+
+	// Foreign keys aren't enforced, so the checks aren't deleted in
+	// cascade.
+	_, err = tx.ExecContext(
+		ctx,
+		"DELETE FROM price_alert_checks WHERE alert_id = ?",
+		id,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (s *SQLiteRepository) CreatePriceAlertCheck(
+	ctx context.Context,
+	check entity.PriceAlertCheck,
+	maxChecks int,
+) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		"INSERT INTO price_alert_checks ("+priceAlertCheckColumns+") "+
+			"VALUES (?, ?, ?, ?, ?)",
+		check.ID,
+		check.AlertID,
+		check.Price,
+		check.Notified,
+		check.CheckedAt.UTC(),
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	_, err = s.db.ExecContext(
+		ctx,
+		"DELETE FROM price_alert_checks WHERE id IN ("+
+			"SELECT id FROM price_alert_checks WHERE alert_id = ? "+
+			"ORDER BY checked_at DESC LIMIT -1 OFFSET ?)",
+		check.AlertID,
+		maxChecks,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (s *SQLiteRepository) ListPriceAlertChecks(
+	ctx context.Context,
+	alertID string,
+	limit int,
+) ([]entity.PriceAlertCheck, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		"SELECT "+priceAlertCheckColumns+" FROM price_alert_checks "+
+			"WHERE alert_id = ? ORDER BY checked_at DESC LIMIT ?",
+		alertID,
+		limit,
+	)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	defer rows.Close()
+
+	checks := []entity.PriceAlertCheck{}
+	for rows.Next() {
+		check := entity.PriceAlertCheck{}
+		err := rows.Scan(
+			&check.ID,
+			&check.AlertID,
+			&check.Price,
+			&check.Notified,
+			&check.CheckedAt,
+		)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+		checks = append(checks, check)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return checks, nil
+}
+
+func scanPriceAlert(row scanner) (*entity.PriceAlert, error) {
+	alert := &entity.PriceAlert{}
+	var lastCheckedAt, notifiedAt sql.NullTime
+	err := row.Scan(
+		&alert.ID,
+		&alert.UserID,
+		&alert.Origin,
+		&alert.Destination,
+		&alert.Date,
+		&alert.TargetPrice,
+		&alert.DropPercent,
+		&alert.Active,
+		&alert.BasePrice,
+		&alert.LastPrice,
+		&lastCheckedAt,
+		&notifiedAt,
+		&alert.CreatedAt,
+		&alert.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	alert.LastCheckedAt = lastCheckedAt.Time
+	alert.NotifiedAt = notifiedAt.Time
+
+	return alert, nil
+}
@@ -0,0 +1,149 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/mattn/go-sqlite3"
+)
+
+const savedSearchColumns = "id, user_id, name, origin, destination, date, " +
+	"sort_by, sort_order, max_price, max_duration, page_size, created_at"
+
+func (s *SQLiteRepository) CreateSavedSearch(
+	ctx context.Context,
+	search entity.SavedSearch,
+) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		"INSERT INTO saved_searches ("+savedSearchColumns+") "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		search.ID,
+		search.UserID,
+		search.Name,
+		search.Origin,
+		search.Destination,
+		search.Date,
+		search.SortBy,
+		search.SortOrder,
+		search.MaxPrice,
+		search.MaxDuration,
+		search.PageSize,
+		search.CreatedAt.UTC(),
+	)
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) &&
+		sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+		return errs.ErrSavedSearchAlreadyExists
+	}
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (s *SQLiteRepository) ListSavedSearches(
+	ctx context.Context,
+	userID string,
+) ([]entity.SavedSearch, error) {
+	rows, err := s.db.QueryContext(
+		ctx,
+		"SELECT "+savedSearchColumns+" FROM saved_searches "+
+			"WHERE user_id = ? ORDER BY created_at DESC",
+		userID,
+	)
+	if err != nil {
+		return nil, errs.New(err)
+	}
+	defer rows.Close()
+
+	searches := []entity.SavedSearch{}
+	for rows.Next() {
+		search, err := scanSavedSearch(rows)
+		if err != nil {
+			return nil, errs.New(err)
+		}
+		searches = append(searches, *search)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errs.New(err)
+	}
+
+	return searches, nil
+}
+
+func (s *SQLiteRepository) GetSavedSearch(
+	ctx context.Context,
+	userID, id string,
+) (*entity.SavedSearch, error) {
+	row := s.db.QueryRowContext(
+		ctx,
+		"SELECT "+savedSearchColumns+" FROM saved_searches "+
+			"WHERE user_id = ? AND id = ?",
+		userID,
+		id,
+	)
+
+	search, err := scanSavedSearch(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.ErrSavedSearchNotFound
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	return search, nil
+}
+
+func (s *SQLiteRepository) DeleteSavedSearch(
+	ctx context.Context,
+	userID, id string,
+) error {
+	res, err := s.db.ExecContext(
+		ctx,
+		"DELETE FROM saved_searches WHERE user_id = ? AND id = ?",
+		userID,
+		id,
+	)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return errs.New(err)
+	}
+	if deleted == 0 {
+		return errs.ErrSavedSearchNotFound
+	}
+
+	return nil
+}
+
+func scanSavedSearch(row scanner) (*entity.SavedSearch, error) {
+	search := &entity.SavedSearch{}
+	err := row.Scan(
+		&search.ID,
+		&search.UserID,
+		&search.Name,
+		&search.Origin,
+		&search.Destination,
+		&search.Date,
+		&search.SortBy,
+		&search.SortOrder,
+		&search.MaxPrice,
+		&search.MaxDuration,
+		&search.PageSize,
+		&search.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return search, nil
+}
@@ -0,0 +1,55 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"io/fs"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo"
+	_ "github.com/mattn/go-sqlite3" // sqlite3 driver
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// SQLiteRepository stores data in an embedded SQLite database, at the
+// path or file URI of DATABASE_URL.
+type SQLiteRepository struct {
+	db *sql.DB
+}
+
+func NewSQLiteRepository(
+	e *env.Env,
+) *SQLiteRepository {
+	db, err := sql.Open("sqlite3", e.DatabaseURL)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := migrate(context.Background(), db); err != nil {
+		panic(err)
+	}
+
+	return &SQLiteRepository{
+		db: db,
+	}
+}
+
+func migrate(ctx context.Context, db *sql.DB) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	fsys, err := fs.Sub(migrations, "migrations")
+	if err != nil {
+		return err
+	}
+
+	return repo.Migrate(ctx, conn, fsys)
+}
+
+var _ repo.Repository = (*SQLiteRepository)(nil)
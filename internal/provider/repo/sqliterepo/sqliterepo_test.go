@@ -0,0 +1,82 @@
+package sqliterepo
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/provider/repo/repotest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLiteRepository_User(t *testing.T) {
+	e := &env.Env{
+		DatabaseURL: filepath.Join(t.TempDir(), "test.db"),
+	}
+
+	repotest.TestUserRepository(t, NewSQLiteRepository(e))
+}
+
+func TestSQLiteRepository_APIKey(t *testing.T) {
+	e := &env.Env{
+		DatabaseURL: filepath.Join(t.TempDir(), "test.db"),
+	}
+
+	repotest.TestAPIKeyRepository(t, NewSQLiteRepository(e))
+}
+
+func TestSQLiteRepository_Identity(t *testing.T) {
+	e := &env.Env{
+		DatabaseURL: filepath.Join(t.TempDir(), "test.db"),
+	}
+
+	repotest.TestIdentityRepository(t, NewSQLiteRepository(e))
+}
+
+func TestSQLiteRepository_Organization(t *testing.T) {
+	e := &env.Env{
+		DatabaseURL: filepath.Join(t.TempDir(), "test.db"),
+	}
+
+	repotest.TestOrganizationRepository(t, NewSQLiteRepository(e))
+}
+
+func TestSQLiteRepository_Audit(t *testing.T) {
+	e := &env.Env{
+		DatabaseURL: filepath.Join(t.TempDir(), "test.db"),
+	}
+
+	repotest.TestAuditRepository(t, NewSQLiteRepository(e))
+}
+
+func TestSQLiteRepository_SavedSearch(t *testing.T) {
+	e := &env.Env{
+		DatabaseURL: filepath.Join(t.TempDir(), "test.db"),
+	}
+
+	repotest.TestSavedSearchRepository(t, NewSQLiteRepository(e))
+}
+
+func TestSQLiteRepository_PriceAlert(t *testing.T) {
+	e := &env.Env{
+		DatabaseURL: filepath.Join(t.TempDir(), "test.db"),
+	}
+
+	repotest.TestPriceAlertRepository(t, NewSQLiteRepository(e))
+}
+
+func TestSQLiteRepository_Migrate(t *testing.T) {
+	e := &env.Env{
+		DatabaseURL: filepath.Join(t.TempDir(), "test.db"),
+	}
+
+	// Migrations already applied are skipped.
+	NewSQLiteRepository(e)
+	s := NewSQLiteRepository(e)
+
+	var versions int
+	err := s.db.QueryRow("SELECT COUNT(*) FROM schema_migrations").
+		Scan(&versions)
+	assert.Nil(t, err)
+	assert.Equal(t, 9, versions)
+}
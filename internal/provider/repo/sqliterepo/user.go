@@ -0,0 +1,184 @@
+package sqliterepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/errs"
+	"github.com/mattn/go-sqlite3"
+)
+
+const userColumns = "id, email, password_hash, plan, roles, " +
+	"organization_id, email_verified_at, created_at, updated_at"
+
+func (s *SQLiteRepository) CreateUser(
+	ctx context.Context,
+	user entity.User,
+) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		"INSERT INTO users ("+userColumns+") "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		user.ID,
+		user.Email,
+		user.PasswordHash,
+		user.Plan,
+		strings.Join(user.Roles, " "),
+		nullString(user.OrganizationID),
+		nullTime(user.EmailVerifiedAt),
+		user.CreatedAt.UTC(),
+		user.UpdatedAt.UTC(),
+	)
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) &&
+		sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+		return errs.ErrUserAlreadyExists
+	}
+	if err != nil {
+		return errs.New(err)
+	}
+
+	return nil
+}
+
+func (s *SQLiteRepository) GetUserByEmail(
+	ctx context.Context,
+	email string,
+) (*entity.User, error) {
+	return s.getUser(
+		ctx,
+		"SELECT "+userColumns+" FROM users WHERE email = ?",
+		email,
+	)
+}
+
+func (s *SQLiteRepository) GetUserByID(
+	ctx context.Context,
+	id string,
+) (*entity.User, error) {
+	return s.getUser(
+		ctx,
+		"SELECT "+userColumns+" FROM users WHERE id = ?",
+		id,
+	)
+}
+
+func (s *SQLiteRepository) getUser(
+	ctx context.Context,
+	query string,
+	args ...any,
+) (*entity.User, error) {
+	user := &entity.User{}
+	var roles string
+	var organizationID sql.NullString
+	var emailVerifiedAt sql.NullTime
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(
+		&user.ID,
+		&user.Email,
+		&user.PasswordHash,
+		&user.Plan,
+		&roles,
+		&organizationID,
+		&emailVerifiedAt,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errs.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, errs.New(err)
+	}
+
+	user.Roles = strings.Fields(roles)
+	user.OrganizationID = organizationID.String
+	user.EmailVerifiedAt = emailVerifiedAt.Time
+
+	return user, nil
+}
+
+func (s *SQLiteRepository) UpdateUserRoles(
+	ctx context.Context,
+	id string,
+	roles []entity.Role,
+	updatedAt time.Time,
+) error {
+	return s.updateUser(
+		ctx,
+		"UPDATE users SET roles = ?, updated_at = ? WHERE id = ?",
+		strings.Join(roles, " "),
+		updatedAt.UTC(),
+		id,
+	)
+}
+
+func (s *SQLiteRepository) UpdateUserPassword(
+	ctx context.Context,
+	id, passwordHash string,
+	updatedAt time.Time,
+) error {
+	return s.updateUser(
+		ctx,
+		"UPDATE users SET password_hash = ?, updated_at = ? WHERE id = ?",
+		passwordHash,
+		updatedAt.UTC(),
+		id,
+	)
+}
+
+func (s *SQLiteRepository) VerifyUserEmail(
+	ctx context.Context,
+	id string,
+	verifiedAt time.Time,
+) error {
+	return s.updateUser(
+		ctx,
+		"UPDATE users SET email_verified_at = ?, updated_at = ? "+
+			"WHERE id = ?",
+		verifiedAt.UTC(),
+		verifiedAt.UTC(),
+		id,
+	)
+}
+
+func (s *SQLiteRepository) UpdateUserOrganization(
+	ctx context.Context,
+	id, organizationID string,
+	updatedAt time.Time,
+) error {
+	return s.updateUser(
+		ctx,
+		"UPDATE users SET organization_id = ?, updated_at = ? "+
+			"WHERE id = ?",
+		nullString(organizationID),
+		updatedAt.UTC(),
+		id,
+	)
+}
+
+// updateUser runs an update of a single user, which must exist.
+func (s *SQLiteRepository) updateUser(
+	ctx context.Context,
+	query string,
+	args ...any,
+) error {
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return errs.New(err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return errs.New(err)
+	}
+	if updated == 0 {
+		return errs.ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package container
+
+import (
+	"context"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+)
+
+func NewPostgresContainer(
+	ctx context.Context,
+) (connectionString string, cleanUp func(context.Context) error) {
+	postgresCont, err := postgres.Run(
+		ctx,
+		"postgres:alpine",
+		postgres.WithDatabase("flight-api"),
+		postgres.BasicWaitStrategies(),
+		testcontainers.WithLogger(newLogger()),
+	)
+	if err != nil {
+		panic(err)
+	}
+	connStr, err := postgresCont.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		panic(err)
+	}
+
+	cleanUp = func(ctx context.Context) error {
+		return postgresCont.Terminate(ctx)
+	}
+
+	return connStr, cleanUp
+}
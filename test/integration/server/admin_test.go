@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielmesquitta/flight-api/internal/app/server/dto"
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminAccess(t *testing.T) {
+	t.Parallel()
+
+	app, cleanUp := NewTestApp(t)
+	defer func() {
+		err := cleanUp(context.Background())
+		assert.Nil(t, err)
+	}()
+
+	registered := app.Register("johndoe@email.com", "P@ssw0rd")
+	login := app.Login("johndoe@email.com", "P@ssw0rd")
+
+	usage := func(opts ...RequestOption) int {
+		statusCode, _, err := app.MakeRequest(
+			http.MethodGet,
+			"/api/v1/admin/providers/usage",
+			opts...,
+		)
+		assert.Nil(t, err)
+		return statusCode
+	}
+
+	admin := WithBasicAuth(ev.AdminUsername, ev.AdminPassword)
+	wrongPassword := WithBasicAuth(ev.AdminUsername, "wrong")
+
+	assert.Equal(t, http.StatusOK, usage(admin))
+	assert.Equal(t, http.StatusUnauthorized, usage(wrongPassword))
+	assert.Equal(
+		t,
+		http.StatusForbidden,
+		usage(WithBearerToken(login.AccessToken)),
+	)
+
+	var updated dto.UpdateUserRolesResponse
+	statusCode, rawBody, err := app.MakeRequest(
+		http.MethodPut,
+		"/api/v1/admin/users/"+registered.User.ID+"/roles",
+		admin,
+		WithBody(&dto.UpdateUserRolesRequest{
+			UpdateUserRolesUseCaseInput: &auth.UpdateUserRolesUseCaseInput{
+				Roles: []entity.Role{entity.RoleAdmin},
+			},
+		}),
+		WithResponse(&updated),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, statusCode, rawBody)
+	assert.Equal(t, []entity.Role{entity.RoleAdmin}, updated.User.Roles)
+
+	login = app.Login("johndoe@email.com", "P@ssw0rd")
+	assert.Equal(t, http.StatusOK, usage(WithBearerToken(login.AccessToken)))
+}
+
+func TestAdminRoutes_BasicAuth(t *testing.T) {
+	t.Parallel()
+
+	app, cleanUp := NewTestApp(t)
+	defer func() {
+		err := cleanUp(context.Background())
+		assert.Nil(t, err)
+	}()
+
+	admin := WithBasicAuth(ev.AdminUsername, ev.AdminPassword)
+
+	// Admin routes must not run the middleware of the groups registered
+	// before them, which would reject the Basic credentials.
+	routes := []string{
+		"/api/v1/admin/providers/usage",
+		"/api/v1/admin/cache/warmer",
+		"/api/v1/admin/cache/keys",
+		"/api/v1/admin/login-lockouts",
+		"/api/v1/admin/organizations",
+		"/api/v1/admin/audit-events",
+	}
+	for _, route := range routes {
+		statusCode, rawBody, err := app.MakeRequest(
+			http.MethodGet,
+			route,
+			admin,
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode, route+": "+rawBody)
+	}
+}
+
+func TestAdminRoutes_BasicAuthThrottled(t *testing.T) {
+	t.Parallel()
+
+	app, cleanUp := NewTestApp(t, func(e *env.Env) {
+		e.RateLimits = "ip=3/1m"
+	})
+	defer func() {
+		err := cleanUp(context.Background())
+		assert.Nil(t, err)
+	}()
+
+	usage := func() int {
+		statusCode, _, err := app.MakeRequest(
+			http.MethodGet,
+			"/api/v1/admin/providers/usage",
+			WithBasicAuth(ev.AdminUsername, "wrong"),
+		)
+		assert.Nil(t, err)
+		return statusCode
+	}
+
+	// Wrong credentials are counted before they are checked.
+	for range 3 {
+		assert.Equal(t, http.StatusUnauthorized, usage())
+	}
+	assert.Equal(t, http.StatusTooManyRequests, usage())
+}
+
+func TestAdminRoutes_BasicAuthDisabled(t *testing.T) {
+	t.Parallel()
+
+	app, cleanUp := NewTestApp(t, func(e *env.Env) {
+		e.AdminUsername = ""
+		e.AdminPassword = ""
+	})
+	defer func() {
+		err := cleanUp(context.Background())
+		assert.Nil(t, err)
+	}()
+
+	statusCode, rawBody, err := app.MakeRequest(
+		http.MethodGet,
+		"/api/v1/admin/providers/usage",
+		WithBasicAuth("", ""),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, statusCode, rawBody)
+}
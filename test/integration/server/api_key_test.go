@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielmesquitta/flight-api/internal/app/server/dto"
+	"github.com/danielmesquitta/flight-api/internal/app/server/middleware"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/apikey"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeys(t *testing.T) {
+	t.Parallel()
+
+	app, cleanUp := NewTestApp(t)
+	defer func() {
+		err := cleanUp(context.Background())
+		assert.Nil(t, err)
+	}()
+
+	app.Register("johndoe@email.com", "P@ssw0rd")
+	login := app.Login("johndoe@email.com", "P@ssw0rd")
+
+	var created dto.CreateAPIKeyResponse
+	statusCode, rawBody, err := app.MakeRequest(
+		http.MethodPost,
+		"/api/v1/api-keys",
+		WithBearerToken(login.AccessToken),
+		WithBody(&dto.CreateAPIKeyRequest{
+			CreateAPIKeyUseCaseInput: &apikey.CreateAPIKeyUseCaseInput{
+				Name:   "backend",
+				Scopes: []entity.Scope{entity.ScopeFlightsSearch},
+			},
+		}),
+		WithResponse(&created),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, statusCode, rawBody)
+	assert.NotEmpty(t, created.Key)
+
+	var listed dto.ListAPIKeysResponse
+	statusCode, rawBody, err = app.MakeRequest(
+		http.MethodGet,
+		"/api/v1/api-keys",
+		WithBearerToken(login.AccessToken),
+		WithResponse(&listed),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, statusCode, rawBody)
+	if assert.Len(t, listed.Data, 1) {
+		assert.Equal(t, created.APIKey.ID, listed.Data[0].ID)
+	}
+
+	// Without search parameters, authenticated requests are bad requests.
+	search := func() int {
+		statusCode, _, err := app.MakeRequest(
+			http.MethodGet,
+			"/api/v1/flights/search",
+			WithHeaders(map[string]string{
+				middleware.HeaderAPIKey: created.Key,
+			}),
+		)
+		assert.Nil(t, err)
+		return statusCode
+	}
+
+	assert.Equal(t, http.StatusBadRequest, search())
+
+	statusCode, rawBody, err = app.MakeRequest(
+		http.MethodDelete,
+		"/api/v1/api-keys/"+created.APIKey.ID,
+		WithBearerToken(login.AccessToken),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, statusCode, rawBody)
+
+	assert.Equal(t, http.StatusUnauthorized, search())
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielmesquitta/flight-api/internal/app/server/dto"
+	"github.com/danielmesquitta/flight-api/internal/app/server/handler"
+	"github.com/danielmesquitta/flight-api/internal/domain/entity"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditEvents(t *testing.T) {
+	t.Parallel()
+
+	app, cleanUp := NewTestApp(t)
+	defer func() {
+		err := cleanUp(context.Background())
+		assert.Nil(t, err)
+	}()
+
+	admin := WithBasicAuth(ev.AdminUsername, ev.AdminPassword)
+
+	registered := app.Register("johndoe@email.com", "P@ssw0rd")
+
+	statusCode, rawBody, err := app.MakeRequest(
+		http.MethodPost,
+		"/api/v1/auth/login",
+		WithBody(&dto.LoginRequest{
+			LoginUseCaseInput: &auth.LoginUseCaseInput{
+				Email:    "johndoe@email.com",
+				Password: "wrong",
+			},
+		}),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, statusCode, rawBody)
+
+	statusCode, rawBody, err = app.MakeRequest(
+		http.MethodDelete,
+		"/api/v1/admin/users/"+registered.User.ID+"/sessions",
+		admin,
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, statusCode, rawBody)
+
+	listAuditEvents := func(
+		queryParams map[string]string,
+	) []entity.AuditEvent {
+		var events dto.ListAuditEventsResponse
+		statusCode, rawBody, err := app.MakeRequest(
+			http.MethodGet,
+			"/api/v1/admin/audit-events",
+			admin,
+			WithQueryParams(queryParams),
+			WithResponse(&events),
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode, rawBody)
+		if events.ListAuditEventsUseCaseOutput == nil {
+			return nil
+		}
+		return events.Data
+	}
+
+	failures := listAuditEvents(map[string]string{
+		handler.QueryParamAction:  entity.AuditActionLogin,
+		handler.QueryParamOutcome: entity.AuditOutcomeFailure,
+	})
+	if assert.Len(t, failures, 1) {
+		assert.Equal(t, "email:johndoe@email.com", failures[0].Actor)
+		assert.NotEmpty(t, failures[0].IP)
+		assert.NotEmpty(t, failures[0].RequestID)
+	}
+
+	revocations := listAuditEvents(map[string]string{
+		handler.QueryParamActor: "admin:" + ev.AdminUsername,
+	})
+	if assert.Len(t, revocations, 1) {
+		assert.Equal(
+			t,
+			entity.AuditActionUserSessionsRevoke,
+			revocations[0].Action,
+		)
+		assert.Equal(t, entity.AuditOutcomeSuccess, revocations[0].Outcome)
+	}
+
+	statusCode, rawBody, err = app.MakeRequest(
+		http.MethodGet,
+		"/api/v1/admin/audit-events",
+		admin,
+		WithQueryParams(map[string]string{
+			handler.QueryParamOutcome: "unknown",
+		}),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusBadRequest, statusCode, rawBody)
+}
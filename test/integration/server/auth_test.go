@@ -2,11 +2,24 @@ package server
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
 	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/danielmesquitta/flight-api/internal/app/server/dto"
+	"github.com/danielmesquitta/flight-api/internal/config/env"
 	"github.com/danielmesquitta/flight-api/internal/domain/usecase/auth"
+	"github.com/danielmesquitta/flight-api/internal/provider/mailer/filemailer"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,6 +41,26 @@ func TestLogin(t *testing.T) {
 			},
 			expectedCode: http.StatusOK,
 		},
+		{
+			description: "fails with wrong password",
+			body: &dto.LoginRequest{
+				LoginUseCaseInput: &auth.LoginUseCaseInput{
+					Email:    "johndoe@email.com",
+					Password: "wrong",
+				},
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			description: "fails with unknown email",
+			body: &dto.LoginRequest{
+				LoginUseCaseInput: &auth.LoginUseCaseInput{
+					Email:    "janedoe@email.com",
+					Password: "P@ssw0rd",
+				},
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
 		{
 			description: "fails with invalid email",
 			body: &dto.LoginRequest{
@@ -70,6 +103,8 @@ func TestLogin(t *testing.T) {
 				assert.Nil(t, err)
 			}()
 
+			app.Register("johndoe@email.com", "P@ssw0rd")
+
 			var actual dto.LoginResponse
 			statusCode, rawBody, err := app.MakeRequest(
 				http.MethodPost,
@@ -91,6 +126,359 @@ func TestLogin(t *testing.T) {
 			}
 
 			assert.NotEmpty(t, actual.AccessToken)
+			assert.NotEmpty(t, actual.RefreshToken)
+		})
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	t.Parallel()
+
+	app, cleanUp := NewTestApp(t, func(e *env.Env) {
+		e.LoginDelay = time.Nanosecond
+		e.LoginMaxFailures = 2
+		e.RateLimits = "login=10/1m"
+	})
+	defer func() {
+		err := cleanUp(context.Background())
+		assert.Nil(t, err)
+	}()
+
+	app.Register("johndoe@email.com", "P@ssw0rd")
+
+	login := func(password string, opts ...RequestOption) (int, string) {
+		statusCode, rawBody, err := app.MakeRequest(
+			http.MethodPost,
+			"/api/v1/auth/login",
+			append(opts, WithBody(&dto.LoginRequest{
+				LoginUseCaseInput: &auth.LoginUseCaseInput{
+					Email:    "johndoe@email.com",
+					Password: password,
+				},
+			}))...,
+		)
+		assert.Nil(t, err)
+		return statusCode, rawBody
+	}
+
+	for range 2 {
+		statusCode, rawBody := login("wrong")
+		assert.Equal(t, http.StatusUnauthorized, statusCode, rawBody)
+	}
+
+	var headers http.Header
+	statusCode, rawBody := login("P@ssw0rd", WithResponseHeaders(&headers))
+	assert.Equal(t, http.StatusTooManyRequests, statusCode, rawBody)
+	assert.NotEmpty(t, headers.Get("Retry-After"))
+
+	admin := WithBasicAuth(ev.AdminUsername, ev.AdminPassword)
+
+	var lockouts dto.ListLoginLockoutsResponse
+	statusCode, rawBody, err := app.MakeRequest(
+		http.MethodGet,
+		"/api/v1/admin/login-lockouts",
+		admin,
+		WithResponse(&lockouts),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, statusCode, rawBody)
+	if assert.Len(t, lockouts.Data, 1) {
+		assert.Equal(t, "johndoe@email.com", lockouts.Data[0].Subject)
+	}
+
+	statusCode, rawBody, err = app.MakeRequest(
+		http.MethodDelete,
+		"/api/v1/admin/login-lockouts/account/johndoe@email.com",
+		admin,
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, statusCode, rawBody)
+
+	statusCode, rawBody = login("P@ssw0rd")
+	assert.Equal(t, http.StatusOK, statusCode, rawBody)
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description  string
+		body         *dto.RegisterRequest
+		expectedCode int
+	}{
+		{
+			description: "registers",
+			body: &dto.RegisterRequest{
+				RegisterUseCaseInput: &auth.RegisterUseCaseInput{
+					Email:    "janedoe@email.com",
+					Password: "P@ssw0rd",
+				},
+			},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			description: "fails with taken email",
+			body: &dto.RegisterRequest{
+				RegisterUseCaseInput: &auth.RegisterUseCaseInput{
+					Email:    "johndoe@email.com",
+					Password: "P@ssw0rd",
+				},
+			},
+			expectedCode: http.StatusConflict,
+		},
+		{
+			description: "fails with short password",
+			body: &dto.RegisterRequest{
+				RegisterUseCaseInput: &auth.RegisterUseCaseInput{
+					Email:    "janedoe@email.com",
+					Password: "short",
+				},
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			t.Parallel()
+
+			app, cleanUp := NewTestApp(t)
+			defer func() {
+				err := cleanUp(context.Background())
+				assert.Nil(t, err)
+			}()
+
+			app.Register("johndoe@email.com", "P@ssw0rd")
+
+			var actual dto.RegisterResponse
+			statusCode, rawBody, err := app.MakeRequest(
+				http.MethodPost,
+				"/api/v1/auth/register",
+				WithBody(test.body),
+				WithResponse(&actual),
+			)
+			assert.Nil(t, err)
+
+			assert.Equal(
+				t,
+				test.expectedCode,
+				statusCode,
+				rawBody,
+			)
+
+			if test.expectedCode != http.StatusCreated {
+				return
+			}
+
+			assert.Equal(t, test.body.Email, actual.User.Email)
 		})
 	}
 }
+
+func TestRefresh(t *testing.T) {
+	t.Parallel()
+
+	app, cleanUp := NewTestApp(t)
+	defer func() {
+		err := cleanUp(context.Background())
+		assert.Nil(t, err)
+	}()
+
+	app.Register("johndoe@email.com", "P@ssw0rd")
+	login := app.Login("johndoe@email.com", "P@ssw0rd")
+
+	refresh := func(refreshToken string) (int, *dto.RefreshResponse) {
+		var actual dto.RefreshResponse
+		statusCode, rawBody, err := app.MakeRequest(
+			http.MethodPost,
+			"/api/v1/auth/refresh",
+			WithBody(&dto.RefreshRequest{
+				RefreshUseCaseInput: &auth.RefreshUseCaseInput{
+					RefreshToken: refreshToken,
+				},
+			}),
+			WithResponse(&actual),
+		)
+		assert.Nil(t, err, rawBody)
+		return statusCode, &actual
+	}
+
+	statusCode, rotated := refresh(login.RefreshToken)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.NotEmpty(t, rotated.AccessToken)
+	assert.NotEqual(t, login.RefreshToken, rotated.RefreshToken)
+
+	statusCode, _ = refresh(login.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, statusCode)
+
+	statusCode, _ = refresh(rotated.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, statusCode)
+}
+
+func TestLogout(t *testing.T) {
+	t.Parallel()
+
+	app, cleanUp := NewTestApp(t)
+	defer func() {
+		err := cleanUp(context.Background())
+		assert.Nil(t, err)
+	}()
+
+	app.Register("johndoe@email.com", "P@ssw0rd")
+	login := app.Login("johndoe@email.com", "P@ssw0rd")
+
+	logout := func() int {
+		statusCode, rawBody, err := app.MakeRequest(
+			http.MethodPost,
+			"/api/v1/auth/logout",
+			WithBearerToken(login.AccessToken),
+		)
+		assert.Nil(t, err, rawBody)
+		return statusCode
+	}
+
+	assert.Equal(t, http.StatusNoContent, logout())
+	assert.Equal(t, http.StatusUnauthorized, logout())
+
+	statusCode, rawBody, err := app.MakeRequest(
+		http.MethodPost,
+		"/api/v1/auth/refresh",
+		WithBody(&dto.RefreshRequest{
+			RefreshUseCaseInput: &auth.RefreshUseCaseInput{
+				RefreshToken: login.RefreshToken,
+			},
+		}),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, statusCode, rawBody)
+}
+
+func TestJWKS(t *testing.T) {
+	t.Parallel()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	assert.Nil(t, err)
+	key := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	app, cleanUp := NewTestApp(t, func(e *env.Env) {
+		e.JWTSigningKeys = "test-1=" + strings.TrimSpace(string(key))
+	})
+	defer func() {
+		err := cleanUp(context.Background())
+		assert.Nil(t, err)
+	}()
+
+	app.Register("johndoe@email.com", "P@ssw0rd")
+	login := app.Login("johndoe@email.com", "P@ssw0rd")
+
+	var actual dto.JWKSResponse
+	statusCode, rawBody, err := app.MakeRequest(
+		http.MethodGet,
+		"/.well-known/jwks.json",
+		WithResponse(&actual),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, statusCode, rawBody)
+	assert.NotEmpty(t, actual.Keys)
+
+	_, err = jwt.Parse(login.AccessToken, func(token *jwt.Token) (any, error) {
+		for _, key := range actual.Keys {
+			if key.Kid == token.Header["kid"] && key.Crv == "Ed25519" {
+				x, err := base64.RawURLEncoding.DecodeString(key.X)
+				return ed25519.PublicKey(x), err
+			}
+		}
+		return nil, errors.New("key not found")
+	})
+	assert.Nil(t, err)
+}
+
+func TestPasswordReset(t *testing.T) {
+	t.Parallel()
+
+	mailPath := filepath.Join(t.TempDir(), "mail.jsonl")
+
+	app, cleanUp := NewTestApp(t, func(e *env.Env) {
+		e.MailerDriver = env.MailerDriverFile
+		e.MailerFilePath = mailPath
+		e.RateLimits = "login=10/1m"
+	})
+	defer func() {
+		err := cleanUp(context.Background())
+		assert.Nil(t, err)
+	}()
+
+	app.Register("johndoe@email.com", "P@ssw0rd")
+
+	statusCode, rawBody, err := app.MakeRequest(
+		http.MethodPost,
+		"/api/v1/auth/email/verify",
+		WithBody(&dto.VerifyEmailRequest{
+			VerifyEmailUseCaseInput: &auth.VerifyEmailUseCaseInput{
+				Token: lastMailToken(t, mailPath),
+			},
+		}),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, statusCode, rawBody)
+
+	messages, err := filemailer.Read(mailPath)
+	assert.Nil(t, err)
+
+	statusCode, rawBody, err = app.MakeRequest(
+		http.MethodPost,
+		"/api/v1/auth/password/forgot",
+		WithBody(&dto.ForgotPasswordRequest{
+			ForgotPasswordUseCaseInput: &auth.ForgotPasswordUseCaseInput{
+				Email: "johndoe@email.com",
+			},
+		}),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusAccepted, statusCode, rawBody)
+
+	// The reset e-mail is sent in the background.
+	assert.Eventually(t, func() bool {
+		sent, err := filemailer.Read(mailPath)
+		return err == nil && len(sent) > len(messages)
+	}, 5*time.Second, 50*time.Millisecond)
+
+	reset := func() int {
+		statusCode, rawBody, err := app.MakeRequest(
+			http.MethodPost,
+			"/api/v1/auth/password/reset",
+			WithBody(&dto.ResetPasswordRequest{
+				ResetPasswordUseCaseInput: &auth.ResetPasswordUseCaseInput{
+					Token:    lastMailToken(t, mailPath),
+					Password: "N3wP@ssw0rd",
+				},
+			}),
+		)
+		assert.Nil(t, err, rawBody)
+		return statusCode
+	}
+
+	assert.Equal(t, http.StatusNoContent, reset())
+	assert.Equal(t, http.StatusUnauthorized, reset())
+
+	app.Login("johndoe@email.com", "N3wP@ssw0rd")
+}
+
+// lastMailToken returns the token of the last e-mail sent.
+func lastMailToken(t *testing.T, mailPath string) string {
+	messages, err := filemailer.Read(mailPath)
+	assert.Nil(t, err)
+	if !assert.NotEmpty(t, messages) {
+		return ""
+	}
+
+	match := regexp.MustCompile(`token: (\S+)`).
+		FindStringSubmatch(messages[len(messages)-1].Body)
+	if !assert.Len(t, match, 2) {
+		return ""
+	}
+
+	return match[1]
+}
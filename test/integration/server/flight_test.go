@@ -57,6 +57,7 @@ func TestSearchFlights(t *testing.T) {
 			}
 
 			if test.isLoggedIn {
+				app.Register("johndoe@email.com", "P@ssw0rd")
 				loginRes := app.Login("johndoe@email.com", "P@ssw0rd")
 				opts = append(opts, WithBearerToken(loginRes.AccessToken))
 			}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/danielmesquitta/flight-api/internal/app/server/dto"
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/provider/idp/idptest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDCLogin(t *testing.T) {
+	t.Parallel()
+
+	idpServer := idptest.NewServer(t)
+
+	app, cleanUp := NewTestApp(t, func(e *env.Env) {
+		e.OIDCProviders = idpServer.Provider("acme")
+	})
+	defer func() {
+		err := cleanUp(context.Background())
+		assert.Nil(t, err)
+	}()
+
+	var headers http.Header
+	statusCode, rawBody, err := app.MakeRequest(
+		http.MethodGet,
+		"/api/v1/auth/oidc/acme/start",
+		WithResponseHeaders(&headers),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusFound, statusCode, rawBody)
+
+	code, state := idpServer.Authorize(t, headers.Get("Location"))
+
+	callback := func() (int, *dto.FinishOIDCLoginResponse) {
+		var actual dto.FinishOIDCLoginResponse
+		statusCode, rawBody, err := app.MakeRequest(
+			http.MethodGet,
+			"/api/v1/auth/oidc/acme/callback",
+			WithQueryParams(map[string]string{
+				"code":  code,
+				"state": state,
+			}),
+			WithResponse(&actual),
+		)
+		assert.Nil(t, err, rawBody)
+		return statusCode, &actual
+	}
+
+	statusCode, login := callback()
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.NotEmpty(t, login.AccessToken)
+	assert.NotEmpty(t, login.RefreshToken)
+
+	statusCode, _ = callback()
+	assert.Equal(t, http.StatusUnauthorized, statusCode)
+
+	statusCode, rawBody, err = app.MakeRequest(
+		http.MethodGet,
+		"/api/v1/auth/oidc/unknown/start",
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode, rawBody)
+
+	statusCode, rawBody, err = app.MakeRequest(
+		http.MethodPost,
+		"/api/v1/auth/logout",
+		WithBearerToken(login.AccessToken),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, statusCode, rawBody)
+}
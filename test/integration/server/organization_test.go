@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/app/server/dto"
+	"github.com/danielmesquitta/flight-api/internal/app/server/handler"
+	"github.com/danielmesquitta/flight-api/internal/config/env"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/organization"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizations(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	assert.Nil(t, err)
+
+	app, cleanUp := NewTestApp(t, func(e *env.Env) {
+		e.ProviderCredentialsKey = base64.StdEncoding.EncodeToString(key)
+	})
+	defer func() {
+		err := cleanUp(context.Background())
+		assert.Nil(t, err)
+	}()
+
+	admin := WithBasicAuth(ev.AdminUsername, ev.AdminPassword)
+
+	createOrganization := func(name string) (int, string, string) {
+		var created dto.CreateOrganizationResponse
+		statusCode, rawBody, err := app.MakeRequest(
+			http.MethodPost,
+			"/api/v1/admin/organizations",
+			admin,
+			WithBody(&dto.CreateOrganizationRequest{
+				CreateOrganizationUseCaseInput: &organization.
+					CreateOrganizationUseCaseInput{Name: name},
+			}),
+			WithResponse(&created),
+		)
+		assert.Nil(t, err)
+		if created.CreateOrganizationUseCaseOutput == nil {
+			return statusCode, rawBody, ""
+		}
+		return statusCode, rawBody, created.Organization.ID
+	}
+
+	statusCode, rawBody, orgID := createOrganization("Agency")
+	assert.Equal(t, http.StatusCreated, statusCode, rawBody)
+
+	statusCode, rawBody, _ = createOrganization("Agency")
+	assert.Equal(t, http.StatusConflict, statusCode, rawBody)
+
+	var orgs dto.ListOrganizationsResponse
+	statusCode, rawBody, err = app.MakeRequest(
+		http.MethodGet,
+		"/api/v1/admin/organizations",
+		admin,
+		WithResponse(&orgs),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, statusCode, rawBody)
+	assert.Len(t, orgs.Data, 1)
+
+	configureProvider := func(enabled bool, apiKey string) int {
+		statusCode, _, err := app.MakeRequest(
+			http.MethodPut,
+			"/api/v1/admin/organizations/"+orgID+"/providers/duffel",
+			admin,
+			WithBody(&dto.ConfigureProviderRequest{
+				ConfigureProviderUseCaseInput: &organization.
+					ConfigureProviderUseCaseInput{
+					Enabled: enabled,
+					APIKey:  apiKey,
+				},
+			}),
+		)
+		assert.Nil(t, err)
+		return statusCode
+	}
+
+	assert.Equal(t, http.StatusBadRequest, configureProvider(true, ""))
+	assert.Equal(t, http.StatusOK, configureProvider(false, "duffel-key"))
+
+	var providers dto.ListOrganizationProvidersResponse
+	statusCode, rawBody, err = app.MakeRequest(
+		http.MethodGet,
+		"/api/v1/admin/organizations/"+orgID+"/providers",
+		admin,
+		WithResponse(&providers),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, statusCode, rawBody)
+	assert.Len(t, providers.Data, 1)
+	assert.NotContains(t, rawBody, "duffel-key")
+
+	registered := app.Register("johndoe@email.com", "P@ssw0rd")
+
+	var updated dto.UpdateUserOrganizationResponse
+	statusCode, rawBody, err = app.MakeRequest(
+		http.MethodPut,
+		"/api/v1/admin/users/"+registered.User.ID+"/organization",
+		admin,
+		WithBody(&dto.UpdateUserOrganizationRequest{
+			UpdateUserOrganizationUseCaseInput: &organization.
+				UpdateUserOrganizationUseCaseInput{OrganizationID: orgID},
+		}),
+		WithResponse(&updated),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, statusCode, rawBody)
+	assert.Equal(t, orgID, updated.User.OrganizationID)
+
+	// The organization has no provider enabled, so its users can't
+	// search with the providers of the default tenant.
+	login := app.Login("johndoe@email.com", "P@ssw0rd")
+	statusCode, rawBody, err = app.MakeRequest(
+		http.MethodGet,
+		"/api/v1/flights/search",
+		WithBearerToken(login.AccessToken),
+		WithQueryParams(map[string]string{
+			handler.QueryParamOrigin:      "SYD",
+			handler.QueryParamDestination: "BKK",
+			handler.QueryParamDate: time.Now().
+				AddDate(0, 3, 0).
+				Format(time.DateOnly),
+		}),
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNotFound, statusCode, rawBody)
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/app/server/dto"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/pricealert"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriceAlerts(t *testing.T) {
+	t.Parallel()
+
+	app, cleanUp := NewTestApp(t)
+	defer func() {
+		err := cleanUp(context.Background())
+		assert.Nil(t, err)
+	}()
+
+	app.Register("johndoe@email.com", "P@ssw0rd")
+	johnDoe := WithBearerToken(
+		app.Login("johndoe@email.com", "P@ssw0rd").AccessToken,
+	)
+	app.Register("janedoe@email.com", "P@ssw0rd")
+	janeDoe := WithBearerToken(
+		app.Login("janedoe@email.com", "P@ssw0rd").AccessToken,
+	)
+
+	createPriceAlert := func(
+		date time.Time,
+		targetPrice int64,
+	) (int, string, string) {
+		var created dto.CreatePriceAlertResponse
+		statusCode, rawBody, err := app.MakeRequest(
+			http.MethodPost,
+			"/api/v1/price-alerts",
+			johnDoe,
+			WithBody(&dto.CreatePriceAlertRequest{
+				CreatePriceAlertUseCaseInput: &pricealert.
+					CreatePriceAlertUseCaseInput{
+					Origin:      "SYD",
+					Destination: "BKK",
+					Date:        date.Format(time.DateOnly),
+					TargetPrice: targetPrice,
+				},
+			}),
+			WithResponse(&created),
+		)
+		assert.Nil(t, err)
+		if created.CreatePriceAlertUseCaseOutput == nil {
+			return statusCode, rawBody, ""
+		}
+		return statusCode, rawBody, created.PriceAlert.ID
+	}
+
+	statusCode, rawBody, alertID := createPriceAlert(
+		time.Now().AddDate(0, 3, 0),
+		50000,
+	)
+	assert.Equal(t, http.StatusCreated, statusCode, rawBody)
+
+	statusCode, rawBody, _ = createPriceAlert(
+		time.Now().AddDate(0, 0, -2),
+		50000,
+	)
+	assert.Equal(t, http.StatusBadRequest, statusCode, rawBody)
+
+	statusCode, rawBody, _ = createPriceAlert(time.Now().AddDate(0, 3, 0), 0)
+	assert.Equal(t, http.StatusBadRequest, statusCode, rawBody)
+
+	listPriceAlerts := func(opt RequestOption) int {
+		var alerts dto.ListPriceAlertsResponse
+		statusCode, rawBody, err := app.MakeRequest(
+			http.MethodGet,
+			"/api/v1/price-alerts",
+			opt,
+			WithResponse(&alerts),
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode, rawBody)
+		return len(alerts.Data)
+	}
+
+	assert.Equal(t, 1, listPriceAlerts(johnDoe))
+	assert.Equal(t, 0, listPriceAlerts(janeDoe))
+
+	listPriceAlertChecks := func(opt RequestOption) int {
+		statusCode, _, err := app.MakeRequest(
+			http.MethodGet,
+			"/api/v1/price-alerts/"+alertID+"/checks",
+			opt,
+		)
+		assert.Nil(t, err)
+		return statusCode
+	}
+
+	assert.Equal(t, http.StatusOK, listPriceAlertChecks(johnDoe))
+	assert.Equal(t, http.StatusNotFound, listPriceAlertChecks(janeDoe))
+
+	deletePriceAlert := func(opt RequestOption) int {
+		statusCode, _, err := app.MakeRequest(
+			http.MethodDelete,
+			"/api/v1/price-alerts/"+alertID,
+			opt,
+		)
+		assert.Nil(t, err)
+		return statusCode
+	}
+
+	assert.Equal(t, http.StatusNotFound, deletePriceAlert(janeDoe))
+	assert.Equal(t, http.StatusNoContent, deletePriceAlert(johnDoe))
+	assert.Equal(t, 0, listPriceAlerts(johnDoe))
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danielmesquitta/flight-api/internal/app/server/dto"
+	"github.com/danielmesquitta/flight-api/internal/domain/usecase/savedsearch"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedSearches(t *testing.T) {
+	t.Parallel()
+
+	app, cleanUp := NewTestApp(t)
+	defer func() {
+		err := cleanUp(context.Background())
+		assert.Nil(t, err)
+	}()
+
+	app.Register("johndoe@email.com", "P@ssw0rd")
+	johnDoe := WithBearerToken(
+		app.Login("johndoe@email.com", "P@ssw0rd").AccessToken,
+	)
+	app.Register("janedoe@email.com", "P@ssw0rd")
+	janeDoe := WithBearerToken(
+		app.Login("janedoe@email.com", "P@ssw0rd").AccessToken,
+	)
+
+	createSavedSearch := func(date string) (int, string, string) {
+		var created dto.CreateSavedSearchResponse
+		statusCode, rawBody, err := app.MakeRequest(
+			http.MethodPost,
+			"/api/v1/saved-searches",
+			johnDoe,
+			WithBody(&dto.CreateSavedSearchRequest{
+				CreateSavedSearchUseCaseInput: &savedsearch.
+					CreateSavedSearchUseCaseInput{
+					Name:        "Sydney to Bangkok " + date,
+					Origin:      "SYD",
+					Destination: "BKK",
+					Date:        date,
+				},
+			}),
+			WithResponse(&created),
+		)
+		assert.Nil(t, err)
+		if created.CreateSavedSearchUseCaseOutput == nil {
+			return statusCode, rawBody, ""
+		}
+		return statusCode, rawBody, created.SavedSearch.ID
+	}
+
+	statusCode, rawBody, searchID := createSavedSearch("+90d")
+	assert.Equal(t, http.StatusCreated, statusCode, rawBody)
+
+	statusCode, rawBody, _ = createSavedSearch("+90d")
+	assert.Equal(t, http.StatusConflict, statusCode, rawBody)
+
+	statusCode, rawBody, _ = createSavedSearch("someday")
+	assert.Equal(t, http.StatusBadRequest, statusCode, rawBody)
+
+	listSavedSearches := func(opt RequestOption) int {
+		var searches dto.ListSavedSearchesResponse
+		statusCode, rawBody, err := app.MakeRequest(
+			http.MethodGet,
+			"/api/v1/saved-searches",
+			opt,
+			WithResponse(&searches),
+		)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, statusCode, rawBody)
+		return len(searches.Data)
+	}
+
+	assert.Equal(t, 1, listSavedSearches(johnDoe))
+	assert.Equal(t, 0, listSavedSearches(janeDoe))
+
+	runSavedSearch := func(
+		opt RequestOption,
+	) (int, string, *dto.RunSavedSearchResponse) {
+		var out dto.RunSavedSearchResponse
+		statusCode, rawBody, err := app.MakeRequest(
+			http.MethodPost,
+			"/api/v1/saved-searches/"+searchID+"/run",
+			opt,
+			WithResponse(&out),
+		)
+		assert.Nil(t, err)
+		return statusCode, rawBody, &out
+	}
+
+	statusCode, rawBody, out := runSavedSearch(johnDoe)
+	assert.Equal(t, http.StatusOK, statusCode, rawBody)
+	if assert.NotNil(t, out.RunSavedSearchUseCaseOutput) {
+		assert.Equal(
+			t,
+			time.Now().UTC().AddDate(0, 0, 90).Format(time.DateOnly),
+			out.Date.Format(time.DateOnly),
+		)
+		assert.NotEmpty(t, out.Data)
+	}
+
+	statusCode, rawBody, _ = runSavedSearch(janeDoe)
+	assert.Equal(t, http.StatusNotFound, statusCode, rawBody)
+
+	deleteSavedSearch := func(opt RequestOption) int {
+		statusCode, _, err := app.MakeRequest(
+			http.MethodDelete,
+			"/api/v1/saved-searches/"+searchID,
+			opt,
+		)
+		assert.Nil(t, err)
+		return statusCode
+	}
+
+	assert.Equal(t, http.StatusNotFound, deleteSavedSearch(janeDoe))
+	assert.Equal(t, http.StatusNoContent, deleteSavedSearch(johnDoe))
+	assert.Equal(t, 0, listSavedSearches(johnDoe))
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -39,10 +40,17 @@ func init() {
 
 	vl = validator.New()
 	ev = config.LoadConfig(vl)
+
+	// Admin credentials aren't configured by default.
+	ev.AdminUsername = "admin"
+	ev.AdminPassword = "adminpassword"
 }
 
+// NewTestApp starts an app for the test, with its env changed by the
+// given options.
 func NewTestApp(
 	t *testing.T,
+	opts ...func(*env.Env),
 ) (app *TestApp, cleanUp func(context.Context) error) {
 	wg.Wait()
 	v := vl
@@ -78,6 +86,10 @@ func NewTestApp(
 	}
 
 	e.RedisDatabaseURL = redisDatabaseURL
+	e.DatabaseDriver = env.DatabaseDriverInMemory
+	for _, opt := range opts {
+		opt(&e)
+	}
 
 	restAPI := server.NewTest(v, &e, t)
 
@@ -93,13 +105,14 @@ func NewTestApp(
 type RequestOption func(*requestOptions)
 
 type requestOptions struct {
-	body          any
-	token         string
-	bearerToken   string
-	headers       map[string]string
-	queryParams   map[string]string
-	response      any
-	errorResponse any
+	body            any
+	token           string
+	bearerToken     string
+	headers         map[string]string
+	queryParams     map[string]string
+	response        any
+	errorResponse   any
+	responseHeaders *http.Header
 }
 
 // WithBody sets the body for the request
@@ -123,6 +136,12 @@ func WithBearerToken(bearerToken string) RequestOption {
 	}
 }
 
+// WithBasicAuth sets basic authorization credentials for the request
+func WithBasicAuth(username, password string) RequestOption {
+	credentials := []byte(username + ":" + password)
+	return WithToken("Basic " + base64.StdEncoding.EncodeToString(credentials))
+}
+
 // WithHeaders sets additional headers for the request
 func WithHeaders(headers map[string]string) RequestOption {
 	return func(o *requestOptions) {
@@ -137,6 +156,14 @@ func WithResponse(response any) RequestOption {
 	}
 }
 
+// WithResponseHeaders sets the headers object to copy the response
+// headers into
+func WithResponseHeaders(headers *http.Header) RequestOption {
+	return func(o *requestOptions) {
+		o.responseHeaders = headers
+	}
+}
+
 // WithError sets the error response object to unmarshal into
 func WithError(errorResponse any) RequestOption {
 	return func(o *requestOptions) {
@@ -203,6 +230,10 @@ func (ta *TestApp) MakeRequest(
 	res, err := ta.a.Test(req, -1)
 	assert.Nil(ta.t, err)
 
+	if options.responseHeaders != nil {
+		*options.responseHeaders = res.Header
+	}
+
 	bytesBody, _ := io.ReadAll(res.Body)
 	if len(bytesBody) == 0 {
 		return res.StatusCode, "", nil
@@ -218,6 +249,27 @@ func (ta *TestApp) MakeRequest(
 	return res.StatusCode, string(bytesBody), nil
 }
 
+func (ta *TestApp) Register(email, password string) *dto.RegisterResponse {
+	body := dto.RegisterRequest{
+		RegisterUseCaseInput: &auth.RegisterUseCaseInput{
+			Email:    email,
+			Password: password,
+		},
+	}
+
+	var out dto.RegisterResponse
+	statusCode, _, err := ta.MakeRequest(
+		http.MethodPost,
+		"/api/v1/auth/register",
+		WithBody(body),
+		WithResponse(&out),
+	)
+	assert.Nil(ta.t, err)
+	assert.Equal(ta.t, http.StatusCreated, statusCode)
+
+	return &out
+}
+
 func (ta *TestApp) Login(email, password string) *dto.LoginResponse {
 	body := dto.LoginRequest{
 		LoginUseCaseInput: &auth.LoginUseCaseInput{